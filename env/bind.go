@@ -0,0 +1,286 @@
+package lxenv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidTarget = errors.New("lxenv: bind target must be a non-nil pointer to a struct")
+)
+
+const (
+	tagEnv          = "env"
+	tagDefault      = "default"
+	tagRequired     = "required"
+	tagEnvPrefix    = "envPrefix"
+	tagEnvSeparator = "envSeparator"
+
+	defaultSeparator  = ","
+	keyValueSeparator = "="
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// BindError aggregates every failure encountered while binding a struct.
+// It matches ErrKeyNotFound and ErrInvalidValue with errors.Is when any of
+// its underlying errors do.
+type BindError struct {
+	Errors []error
+}
+
+// Error joins the underlying error messages with "; ".
+func (e *BindError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the underlying errors.
+func (e *BindError) Unwrap() []error {
+	return e.Errors
+}
+
+// Is reports whether any underlying error matches target.
+func (e *BindError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Bind populates the struct pointed to by v from environment variables.
+// Fields are selected with the following struct tags:
+//
+//   - env:"DB_HOST"         name of the environment variable
+//   - default:"localhost"   value used when the variable is unset or empty
+//   - required:"true"       the variable must be set
+//   - envPrefix:"DB_"       prefix applied to the env names of a nested struct
+//   - envSeparator:";"      separator for slice and map values (default ",")
+//
+// Supported field types are string, bool, all int/uint/float kinds,
+// time.Duration (with the extended units of GetDuration), types with a decoder
+// registered with RegisterDecoder or implementing encoding.TextUnmarshaler,
+// slices of those types and maps of those types written as "k1=v1,k2=v2".
+// Nested structs and pointers to structs are walked recursively.
+//
+// Binding does not stop at the first failure: every missing required key and
+// every unparsable value is collected into a single *BindError.
+//
+// Example:
+//
+//	type Config struct {
+//	    Host    string        `env:"HOST" default:"localhost"`
+//	    Port    int           `env:"PORT" required:"true"`
+//	    Timeout time.Duration `env:"TIMEOUT" default:"30s"`
+//	    DB      struct {
+//	        Name string `env:"NAME"`
+//	    } `envPrefix:"DB_"`
+//	}
+//
+//	var cfg Config
+//	if err := lxenv.Bind(&cfg); err != nil {
+//	    log.Fatal(err)
+//	}
+func Bind(v any) error {
+	return bind(v, Lookup)
+}
+
+// bind binds the struct pointed to by v, reading values with lookup.
+func bind(v any, lookup func(string) (string, bool)) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+
+	b := &binder{lookup: lookup}
+	b.bindStruct(rv.Elem(), "")
+	return b.err()
+}
+
+// binder walks a struct and records every failure it encounters.
+type binder struct {
+	lookup  func(string) (string, bool)
+	missing []string
+	invalid []error
+}
+
+func (b *binder) bindStruct(rv reflect.Value, prefix string) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+
+		key, hasKey := field.Tag.Lookup(tagEnv)
+		if !hasKey {
+			b.bindNested(fv, field, prefix)
+			continue
+		}
+
+		b.bindField(fv, field, prefix+key)
+	}
+}
+
+// bindNested recurses into struct and pointer-to-struct fields that have no env tag.
+func (b *binder) bindNested(fv reflect.Value, field reflect.StructField, prefix string) {
+	nestedPrefix := prefix + field.Tag.Get(tagEnvPrefix)
+
+	switch {
+	case fv.Kind() == reflect.Struct:
+		b.bindStruct(fv, nestedPrefix)
+	case fv.Kind() == reflect.Pointer && fv.Type().Elem().Kind() == reflect.Struct:
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		b.bindStruct(fv.Elem(), nestedPrefix)
+	}
+}
+
+func (b *binder) bindField(fv reflect.Value, field reflect.StructField, key string) {
+	value, exists := b.lookup(key)
+	if !exists || value == "" {
+		if def, ok := field.Tag.Lookup(tagDefault); ok {
+			value = def
+		} else if !exists && field.Tag.Get(tagRequired) == "true" {
+			b.missing = append(b.missing, key)
+			return
+		}
+	}
+	if value == "" {
+		return
+	}
+	value, _, err := resolveForGet(value)
+	if err != nil {
+		b.invalid = append(b.invalid, fmt.Errorf("%s: %w", key, err))
+		return
+	}
+
+	sep := field.Tag.Get(tagEnvSeparator)
+	if sep == "" {
+		sep = defaultSeparator
+	}
+
+	if err := setValue(fv, value, sep); err != nil {
+		b.invalid = append(b.invalid, fmt.Errorf("%w: %s=%q: %v", ErrInvalidValue, key, value, err))
+	}
+}
+
+func (b *binder) err() error {
+	var errs []error
+	if err := missingKeysError(b.missing); err != nil {
+		errs = append(errs, err)
+	}
+	errs = append(errs, b.invalid...)
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return &BindError{Errors: errs}
+}
+
+// setValue parses raw into fv, allocating pointers and splitting slices and maps on sep.
+func setValue(fv reflect.Value, raw, sep string) error {
+	if ok, err := decodeCustom(fv, raw); ok {
+		return err
+	}
+
+	switch fv.Kind() {
+	case reflect.Pointer:
+		ptr := reflect.New(fv.Type().Elem())
+		if err := setValue(ptr.Elem(), raw, sep); err != nil {
+			return err
+		}
+		fv.Set(ptr)
+		return nil
+
+	case reflect.Slice:
+		parts := strings.Split(raw, sep)
+		slice := reflect.MakeSlice(fv.Type(), 0, len(parts))
+		for _, part := range parts {
+			elem := reflect.New(fv.Type().Elem()).Elem()
+			if err := setScalar(elem, strings.TrimSpace(part)); err != nil {
+				return err
+			}
+			slice = reflect.Append(slice, elem)
+		}
+		fv.Set(slice)
+		return nil
+
+	case reflect.Map:
+		m := reflect.MakeMap(fv.Type())
+		for _, part := range strings.Split(raw, sep) {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			idx := strings.Index(part, keyValueSeparator)
+			if idx < 0 {
+				return fmt.Errorf("missing %q in map entry %q", keyValueSeparator, part)
+			}
+			k := reflect.New(fv.Type().Key()).Elem()
+			if err := setScalar(k, strings.TrimSpace(part[:idx])); err != nil {
+				return err
+			}
+			v := reflect.New(fv.Type().Elem()).Elem()
+			if err := setScalar(v, strings.TrimSpace(part[idx+1:])); err != nil {
+				return err
+			}
+			m.SetMapIndex(k, v)
+		}
+		fv.Set(m)
+		return nil
+	}
+
+	return setScalar(fv, raw)
+}
+
+// setScalar parses raw into a string, bool or numeric value, or any type
+// handled by decodeCustom such as time.Duration.
+func setScalar(fv reflect.Value, raw string) error {
+	if ok, err := decodeCustom(fv, raw); ok {
+		return err
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(parsed)
+	default:
+		return fmt.Errorf("%w %s", ErrUnsupportedType, fv.Type())
+	}
+	return nil
+}
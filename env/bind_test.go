@@ -0,0 +1,163 @@
+package lxenv_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hgapdvn/lx/env"
+)
+
+type bindDBConfig struct {
+	Host string `env:"HOST" default:"localhost"`
+	Port int    `env:"PORT" default:"5432"`
+}
+
+type bindConfig struct {
+	Name     string            `env:"TEST_BIND_NAME" required:"true"`
+	Debug    bool              `env:"TEST_BIND_DEBUG"`
+	Workers  uint8             `env:"TEST_BIND_WORKERS" default:"4"`
+	Ratio    float64           `env:"TEST_BIND_RATIO"`
+	Timeout  time.Duration     `env:"TEST_BIND_TIMEOUT" default:"1d 2h"`
+	Tags     []string          `env:"TEST_BIND_TAGS"`
+	Ports    []int             `env:"TEST_BIND_PORTS" envSeparator:";"`
+	Labels   map[string]string `env:"TEST_BIND_LABELS"`
+	Optional *int              `env:"TEST_BIND_OPTIONAL"`
+	DB       bindDBConfig      `envPrefix:"TEST_BIND_DB_"`
+	Cache    *bindDBConfig     `envPrefix:"TEST_BIND_CACHE_"`
+	ignored  string            `env:"TEST_BIND_IGNORED"` //nolint:unused
+}
+
+func TestBind(t *testing.T) {
+	t.Setenv("TEST_BIND_NAME", "lx")
+	t.Setenv("TEST_BIND_DEBUG", "true")
+	t.Setenv("TEST_BIND_RATIO", "0.75")
+	t.Setenv("TEST_BIND_TAGS", "a, b ,c")
+	t.Setenv("TEST_BIND_PORTS", "80;443")
+	t.Setenv("TEST_BIND_LABELS", "env=prod,team=core")
+	t.Setenv("TEST_BIND_OPTIONAL", "7")
+	t.Setenv("TEST_BIND_DB_HOST", "db.internal")
+	t.Setenv("TEST_BIND_CACHE_PORT", "6379")
+	t.Setenv("TEST_BIND_IGNORED", "x")
+
+	var cfg bindConfig
+	if err := lxenv.Bind(&cfg); err != nil {
+		t.Fatalf("Bind() unexpected error: %v", err)
+	}
+
+	optional := 7
+	want := bindConfig{
+		Name:     "lx",
+		Debug:    true,
+		Workers:  4,
+		Ratio:    0.75,
+		Timeout:  26 * time.Hour,
+		Tags:     []string{"a", "b", "c"},
+		Ports:    []int{80, 443},
+		Labels:   map[string]string{"env": "prod", "team": "core"},
+		Optional: &optional,
+		DB:       bindDBConfig{Host: "db.internal", Port: 5432},
+		Cache:    &bindDBConfig{Host: "localhost", Port: 6379},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Bind() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestBind_EmptyValueUsesDefault(t *testing.T) {
+	t.Setenv("TEST_BIND_NAME", "lx")
+	t.Setenv("TEST_BIND_WORKERS", "")
+
+	var cfg bindConfig
+	if err := lxenv.Bind(&cfg); err != nil {
+		t.Fatalf("Bind() unexpected error: %v", err)
+	}
+	if cfg.Workers != 4 {
+		t.Errorf("Workers = %d, want 4", cfg.Workers)
+	}
+}
+
+func TestBind_AggregatesErrors(t *testing.T) {
+	type config struct {
+		A string  `env:"TEST_BIND_AGG_A" required:"true"`
+		B string  `env:"TEST_BIND_AGG_B" required:"true"`
+		C int     `env:"TEST_BIND_AGG_C"`
+		D bool    `env:"TEST_BIND_AGG_D"`
+		E uint8   `env:"TEST_BIND_AGG_E"`
+		F []int   `env:"TEST_BIND_AGG_F"`
+		G float32 `env:"TEST_BIND_AGG_G" default:"oops"`
+	}
+
+	t.Setenv("TEST_BIND_AGG_C", "abc")
+	t.Setenv("TEST_BIND_AGG_D", "maybe")
+	t.Setenv("TEST_BIND_AGG_E", "300")
+	t.Setenv("TEST_BIND_AGG_F", "1,x")
+
+	var cfg config
+	err := lxenv.Bind(&cfg)
+	if err == nil {
+		t.Fatal("Bind() expected error, got nil")
+	}
+
+	if !errors.Is(err, lxenv.ErrKeyNotFound) {
+		t.Errorf("Bind() error should match ErrKeyNotFound, got: %v", err)
+	}
+	if !errors.Is(err, lxenv.ErrInvalidValue) {
+		t.Errorf("Bind() error should match ErrInvalidValue, got: %v", err)
+	}
+
+	var bindErr *lxenv.BindError
+	if !errors.As(err, &bindErr) {
+		t.Fatalf("Bind() error should be *BindError, got %T", err)
+	}
+	if len(bindErr.Errors) != 6 {
+		t.Errorf("len(BindError.Errors) = %d, want 6: %v", len(bindErr.Errors), err)
+	}
+
+	for _, key := range []string{
+		"TEST_BIND_AGG_A", "TEST_BIND_AGG_B", "TEST_BIND_AGG_C",
+		"TEST_BIND_AGG_D", "TEST_BIND_AGG_E", "TEST_BIND_AGG_F", "TEST_BIND_AGG_G",
+	} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("error %q did not mention key %q", err, key)
+		}
+	}
+}
+
+func TestBind_InvalidTarget(t *testing.T) {
+	var cfg bindConfig
+	var nilCfg *bindConfig
+	n := 1
+
+	tests := []struct {
+		name   string
+		target any
+	}{
+		{"nil", nil},
+		{"struct value", cfg},
+		{"nil pointer", nilCfg},
+		{"pointer to non-struct", &n},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := lxenv.Bind(tt.target); !errors.Is(err, lxenv.ErrInvalidTarget) {
+				t.Errorf("Bind(%v) = %v, want ErrInvalidTarget", tt.target, err)
+			}
+		})
+	}
+}
+
+func TestBind_UnsupportedType(t *testing.T) {
+	type config struct {
+		C chan int `env:"TEST_BIND_UNSUPPORTED"`
+	}
+	t.Setenv("TEST_BIND_UNSUPPORTED", "x")
+
+	var cfg config
+	if err := lxenv.Bind(&cfg); !errors.Is(err, lxenv.ErrInvalidValue) {
+		t.Errorf("Bind() = %v, want ErrInvalidValue", err)
+	}
+}
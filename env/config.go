@@ -0,0 +1,540 @@
+package lxenv
+
+import (
+	"io"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Config is an immutable snapshot of configuration values held in memory.
+// Its getters mirror the package-level ones but read from the snapshot
+// instead of the process environment, so several configs can be used side
+// by side and tests can run in parallel.
+//
+// A nil *Config behaves like an empty one.
+//
+// Example:
+//
+//	cfg, err := lxenv.ReadEnv("tenant-a.env")
+//	if err != nil {
+//	    return err
+//	}
+//	port := cfg.GetIntOr("PORT", 8080)
+type Config struct {
+	values  map[string]string
+	origins map[string]Provenance
+}
+
+// NewConfig creates a Config holding a copy of values.
+//
+// Example:
+//
+//	cfg := lxenv.NewConfig(map[string]string{"PORT": "8080"})
+//	port, _ := cfg.GetInt("PORT")
+func NewConfig(values map[string]string) *Config {
+	copied := make(map[string]string, len(values))
+	for k, v := range values {
+		copied[k] = v
+	}
+	return &Config{values: copied}
+}
+
+// Get returns the value of key, or an empty string if it is not set.
+// Value references are resolved as in Get when ResolveOnGet is enabled.
+func (c *Config) Get(key string) string {
+	value, _ := c.Lookup(key)
+	value, _, err := resolveForGet(value)
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+// GetOr returns the value of key if set and non-empty, otherwise defaultValue.
+func (c *Config) GetOr(key string, defaultValue string) string {
+	if value := c.Get(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGet returns the value of key, resolving value references like Get.
+// Panics if the key is not set or its reference cannot be resolved.
+func (c *Config) MustGet(key string) string {
+	value, ok := c.Lookup(key)
+	if !ok {
+		panic("lxenv: config key " + key + " is not set")
+	}
+	value, _, err := resolveForGet(value)
+	if err != nil {
+		panic("lxenv: config key " + key + ": " + err.Error())
+	}
+	return value
+}
+
+// Lookup returns the value of key and reports whether it was set.
+// Unlike Get, this distinguishes between empty and unset keys.
+// Dot- and kebab-notation keys fall back to their UpperSnakeCase name, as in Lookup.
+func (c *Config) Lookup(key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	return lookupMapped(key, c.rawLookup)
+}
+
+// rawLookup looks key up without the UpperSnakeCase fallback.
+func (c *Config) rawLookup(key string) (string, bool) {
+	value, ok := c.values[key]
+	return value, ok
+}
+
+// Has reports whether key is set (even if empty).
+func (c *Config) Has(key string) bool {
+	_, ok := c.Lookup(key)
+	return ok
+}
+
+// GetInt returns the value of key as an integer, decoded like GetInt,
+// including with a decoder registered for int.
+// Returns (0, false) if the key is not set or cannot be parsed.
+func (c *Config) GetInt(key string) (int, bool) {
+	return configAs[int](c, key)
+}
+
+// GetIntOr returns the value of key as an integer, or defaultValue if it is not set or invalid.
+func (c *Config) GetIntOr(key string, defaultValue int) int {
+	if value, ok := c.GetInt(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetInt returns the value of key as an integer.
+// Panics if the key is not set or cannot be parsed as an integer.
+func (c *Config) MustGetInt(key string) int {
+	value, ok := c.GetInt(key)
+	if !ok {
+		panic("lxenv: config key " + key + " is not set or not a valid integer")
+	}
+	return value
+}
+
+// GetBool returns the value of key as a boolean, using the same rules and
+// registered decoder as GetBool.
+// Returns (false, false) if the key is not set or cannot be parsed.
+func (c *Config) GetBool(key string) (bool, bool) {
+	return configAs[bool](c, key)
+}
+
+// GetBoolOr returns the value of key as a boolean, or defaultValue if it is not set or invalid.
+func (c *Config) GetBoolOr(key string, defaultValue bool) bool {
+	if value, ok := c.GetBool(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetBool returns the value of key as a boolean.
+// Panics if the key is not set or cannot be parsed as a boolean.
+func (c *Config) MustGetBool(key string) bool {
+	value, ok := c.GetBool(key)
+	if !ok {
+		panic("lxenv: config key " + key + " is not set or not a valid boolean")
+	}
+	return value
+}
+
+// GetFloat returns the value of key as a float64, decoded like GetFloat,
+// including with a decoder registered for float64.
+// Returns (0, false) if the key is not set or cannot be parsed.
+func (c *Config) GetFloat(key string) (float64, bool) {
+	return configAs[float64](c, key)
+}
+
+// GetFloatOr returns the value of key as a float64, or defaultValue if it is not set or invalid.
+func (c *Config) GetFloatOr(key string, defaultValue float64) float64 {
+	if v, ok := c.GetFloat(key); ok {
+		return v
+	}
+	return defaultValue
+}
+
+// MustGetFloat returns the value of key as a float64.
+// Panics if the key is not set or cannot be parsed as a float64.
+func (c *Config) MustGetFloat(key string) float64 {
+	if v, ok := c.GetFloat(key); ok {
+		return v
+	}
+	if c.Has(key) {
+		panic("lxenv: config key " + key + " is not a valid float")
+	}
+	panic("lxenv: config key " + key + " is not set")
+}
+
+// GetDuration returns the value of key as a duration, using the extended units
+// and registered decoder of GetDuration.
+// Returns (0, false) if the key is not set or cannot be parsed.
+func (c *Config) GetDuration(key string) (time.Duration, bool) {
+	return configAs[time.Duration](c, key)
+}
+
+// GetDurationOr returns the value of key as a duration, or defaultValue if it is not set or invalid.
+func (c *Config) GetDurationOr(key string, defaultValue time.Duration) time.Duration {
+	if value, ok := c.GetDuration(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetDuration returns the value of key as a duration.
+// Panics if the key is not set or cannot be parsed as a duration.
+func (c *Config) MustGetDuration(key string) time.Duration {
+	value, ok := c.GetDuration(key)
+	if !ok {
+		panic("lxenv: config key " + key + " is not set or not a valid duration")
+	}
+	return value
+}
+
+// GetPeriod returns the value of key as a calendar period, using the same
+// formats as GetPeriod.
+// Returns (Period{}, false) if the key is not set or cannot be parsed.
+func (c *Config) GetPeriod(key string) (Period, bool) {
+	return periodValue(c.Get(key))
+}
+
+// GetPeriodOr returns the value of key as a calendar period, or defaultValue if it is not set or invalid.
+func (c *Config) GetPeriodOr(key string, defaultValue Period) Period {
+	if value, ok := c.GetPeriod(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetPeriod returns the value of key as a calendar period.
+// Panics if the key is not set or cannot be parsed as a period.
+func (c *Config) MustGetPeriod(key string) Period {
+	value, ok := c.GetPeriod(key)
+	if !ok {
+		panic("lxenv: config key " + key + " is not set or not a valid period")
+	}
+	return value
+}
+
+// GetStringSlice returns the value of key as a list of strings split by sep,
+// using the same rules as GetStringSlice.
+// Returns (nil, false) if the key is not set or contains no items.
+func (c *Config) GetStringSlice(key, sep string) ([]string, bool) {
+	return stringSliceValue(c.Get(key), sep)
+}
+
+// GetStringSliceOr returns the value of key as a list of strings, or defaultValue if it is not set or empty.
+func (c *Config) GetStringSliceOr(key, sep string, defaultValue []string) []string {
+	if value, ok := c.GetStringSlice(key, sep); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetStringSlice returns the value of key as a list of strings.
+// Panics if the key is not set or contains no items.
+func (c *Config) MustGetStringSlice(key, sep string) []string {
+	value, ok := c.GetStringSlice(key, sep)
+	if !ok {
+		panic("lxenv: config key " + key + " is not set or empty")
+	}
+	return value
+}
+
+// GetIntSlice returns the value of key as a comma-separated list of integers.
+// Returns (nil, false) if the key is not set or cannot be parsed.
+func (c *Config) GetIntSlice(key string) ([]int, bool) {
+	return intSliceValue(c.Get(key))
+}
+
+// GetIntSliceOr returns the value of key as a list of integers, or defaultValue if it is not set or invalid.
+func (c *Config) GetIntSliceOr(key string, defaultValue []int) []int {
+	if value, ok := c.GetIntSlice(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetIntSlice returns the value of key as a list of integers.
+// Panics if the key is not set or cannot be parsed.
+func (c *Config) MustGetIntSlice(key string) []int {
+	value, ok := c.GetIntSlice(key)
+	if !ok {
+		panic("lxenv: config key " + key + " is not set or not a valid integer list")
+	}
+	return value
+}
+
+// GetMap returns the value of key, of the form "k1=v1,k2=v2", as a map.
+// Returns (nil, false) if the key is not set or cannot be parsed.
+func (c *Config) GetMap(key string) (map[string]string, bool) {
+	return mapValue(c.Get(key))
+}
+
+// GetMapOr returns the value of key as a map, or defaultValue if it is not set or invalid.
+func (c *Config) GetMapOr(key string, defaultValue map[string]string) map[string]string {
+	if value, ok := c.GetMap(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetMap returns the value of key as a map.
+// Panics if the key is not set or cannot be parsed as a map.
+func (c *Config) MustGetMap(key string) map[string]string {
+	value, ok := c.GetMap(key)
+	if !ok {
+		panic("lxenv: config key " + key + " is not set or not a valid map")
+	}
+	return value
+}
+
+// GetURL returns the value of key as an absolute URL.
+// Returns (nil, false) if the key is not set, cannot be parsed or has no scheme.
+func (c *Config) GetURL(key string) (*url.URL, bool) {
+	return urlValue(c.Get(key))
+}
+
+// GetURLOr returns the value of key as an absolute URL, or defaultValue if it is not set or invalid.
+func (c *Config) GetURLOr(key string, defaultValue *url.URL) *url.URL {
+	if value, ok := c.GetURL(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetURL returns the value of key as an absolute URL.
+// Panics if the key is not set or is not a valid absolute URL.
+func (c *Config) MustGetURL(key string) *url.URL {
+	value, ok := c.GetURL(key)
+	if !ok {
+		panic("lxenv: config key " + key + " is not set or not a valid URL")
+	}
+	return value
+}
+
+// GetByteSize returns the value of key as a number of bytes, using the units of GetByteSize.
+// Returns (0, false) if the key is not set or cannot be parsed.
+func (c *Config) GetByteSize(key string) (int64, bool) {
+	return byteSizeValue(c.Get(key))
+}
+
+// GetByteSizeOr returns the value of key as a number of bytes, or defaultValue if it is not set or invalid.
+func (c *Config) GetByteSizeOr(key string, defaultValue int64) int64 {
+	if value, ok := c.GetByteSize(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetByteSize returns the value of key as a number of bytes.
+// Panics if the key is not set or cannot be parsed as a byte size.
+func (c *Config) MustGetByteSize(key string) int64 {
+	value, ok := c.GetByteSize(key)
+	if !ok {
+		panic("lxenv: config key " + key + " is not set or not a valid byte size")
+	}
+	return value
+}
+
+// GetTime returns the value of key as a time, trying each layout in order, or RFC 3339 if none is given.
+// Returns (zero time, false) if the key is not set or matches no layout.
+func (c *Config) GetTime(key string, layouts ...string) (time.Time, bool) {
+	return timeValue(c.Get(key), layouts)
+}
+
+// GetTimeOr returns the value of key as a time, or defaultValue if it is not set or invalid.
+func (c *Config) GetTimeOr(key string, defaultValue time.Time, layouts ...string) time.Time {
+	if value, ok := c.GetTime(key, layouts...); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetTime returns the value of key as a time.
+// Panics if the key is not set or matches none of layouts.
+func (c *Config) MustGetTime(key string, layouts ...string) time.Time {
+	value, ok := c.GetTime(key, layouts...)
+	if !ok {
+		panic("lxenv: config key " + key + " is not set or not a valid time")
+	}
+	return value
+}
+
+// GetLocation returns the value of key as a time zone location.
+// Returns (nil, false) if the key is not set or names an unknown location.
+func (c *Config) GetLocation(key string) (*time.Location, bool) {
+	return locationValue(c.Get(key))
+}
+
+// GetLocationOr returns the value of key as a time zone location, or defaultValue if it is not set or unknown.
+func (c *Config) GetLocationOr(key string, defaultValue *time.Location) *time.Location {
+	if value, ok := c.GetLocation(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetLocation returns the value of key as a time zone location.
+// Panics if the key is not set or names an unknown location.
+func (c *Config) MustGetLocation(key string) *time.Location {
+	value, ok := c.GetLocation(key)
+	if !ok {
+		panic("lxenv: config key " + key + " is not set or not a valid location")
+	}
+	return value
+}
+
+// GetIP returns the value of key as an IPv4 or IPv6 address.
+// Returns (nil, false) if the key is not set or cannot be parsed.
+func (c *Config) GetIP(key string) (net.IP, bool) {
+	return ipValue(c.Get(key))
+}
+
+// GetIPOr returns the value of key as an IP address, or defaultValue if it is not set or invalid.
+func (c *Config) GetIPOr(key string, defaultValue net.IP) net.IP {
+	if value, ok := c.GetIP(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetIP returns the value of key as an IP address.
+// Panics if the key is not set or cannot be parsed as an IP address.
+func (c *Config) MustGetIP(key string) net.IP {
+	value, ok := c.GetIP(key)
+	if !ok {
+		panic("lxenv: config key " + key + " is not set or not a valid IP address")
+	}
+	return value
+}
+
+// GetCIDR returns the value of key as a network in CIDR notation.
+// Returns (nil, false) if the key is not set or cannot be parsed.
+func (c *Config) GetCIDR(key string) (*net.IPNet, bool) {
+	return cidrValue(c.Get(key))
+}
+
+// GetCIDROr returns the value of key as a network, or defaultValue if it is not set or invalid.
+func (c *Config) GetCIDROr(key string, defaultValue *net.IPNet) *net.IPNet {
+	if value, ok := c.GetCIDR(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetCIDR returns the value of key as a network in CIDR notation.
+// Panics if the key is not set or cannot be parsed as a CIDR.
+func (c *Config) MustGetCIDR(key string) *net.IPNet {
+	value, ok := c.GetCIDR(key)
+	if !ok {
+		panic("lxenv: config key " + key + " is not set or not a valid CIDR")
+	}
+	return value
+}
+
+// GetEnum returns the value of key if it is exactly one of allowed.
+// Returns ("", false) if the key is not set, empty or not allowed.
+func (c *Config) GetEnum(key string, allowed ...string) (string, bool) {
+	return enumValue(c.Get(key), allowed)
+}
+
+// GetEnumOr returns the value of key if it is one of allowed, otherwise defaultValue.
+func (c *Config) GetEnumOr(key string, defaultValue string, allowed ...string) string {
+	if value, ok := c.GetEnum(key, allowed...); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetEnum returns the value of key, which must be one of allowed.
+// Panics if the key is not set or not allowed.
+func (c *Config) MustGetEnum(key string, allowed ...string) string {
+	value, ok := c.GetEnum(key, allowed...)
+	if !ok {
+		panic("lxenv: config key " + key + " is not set or not one of " + strings.Join(allowed, ", "))
+	}
+	return value
+}
+
+// Require ensures the provided keys exist in the config.
+// Returns ErrKeyNotFound wrapped with the missing keys when any key is unset.
+func (c *Config) Require(keys ...string) error {
+	return requireKeys(keys, c.Has)
+}
+
+// Validate checks every rule against the config, as Validate does for the environment.
+func (c *Config) Validate(rules ...*Rule) error {
+	return validateRules(rules, c.Lookup)
+}
+
+// Bind populates the struct pointed to by v from the config, using the same
+// struct tags and error aggregation as Bind.
+func (c *Config) Bind(v any) error {
+	return bind(v, c.Lookup)
+}
+
+// Keys returns every key in the config in sorted order.
+func (c *Config) Keys() []string {
+	if c == nil {
+		return []string{}
+	}
+	keys := make([]string, 0, len(c.values))
+	for k := range c.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Map returns a copy of the config's key/value pairs.
+func (c *Config) Map() map[string]string {
+	if c == nil {
+		return map[string]string{}
+	}
+	return NewConfig(c.values).values
+}
+
+// Len returns the number of keys in the config.
+func (c *Config) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.values)
+}
+
+// Origin reports the file, line and format that defined key, along with the
+// earlier definitions it overrode. Configs created with NewConfig have no file
+// sources and report the environment format for their keys.
+// Returns false if key is not in the config.
+func (c *Config) Origin(key string) (Provenance, bool) {
+	value, ok := c.Lookup(key)
+	if !ok {
+		return Provenance{}, false
+	}
+	p, ok := c.origins[setKey(key, c.rawLookup)]
+	if !ok {
+		return Provenance{Key: key, Source: Source{Format: sourceEnvironment, Value: value}}, true
+	}
+	p.Overridden = append([]Source(nil), p.Overridden...)
+	return p, true
+}
+
+// Dump writes a table of the config's values and their sources, masking
+// secrets, in the same layout as Dump.
+func (c *Config) Dump(w io.Writer) error {
+	list := make([]Provenance, 0, c.Len())
+	for _, k := range c.Keys() {
+		p, _ := c.Origin(k)
+		list = append(list, p)
+	}
+	return dumpProvenance(w, list)
+}
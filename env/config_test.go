@@ -0,0 +1,253 @@
+package lxenv_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hgapdvn/lx/env"
+)
+
+func TestParseEnv(t *testing.T) {
+	t.Setenv("TEST_PARSE_HOME", "/home/lx")
+
+	got, err := lxenv.ParseEnv(strings.NewReader("A=1\nB=\"two words\" # comment\nC=${TEST_PARSE_HOME}/x\n"))
+	if err != nil {
+		t.Fatalf("ParseEnv() unexpected error: %v", err)
+	}
+
+	want := map[string]string{"A": "1", "B": "two words", "C": "/home/lx/x"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseEnv() = %v, want %v", got, want)
+	}
+	if lxenv.Has("A") {
+		t.Error("ParseEnv() must not modify the process environment")
+	}
+}
+
+func TestParseProperties(t *testing.T) {
+	got, err := lxenv.ParseProperties(strings.NewReader("server.port=8080\n"))
+	if err != nil {
+		t.Fatalf("ParseProperties() unexpected error: %v", err)
+	}
+	if want := map[string]string{"server.port": "8080"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseProperties() = %v, want %v", got, want)
+	}
+}
+
+func TestParseYML(t *testing.T) {
+	got, err := lxenv.ParseYML(strings.NewReader("server:\n  port: 8080\n  hosts: [a, b]\n"))
+	if err != nil {
+		t.Fatalf("ParseYML() unexpected error: %v", err)
+	}
+	want := map[string]string{"server.port": "8080", "server.hosts.0": "a", "server.hosts.1": "b", "server.hosts": "a,b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseYML() = %v, want %v", got, want)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	if _, err := lxenv.ParseEnv(strings.NewReader("NOEQUALS\n")); err == nil {
+		t.Error("ParseEnv() expected error for missing '=', got nil")
+	}
+	if _, err := lxenv.ParseYML(strings.NewReader("a: [1\n")); err == nil {
+		t.Error("ParseYML() expected error for unterminated flow, got nil")
+	}
+}
+
+func TestReadEnv_DoesNotMutateEnvironment(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := lxenv.ReadEnv(baseEnv, overrideEnv)
+	if err != nil {
+		t.Fatalf("ReadEnv() unexpected error: %v", err)
+	}
+
+	tests := []struct{ key, want string }{
+		{"APP_NAME", "lx"},
+		{"APP_ENV", "local"},
+		{"DB_HOST", "127.0.0.1"},
+		{"TOKEN", "abc#123"},
+	}
+	for _, tt := range tests {
+		if got := cfg.Get(tt.key); got != tt.want {
+			t.Errorf("cfg.Get(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+
+	if _, exists := os.LookupEnv("APP_NAME"); exists {
+		t.Error("ReadEnv() must not modify the process environment")
+	}
+}
+
+func TestReadYML_SideBySide(t *testing.T) {
+	t.Parallel()
+
+	base, err := lxenv.ReadYML(baseYML)
+	if err != nil {
+		t.Fatalf("ReadYML() unexpected error: %v", err)
+	}
+	local, err := lxenv.ReadYML(baseYML, overrideYML)
+	if err != nil {
+		t.Fatalf("ReadYML() unexpected error: %v", err)
+	}
+
+	if got := base.Get("database.host"); got != "localhost" {
+		t.Errorf("base database.host = %q, want %q", got, "localhost")
+	}
+	if got := local.Get("database.host"); got != "127.0.0.1" {
+		t.Errorf("local database.host = %q, want %q", got, "127.0.0.1")
+	}
+}
+
+func TestReadProperties_Errors(t *testing.T) {
+	if _, err := lxenv.ReadProperties(baseProperties, "testdata/nonexistent.properties"); err == nil {
+		t.Error("ReadProperties() expected error when second file not found, got nil")
+	}
+}
+
+func TestConfig_Getters(t *testing.T) {
+	cfg := lxenv.NewConfig(map[string]string{
+		"STR":      "hello",
+		"EMPTY":    "",
+		"INT":      "42",
+		"BOOL":     "true",
+		"FLOAT":    "0.5",
+		"DURATION": "1d 2h",
+		"BAD":      "nope",
+	})
+
+	if got := cfg.Get("STR"); got != "hello" {
+		t.Errorf("Get() = %q, want %q", got, "hello")
+	}
+	if got := cfg.GetOr("EMPTY", "def"); got != "def" {
+		t.Errorf("GetOr() on empty = %q, want %q", got, "def")
+	}
+	if _, ok := cfg.Lookup("EMPTY"); !ok {
+		t.Error("Lookup() on empty key should report true")
+	}
+	if cfg.Has("MISSING") {
+		t.Error("Has() on missing key should report false")
+	}
+	if got, ok := cfg.GetInt("INT"); !ok || got != 42 {
+		t.Errorf("GetInt() = (%d, %v), want (42, true)", got, ok)
+	}
+	if got := cfg.GetIntOr("BAD", 7); got != 7 {
+		t.Errorf("GetIntOr() = %d, want 7", got)
+	}
+	if got, ok := cfg.GetBool("BOOL"); !ok || !got {
+		t.Errorf("GetBool() = (%v, %v), want (true, true)", got, ok)
+	}
+	if got := cfg.GetBoolOr("BAD", true); !got {
+		t.Errorf("GetBoolOr() = %v, want true", got)
+	}
+	if got, ok := cfg.GetFloat("FLOAT"); !ok || got != 0.5 {
+		t.Errorf("GetFloat() = (%v, %v), want (0.5, true)", got, ok)
+	}
+	if got := cfg.GetFloatOr("BAD", 1.5); got != 1.5 {
+		t.Errorf("GetFloatOr() = %v, want 1.5", got)
+	}
+	if got, ok := cfg.GetDuration("DURATION"); !ok || got != 26*time.Hour {
+		t.Errorf("GetDuration() = (%v, %v), want (26h, true)", got, ok)
+	}
+	if got := cfg.GetDurationOr("BAD", time.Second); got != time.Second {
+		t.Errorf("GetDurationOr() = %v, want 1s", got)
+	}
+	if got := cfg.MustGetInt("INT"); got != 42 {
+		t.Errorf("MustGetInt() = %d, want 42", got)
+	}
+	if got := cfg.Keys(); len(got) != 7 || got[0] != "BAD" {
+		t.Errorf("Keys() = %v, want 7 sorted keys", got)
+	}
+	if got := cfg.Len(); got != 7 {
+		t.Errorf("Len() = %d, want 7", got)
+	}
+}
+
+func TestConfig_MustGetPanics(t *testing.T) {
+	cfg := lxenv.NewConfig(map[string]string{"BAD": "nope"})
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"MustGet", func() { cfg.MustGet("MISSING") }},
+		{"MustGetInt", func() { cfg.MustGetInt("BAD") }},
+		{"MustGetBool", func() { cfg.MustGetBool("BAD") }},
+		{"MustGetFloat invalid", func() { cfg.MustGetFloat("BAD") }},
+		{"MustGetFloat missing", func() { cfg.MustGetFloat("MISSING") }},
+		{"MustGetDuration", func() { cfg.MustGetDuration("BAD") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s did not panic", tt.name)
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
+
+func TestConfig_RequireAndBind(t *testing.T) {
+	cfg := lxenv.NewConfig(map[string]string{"HOST": "db", "PORT": "5432"})
+
+	if err := cfg.Require("HOST", "PORT"); err != nil {
+		t.Errorf("Require() unexpected error: %v", err)
+	}
+	err := cfg.Require("HOST", "USER")
+	if !errors.Is(err, lxenv.ErrKeyNotFound) || !strings.Contains(err.Error(), "USER") {
+		t.Errorf("Require() = %v, want ErrKeyNotFound mentioning USER", err)
+	}
+
+	var target struct {
+		Host string `env:"HOST"`
+		Port int    `env:"PORT"`
+	}
+	if err := cfg.Bind(&target); err != nil {
+		t.Fatalf("Bind() unexpected error: %v", err)
+	}
+	if target.Host != "db" || target.Port != 5432 {
+		t.Errorf("Bind() = %+v, want {db 5432}", target)
+	}
+}
+
+func TestConfig_Isolation(t *testing.T) {
+	values := map[string]string{"A": "1"}
+	cfg := lxenv.NewConfig(values)
+
+	values["A"] = "changed"
+	if got := cfg.Get("A"); got != "1" {
+		t.Errorf("NewConfig() should copy its input, got %q", got)
+	}
+
+	m := cfg.Map()
+	m["A"] = "changed"
+	if got := cfg.Get("A"); got != "1" {
+		t.Errorf("Map() should return a copy, got %q", got)
+	}
+}
+
+func TestConfig_Nil(t *testing.T) {
+	var cfg *lxenv.Config
+
+	if got := cfg.Get("A"); got != "" {
+		t.Errorf("nil Get() = %q, want empty", got)
+	}
+	if cfg.Has("A") {
+		t.Error("nil Has() should be false")
+	}
+	if got := cfg.Keys(); len(got) != 0 {
+		t.Errorf("nil Keys() = %v, want empty", got)
+	}
+	if got := cfg.Len(); got != 0 {
+		t.Errorf("nil Len() = %d, want 0", got)
+	}
+	if err := cfg.Require("A"); !errors.Is(err, lxenv.ErrKeyNotFound) {
+		t.Errorf("nil Require() = %v, want ErrKeyNotFound", err)
+	}
+}
@@ -0,0 +1,195 @@
+package lxenv
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnsupportedType = errors.New("lxenv: unsupported type")
+)
+
+// decoderFunc decodes a raw value into a value of its registered type.
+type decoderFunc func(raw string) (any, error)
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = map[reflect.Type]decoderFunc{
+		durationType: func(raw string) (any, error) {
+			return parseDuration(raw)
+		},
+		reflect.TypeOf((*url.URL)(nil)): func(raw string) (any, error) {
+			u, err := url.Parse(raw)
+			if err != nil {
+				return nil, err
+			}
+			if u.Scheme == "" {
+				return nil, errors.New("URL has no scheme")
+			}
+			return u, nil
+		},
+		reflect.TypeOf((*time.Location)(nil)): func(raw string) (any, error) {
+			if raw == "" {
+				return nil, errors.New("empty location")
+			}
+			return time.LoadLocation(raw)
+		},
+	}
+)
+
+// RegisterDecoder makes fn the decoder for values of type T, used by GetAs
+// and Bind. A registered decoder takes precedence over encoding.TextUnmarshaler
+// and the built-in conversions. Registering a type that already has a decoder
+// replaces it. Panics if fn is nil.
+//
+// Example:
+//
+//	type Region string
+//
+//	lxenv.RegisterDecoder(func(raw string) (Region, error) {
+//	    switch raw {
+//	    case "eu", "us", "ap":
+//	        return Region(raw), nil
+//	    }
+//	    return "", fmt.Errorf("unknown region %q", raw)
+//	})
+//	region, err := lxenv.GetAs[Region]("REGION")
+func RegisterDecoder[T any](fn func(raw string) (T, error)) {
+	if fn == nil {
+		panic("lxenv: RegisterDecoder called with a nil decoder")
+	}
+	rt := reflect.TypeOf((*T)(nil)).Elem()
+
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	decoders[rt] = func(raw string) (any, error) {
+		return fn(raw)
+	}
+}
+
+// GetAs retrieves an environment variable decoded as a T. Values are decoded,
+// in order of preference, with:
+//
+//   - a decoder registered for T with RegisterDecoder
+//   - the UnmarshalText method if *T implements encoding.TextUnmarshaler
+//   - the rules of Bind for strings, bools, numbers, durations, slices and maps
+//
+// Decoders for time.Duration (with the extended units of GetDuration),
+// *url.URL and *time.Location are registered by default.
+//
+// Returns ErrKeyNotFound if the variable is not set, ErrInvalidValue if it
+// cannot be decoded and ErrUnsupportedType if T cannot be decoded at all.
+//
+// Example:
+//
+//	addr, err := lxenv.GetAs[net.IP]("BIND_ADDR")
+//	ports, err := lxenv.GetAs[[]uint16]("PORTS")
+func GetAs[T any](key string) (T, error) {
+	var zero T
+
+	value, ok := Lookup(key)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+	value, _, err := resolveForGet(value)
+	if err != nil {
+		return zero, fmt.Errorf("%s: %w", key, err)
+	}
+	return decodeAs[T](key, value)
+}
+
+// GetAsOr retrieves an environment variable decoded as a T, or returns
+// defaultValue if it is not set, empty or cannot be decoded.
+//
+// Example:
+//
+//	addr := lxenv.GetAsOr("BIND_ADDR", net.IPv4zero)
+func GetAsOr[T any](key string, defaultValue T) T {
+	if Get(key) == "" {
+		return defaultValue
+	}
+	if value, err := GetAs[T](key); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetAs retrieves an environment variable decoded as a T.
+// Panics if the variable is not set or cannot be decoded.
+//
+// Example:
+//
+//	addr := lxenv.MustGetAs[net.IP]("BIND_ADDR")
+func MustGetAs[T any](key string) T {
+	value, err := GetAs[T](key)
+	if err != nil {
+		panic(err.Error())
+	}
+	return value
+}
+
+// configAs decodes the value of key in c as a T, resolving value references
+// and decoding like GetAs. It reports false if the key is not set or invalid.
+func configAs[T any](c *Config, key string) (T, bool) {
+	var zero T
+
+	value, ok := c.Lookup(key)
+	if !ok {
+		return zero, false
+	}
+	value, _, err := resolveForGet(value)
+	if err != nil {
+		return zero, false
+	}
+	v, err := decodeAs[T](key, value)
+	if err != nil {
+		return zero, false
+	}
+	return v, true
+}
+
+// decodeAs decodes the value of key into a T.
+func decodeAs[T any](key, value string) (T, error) {
+	var v T
+	if err := setValue(reflect.ValueOf(&v).Elem(), value, defaultSeparator); err != nil {
+		var zero T
+		if errors.Is(err, ErrUnsupportedType) {
+			return zero, fmt.Errorf("%s: %w", key, err)
+		}
+		return zero, fmt.Errorf("%w: %s=%q: %v", ErrInvalidValue, key, value, err)
+	}
+	return v, nil
+}
+
+// decodeCustom decodes raw into fv with a registered decoder or, when fv is
+// addressable, encoding.TextUnmarshaler. It reports false if neither applies
+// to fv's type.
+func decodeCustom(fv reflect.Value, raw string) (bool, error) {
+	decodersMu.RLock()
+	decode, ok := decoders[fv.Type()]
+	decodersMu.RUnlock()
+	if ok {
+		v, err := decode(raw)
+		if err != nil {
+			return true, err
+		}
+		if v == nil {
+			fv.Set(reflect.Zero(fv.Type()))
+		} else {
+			fv.Set(reflect.ValueOf(v))
+		}
+		return true, nil
+	}
+
+	if fv.CanAddr() {
+		if u, ok := fv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return true, u.UnmarshalText([]byte(raw))
+		}
+	}
+	return false, nil
+}
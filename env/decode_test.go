@@ -0,0 +1,171 @@
+package lxenv_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hgapdvn/lx/env"
+)
+
+// logLevel implements encoding.TextUnmarshaler.
+type logLevel int
+
+func (l *logLevel) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "debug":
+		*l = -1
+	case "info":
+		*l = 0
+	case "error":
+		*l = 1
+	default:
+		return fmt.Errorf("unknown level %q", text)
+	}
+	return nil
+}
+
+// region has a decoder registered in TestRegisterDecoder.
+type region string
+
+func TestGetAs(t *testing.T) {
+	t.Setenv("TEST_GETAS_INT", "42")
+	t.Setenv("TEST_GETAS_UINT16S", "80, 443")
+	t.Setenv("TEST_GETAS_MAP", "a=1,b=2")
+	t.Setenv("TEST_GETAS_DURATION", "1d 2h")
+	t.Setenv("TEST_GETAS_URL", "https://example.com/x")
+	t.Setenv("TEST_GETAS_IP", "10.0.0.1")
+	t.Setenv("TEST_GETAS_TIME", "2024-01-02T03:04:05Z")
+	t.Setenv("TEST_GETAS_LEVEL", "ERROR")
+	t.Setenv("TEST_GETAS_PTR", "7")
+
+	if got, err := lxenv.GetAs[int]("TEST_GETAS_INT"); err != nil || got != 42 {
+		t.Errorf("GetAs[int]() = (%d, %v), want (42, nil)", got, err)
+	}
+	if got, err := lxenv.GetAs[[]uint16]("TEST_GETAS_UINT16S"); err != nil || !reflect.DeepEqual(got, []uint16{80, 443}) {
+		t.Errorf("GetAs[[]uint16]() = (%v, %v)", got, err)
+	}
+	if got, err := lxenv.GetAs[map[string]int]("TEST_GETAS_MAP"); err != nil || !reflect.DeepEqual(got, map[string]int{"a": 1, "b": 2}) {
+		t.Errorf("GetAs[map[string]int]() = (%v, %v)", got, err)
+	}
+	if got, err := lxenv.GetAs[time.Duration]("TEST_GETAS_DURATION"); err != nil || got != 26*time.Hour {
+		t.Errorf("GetAs[time.Duration]() = (%v, %v), want 26h", got, err)
+	}
+	if got, err := lxenv.GetAs[*url.URL]("TEST_GETAS_URL"); err != nil || got.Host != "example.com" {
+		t.Errorf("GetAs[*url.URL]() = (%v, %v)", got, err)
+	}
+	if got, err := lxenv.GetAs[net.IP]("TEST_GETAS_IP"); err != nil || !got.Equal(net.IPv4(10, 0, 0, 1)) {
+		t.Errorf("GetAs[net.IP]() = (%v, %v)", got, err)
+	}
+	if got, err := lxenv.GetAs[time.Time]("TEST_GETAS_TIME"); err != nil || got.Hour() != 3 {
+		t.Errorf("GetAs[time.Time]() = (%v, %v)", got, err)
+	}
+	if got, err := lxenv.GetAs[logLevel]("TEST_GETAS_LEVEL"); err != nil || got != 1 {
+		t.Errorf("GetAs[logLevel]() = (%v, %v), want (1, nil)", got, err)
+	}
+	if got, err := lxenv.GetAs[*int]("TEST_GETAS_PTR"); err != nil || got == nil || *got != 7 {
+		t.Errorf("GetAs[*int]() = (%v, %v)", got, err)
+	}
+}
+
+func TestGetAs_Errors(t *testing.T) {
+	t.Setenv("TEST_GETAS_BAD", "nope")
+
+	if _, err := lxenv.GetAs[int]("TEST_GETAS_UNSET"); !errors.Is(err, lxenv.ErrKeyNotFound) {
+		t.Errorf("GetAs() of an unset key error = %v, want ErrKeyNotFound", err)
+	}
+	if _, err := lxenv.GetAs[int]("TEST_GETAS_BAD"); !errors.Is(err, lxenv.ErrInvalidValue) {
+		t.Errorf("GetAs[int]() error = %v, want ErrInvalidValue", err)
+	}
+	if _, err := lxenv.GetAs[logLevel]("TEST_GETAS_BAD"); !errors.Is(err, lxenv.ErrInvalidValue) {
+		t.Errorf("GetAs[logLevel]() error = %v, want ErrInvalidValue", err)
+	}
+	if _, err := lxenv.GetAs[chan int]("TEST_GETAS_BAD"); !errors.Is(err, lxenv.ErrUnsupportedType) {
+		t.Errorf("GetAs[chan int]() error = %v, want ErrUnsupportedType", err)
+	}
+}
+
+func TestGetAsOr(t *testing.T) {
+	t.Setenv("TEST_GETASOR_EMPTY", "")
+	t.Setenv("TEST_GETASOR_BAD", "x")
+	t.Setenv("TEST_GETASOR_OK", "3.5")
+
+	if got := lxenv.GetAsOr("TEST_GETASOR_UNSET", 1.5); got != 1.5 {
+		t.Errorf("GetAsOr() unset = %v, want default", got)
+	}
+	if got := lxenv.GetAsOr("TEST_GETASOR_EMPTY", "def"); got != "def" {
+		t.Errorf("GetAsOr() empty = %q, want default", got)
+	}
+	if got := lxenv.GetAsOr("TEST_GETASOR_BAD", 1.5); got != 1.5 {
+		t.Errorf("GetAsOr() invalid = %v, want default", got)
+	}
+	if got := lxenv.GetAsOr("TEST_GETASOR_OK", 1.5); got != 3.5 {
+		t.Errorf("GetAsOr() = %v, want 3.5", got)
+	}
+}
+
+func TestMustGetAs(t *testing.T) {
+	t.Setenv("TEST_MUSTGETAS", "true")
+	if got := lxenv.MustGetAs[bool]("TEST_MUSTGETAS"); !got {
+		t.Error("MustGetAs[bool]() = false, want true")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("MustGetAs() of an unset key did not panic")
+		}
+	}()
+	lxenv.MustGetAs[int]("TEST_MUSTGETAS_UNSET")
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	lxenv.RegisterDecoder(func(raw string) (region, error) {
+		switch raw {
+		case "eu", "us":
+			return region(raw), nil
+		}
+		return "", fmt.Errorf("unknown region %q", raw)
+	})
+
+	t.Setenv("TEST_DECODER_REGION", "eu")
+	t.Setenv("TEST_DECODER_REGIONS", "us,eu")
+	if got, err := lxenv.GetAs[region]("TEST_DECODER_REGION"); err != nil || got != "eu" {
+		t.Errorf("GetAs[region]() = (%q, %v), want (eu, nil)", got, err)
+	}
+	if got, err := lxenv.GetAs[[]region]("TEST_DECODER_REGIONS"); err != nil || !reflect.DeepEqual(got, []region{"us", "eu"}) {
+		t.Errorf("GetAs[[]region]() = (%q, %v)", got, err)
+	}
+
+	t.Setenv("TEST_DECODER_REGION", "mars")
+	if _, err := lxenv.GetAs[region]("TEST_DECODER_REGION"); !errors.Is(err, lxenv.ErrInvalidValue) {
+		t.Errorf("GetAs[region]() error = %v, want ErrInvalidValue", err)
+	}
+
+	// Bind uses the same decoders
+	var cfg struct {
+		Region region   `env:"TEST_DECODER_REGIONS_BIND"`
+		Level  logLevel `env:"TEST_DECODER_LEVEL"`
+	}
+	t.Setenv("TEST_DECODER_REGIONS_BIND", "us")
+	t.Setenv("TEST_DECODER_LEVEL", "debug")
+	if err := lxenv.Bind(&cfg); err != nil {
+		t.Fatalf("Bind() unexpected error: %v", err)
+	}
+	if cfg.Region != "us" || cfg.Level != -1 {
+		t.Errorf("Bind() = %+v, want region us and level -1", cfg)
+	}
+}
+
+func TestRegisterDecoder_NilPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("RegisterDecoder(nil) did not panic")
+		}
+	}()
+	lxenv.RegisterDecoder[int](nil)
+}
@@ -1,19 +1,23 @@
 package lxenv
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// durationRe matches a single numeric component followed by a unit in a duration string.
-var durationRe = regexp.MustCompile(`^\s*([-+]?([0-9]*\.[0-9]+|[0-9]+))\s*([a-zA-Zµμ]+)`)
+// durationRe matches a single unsigned numeric component followed by a unit in a duration string.
+var durationRe = regexp.MustCompile(`^\s*([0-9]*\.[0-9]+|[0-9]+)\s*([a-zA-Zµμ]+)`)
 
 // GetDuration retrieves an environment variable as a duration.
-// Supports standard Go duration strings and extended units: d (days), w (weeks), y (years).
+// Supports standard Go duration strings, extended units: d (days), w (weeks),
+// y (years of 365 days), and ISO-8601 durations such as P1DT12H. A leading
+// minus negates the whole duration: "-1d 12h" is -36h. Months have no fixed
+// length and are rejected; use GetPeriod for calendar periods.
 // Returns (value, true) if the variable is set and can be parsed as a duration.
 // Returns (0, false) if the variable is not set or cannot be parsed.
 //
@@ -23,7 +27,12 @@ var durationRe = regexp.MustCompile(`^\s*([-+]?([0-9]*\.[0-9]+|[0-9]+))\s*([a-zA
 //	    // Use timeout as time.Duration
 //	}
 func GetDuration(key string) (time.Duration, bool) {
-	value := os.Getenv(key)
+	value, err := GetAs[time.Duration](key)
+	return value, err == nil
+}
+
+// durationValue parses a non-empty value with parseDuration.
+func durationValue(value string) (time.Duration, bool) {
 	if value == "" {
 		return 0, false
 	}
@@ -72,11 +81,17 @@ func MustGetDuration(key string) time.Duration {
 //   - w (week = 7d)
 //   - y (year = 365d)
 //
+// ISO-8601 durations such as P1DT2H are accepted as well, except for months,
+// which have no fixed length; use GetPeriod for calendar periods.
+// A leading sign applies to the whole duration.
+//
 // Examples:
 //   - "3d" -> 72 * time.Hour
 //   - "1w" -> 168 * time.Hour
 //   - "1.5d" -> 36 * time.Hour
 //   - "1d 12h" -> 36 * time.Hour
+//   - "-1d 12h" -> -36 * time.Hour
+//   - "P1DT12H" -> 36 * time.Hour
 func parseDuration(s string) (time.Duration, error) {
 	orig := strings.TrimSpace(s)
 	if orig == "" {
@@ -88,9 +103,31 @@ func parseDuration(s string) (time.Duration, error) {
 		return d, nil
 	}
 
-	// Robust envLoader that consumes the string part by part
-	// Supports optional spaces between value and unit, and between parts.
-	remaining := orig
+	negative, remaining, err := splitSign(orig)
+	if err != nil {
+		return 0, fmt.Errorf("lxenv: invalid duration %q", orig)
+	}
+
+	var total time.Duration
+	if isISODuration(remaining) {
+		total, err = isoToDuration(remaining, orig)
+	} else {
+		total, err = unitsToDuration(remaining, orig)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if negative {
+		total = -total
+	}
+	return total, nil
+}
+
+// unitsToDuration adds up the value-unit components of s, e.g. "1d 2h".
+// Robust parser that consumes the string part by part.
+// Supports optional spaces between value and unit, and between parts.
+func unitsToDuration(s, orig string) (time.Duration, error) {
+	remaining := s
 	var total time.Duration
 	for remaining != "" {
 		matches := durationRe.FindStringSubmatch(remaining)
@@ -100,7 +137,7 @@ func parseDuration(s string) (time.Duration, error) {
 
 		fullMatch := matches[0]
 		valStr := matches[1]
-		unit := strings.ToLower(matches[3])
+		unit := strings.ToLower(matches[2])
 
 		val, err := strconv.ParseFloat(valStr, 64)
 		if err != nil {
@@ -127,14 +164,125 @@ func parseDuration(s string) (time.Duration, error) {
 			factor = time.Microsecond
 		case "ns", "nsec", "nanosecond", "nanoseconds":
 			factor = time.Nanosecond
+		case "mo", "mon", "month", "months":
+			return 0, fmt.Errorf("lxenv: months have no fixed duration in %q; use a period", orig)
 		default:
 			return 0, fmt.Errorf("lxenv: unknown unit %q in duration %q", unit, orig)
 		}
 
-		total += time.Duration(val * float64(factor))
+		var ok bool
+		if total, ok = addScaled(total, val, factor); !ok {
+			return 0, fmt.Errorf("lxenv: duration %q overflows time.Duration", orig)
+		}
 		remaining = remaining[len(fullMatch):]
 		remaining = strings.TrimLeft(remaining, " \t\n\r")
 	}
 
 	return total, nil
 }
+
+// isoToDuration converts the ISO-8601 duration s, without sign, to a
+// time.Duration. Years count as 365 days and weeks as 7 days.
+func isoToDuration(s, orig string) (time.Duration, error) {
+	iso, err := parseISODuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("lxenv: invalid duration %q: %v", orig, err)
+	}
+	if iso.months != 0 {
+		return 0, fmt.Errorf("lxenv: months have no fixed duration in %q; use a period", orig)
+	}
+
+	components := []struct {
+		value  float64
+		factor time.Duration
+	}{
+		{iso.years, 365 * 24 * time.Hour},
+		{iso.weeks, 7 * 24 * time.Hour},
+		{iso.days, 24 * time.Hour},
+		{iso.hours, time.Hour},
+		{iso.minutes, time.Minute},
+		{iso.seconds, time.Second},
+	}
+	var total time.Duration
+	for _, c := range components {
+		var ok bool
+		if total, ok = addScaled(total, c.value, c.factor); !ok {
+			return 0, fmt.Errorf("lxenv: duration %q overflows time.Duration", orig)
+		}
+	}
+	return total, nil
+}
+
+// addScaled returns total + value*factor for a non-negative value. It reports
+// false if the result does not fit in a time.Duration.
+func addScaled(total time.Duration, value float64, factor time.Duration) (time.Duration, bool) {
+	f := value * float64(factor)
+	if math.IsNaN(f) || f >= math.MaxInt64 || f < 0 {
+		return 0, false
+	}
+	d := time.Duration(f)
+	if total > math.MaxInt64-d {
+		return 0, false
+	}
+	return total + d, true
+}
+
+// splitSign removes a leading + or - from s and reports whether it was a minus.
+// The sign must be followed directly by the duration.
+func splitSign(s string) (negative bool, rest string, err error) {
+	rest = s
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		negative, rest = s[0] == '-', s[1:]
+	}
+	if rest == "" || rest[0] == ' ' || rest[0] == '\t' || rest[0] == '-' || rest[0] == '+' {
+		return false, "", errors.New("misplaced sign")
+	}
+	return negative, rest, nil
+}
+
+// isoDuration holds the components of an ISO-8601 duration such as P1Y2M3DT4H5M6S.
+type isoDuration struct {
+	years, months, weeks, days float64
+	hours, minutes, seconds    float64
+}
+
+// isoNumber is a non-negative ISO-8601 number; the fraction may follow a comma.
+const isoNumber = `(\d+(?:[.,]\d+)?)`
+
+// isoDurationRe matches an unsigned ISO-8601 duration in upper case.
+var isoDurationRe = regexp.MustCompile(`^P(?:` + isoNumber + `Y)?(?:` + isoNumber + `M)?(?:` + isoNumber + `W)?(?:` + isoNumber + `D)?` +
+	`(?:T(?:` + isoNumber + `H)?(?:` + isoNumber + `M)?(?:` + isoNumber + `S)?)?$`)
+
+// isISODuration reports whether s looks like an ISO-8601 duration.
+func isISODuration(s string) bool {
+	return strings.HasPrefix(s, "P") || strings.HasPrefix(s, "p")
+}
+
+// parseISODuration parses an unsigned ISO-8601 duration. Designators are
+// case-insensitive.
+func parseISODuration(s string) (isoDuration, error) {
+	s = strings.ToUpper(s)
+	m := isoDurationRe.FindStringSubmatch(s)
+	if m == nil {
+		return isoDuration{}, errors.New("malformed ISO-8601 duration")
+	}
+	if strings.Join(m[1:], "") == "" || strings.HasSuffix(s, "T") {
+		return isoDuration{}, errors.New("ISO-8601 duration has no components")
+	}
+
+	values := make([]float64, len(m)-1)
+	for i, text := range m[1:] {
+		if text == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
+		if err != nil {
+			return isoDuration{}, err
+		}
+		values[i] = v
+	}
+	return isoDuration{
+		years: values[0], months: values[1], weeks: values[2], days: values[3],
+		hours: values[4], minutes: values[5], seconds: values[6],
+	}, nil
+}
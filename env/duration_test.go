@@ -2,6 +2,7 @@ package lxenv
 
 import (
 	"os"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -68,6 +69,37 @@ func TestParseDuration(t *testing.T) {
 		{"1d 2", 0, true}, // Missing unit for the second part
 		{"d", 0, true},    // Missing value
 		{"1.2.3", 0, true},
+
+		// Negative durations: the sign applies to every component
+		{"-5m", -5 * time.Minute, false},
+		{"-1d 12h", -36 * time.Hour, false},
+		{"+1w1d", 8 * 24 * time.Hour, false},
+		{"1d -2h", 0, true},
+		{"--1d", 0, true},
+
+		// ISO-8601
+		{"P1D", 24 * time.Hour, false},
+		{"P1DT2H", 26 * time.Hour, false},
+		{"PT1H30M", 90 * time.Minute, false},
+		{"PT0.5S", 500 * time.Millisecond, false},
+		{"PT1,5M", 90 * time.Second, false},
+		{"P2W", 14 * 24 * time.Hour, false},
+		{"P1Y", 365 * 24 * time.Hour, false},
+		{"pt2h", 2 * time.Hour, false},
+		{"-P1DT1H", -25 * time.Hour, false},
+		{"P1M", 0, true}, // months have no fixed length
+		{"1mo", 0, true},
+		{"P", 0, true},
+		{"PT", 0, true},
+		{"P1DT", 0, true},
+		{"PT1H2D", 0, true},
+
+		// Overflow
+		{"300y", 0, true},
+		{"106751d 23h 47m 16s 854ms 775us 808ns", 0, true},
+		{"P300Y", 0, true},
+		{"PT9223372036S", 9223372036 * time.Second, false},
+		{"PT9223372037S", 0, true},
 	}
 
 	for _, tt := range tests {
@@ -341,3 +373,30 @@ func TestMustGetDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDuration_RegisteredDecoder(t *testing.T) {
+	decodersMu.RLock()
+	saved := decoders[durationType]
+	decodersMu.RUnlock()
+	t.Cleanup(func() {
+		decodersMu.Lock()
+		decoders[durationType] = saved
+		decodersMu.Unlock()
+	})
+
+	// bare numbers are minutes
+	RegisterDecoder(func(raw string) (time.Duration, error) {
+		n, err := strconv.Atoi(raw)
+		return time.Duration(n) * time.Minute, err
+	})
+
+	t.Setenv("TEST_DURATION_DECODER", "5")
+	cfg := NewConfig(map[string]string{"TEST_DURATION_DECODER": "5"})
+
+	if got, ok := GetDuration("TEST_DURATION_DECODER"); !ok || got != 5*time.Minute {
+		t.Errorf("GetDuration() = (%v, %v), want (5m, true)", got, ok)
+	}
+	if got, ok := cfg.GetDuration("TEST_DURATION_DECODER"); !ok || got != 5*time.Minute {
+		t.Errorf("Config.GetDuration() = (%v, %v), want (5m, true)", got, ok)
+	}
+}
@@ -9,18 +9,27 @@ import (
 )
 
 var (
-	ErrKeyNotFound = errors.New("lxenv: environment variable not found")
+	ErrKeyNotFound  = errors.New("lxenv: environment variable not found")
+	ErrInvalidValue = errors.New("lxenv: invalid environment variable value")
 )
 
 // Get retrieves the value of an environment variable.
 // Returns the value if set, empty string otherwise.
+// Value references such as file:///run/secrets/db are resolved when files are
+// loaded; Get resolves them too if ResolveOnGet is enabled, and returns an
+// empty string if the reference cannot be resolved.
 //
 // Example:
 //
 //	value := lxenv.Get("HOME")
 //	// value: "/Users/username" (or empty if not set)
 func Get(key string) string {
-	return os.Getenv(key)
+	value, _ := Lookup(key)
+	value, _, err := resolveForGet(value)
+	if err != nil {
+		return ""
+	}
+	return value
 }
 
 // GetOr retrieves the value of an environment variable or returns a default value.
@@ -31,24 +40,29 @@ func Get(key string) string {
 //	port := lxenv.GetOr("PORT", "8080")
 //	// port: "8080" if PORT is not set
 func GetOr(key string, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := Get(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
-// MustGet retrieves the value of an environment variable.
-// Panics if the variable is not set.
+// MustGet retrieves the value of an environment variable, resolving value
+// references like Get. Panics if the variable is not set or its reference
+// cannot be resolved.
 //
 // Example:
 //
 //	value := lxenv.MustGet("HOME")
 //	// value: "/Users/username"
 func MustGet(key string) string {
-	value, ok := os.LookupEnv(key)
+	value, ok := Lookup(key)
 	if !ok {
 		panic("lxenv: environment variable " + key + " is not set")
 	}
+	value, _, err := resolveForGet(value)
+	if err != nil {
+		panic("lxenv: environment variable " + key + ": " + err.Error())
+	}
 	return value
 }
 
@@ -62,13 +76,14 @@ func Set(key, value string) error {
 	return os.Setenv(key, value)
 }
 
-// Unset removes an environment variable.
-// Returns an error if the operation fails.
+// Unset removes an environment variable and forgets where it was loaded
+// from. Returns an error if the operation fails.
 //
 // Example:
 //
 //	err := lxenv.Unset("TEMP_VAR")
 func Unset(key string) error {
+	forgetOrigin(key)
 	return os.Unsetenv(key)
 }
 
@@ -81,7 +96,7 @@ func Unset(key string) error {
 //	    // DEBUG variable is set
 //	}
 func Has(key string) bool {
-	_, exists := os.LookupEnv(key)
+	_, exists := Lookup(key)
 	return exists
 }
 
@@ -104,13 +119,17 @@ func NotExists(key string) bool {
 // Returns (value, true) if the variable is set, (empty, false) otherwise.
 // Unlike Get, this distinguishes between empty and unset variables.
 //
+// A dot- or kebab-notation key that is not set falls back to its
+// UpperSnakeCase name, so "database.pool.size" finds DATABASE_POOL_SIZE.
+// Get, Has and the typed getters all look keys up this way.
+//
 // Example:
 //
 //	if value, exists := lxenv.Lookup("API_KEY"); exists {
 //	    // Use value (might be empty string)
 //	}
 func Lookup(key string) (string, bool) {
-	return os.LookupEnv(key)
+	return lookupMapped(key, os.LookupEnv)
 }
 
 // GetInt retrieves an environment variable as an integer.
@@ -123,15 +142,8 @@ func Lookup(key string) (string, bool) {
 //	    // Use port as int
 //	}
 func GetInt(key string) (int, bool) {
-	value := os.Getenv(key)
-	if value == "" {
-		return 0, false
-	}
-	parsed, err := strconv.Atoi(value)
-	if err != nil {
-		return 0, false
-	}
-	return parsed, true
+	value, err := GetAs[int](key)
+	return value, err == nil
 }
 
 // GetIntOr retrieves an environment variable as an integer or returns a default value.
@@ -175,15 +187,8 @@ func MustGetInt(key string) int {
 //	    // Use debug as bool
 //	}
 func GetBool(key string) (bool, bool) {
-	value := os.Getenv(key)
-	if value == "" {
-		return false, false
-	}
-	parsed, err := strconv.ParseBool(value)
-	if err != nil {
-		return false, false
-	}
-	return parsed, true
+	value, err := GetAs[bool](key)
+	return value, err == nil
 }
 
 // GetBoolOr retrieves an environment variable as a boolean or returns a default value.
@@ -225,15 +230,8 @@ func MustGetBool(key string) bool {
 //	    // use v (float64)
 //	}
 func GetFloat(key string) (float64, bool) {
-	value := os.Getenv(key)
-	if value == "" {
-		return 0, false
-	}
-	parsed, err := strconv.ParseFloat(value, 64)
-	if err != nil {
-		return 0, false
-	}
-	return parsed, true
+	value, err := GetAs[float64](key)
+	return value, err == nil
 }
 
 // GetFloatOr retrieves an environment variable as a float64 or returns a default value.
@@ -261,7 +259,7 @@ func MustGetFloat(key string) float64 {
 	if v, ok := GetFloat(key); ok {
 		return v
 	}
-	if _, exists := os.LookupEnv(key); exists {
+	if Has(key) {
 		panic("lxenv: environment variable " + key + " is not a valid float")
 	}
 	panic("lxenv: environment variable " + key + " is not set")
@@ -270,13 +268,22 @@ func MustGetFloat(key string) float64 {
 // Require ensures the provided keys exist in the environment.
 // Returns ErrKeyNotFound wrapped with the missing keys when any key is unset.
 func Require(keys ...string) error {
+	return requireKeys(keys, Has)
+}
+
+// requireKeys returns ErrKeyNotFound wrapped with every key for which has reports false.
+func requireKeys(keys []string, has func(string) bool) error {
 	var missing []string
 	for _, key := range keys {
-		if NotExists(key) {
+		if !has(key) {
 			missing = append(missing, key)
 		}
 	}
+	return missingKeysError(missing)
+}
 
+// missingKeysError wraps ErrKeyNotFound with the list of missing keys, or returns nil.
+func missingKeysError(missing []string) error {
 	if len(missing) == 0 {
 		return nil
 	}
@@ -285,3 +292,27 @@ func Require(keys ...string) error {
 
 	return fmt.Errorf("%w: %s", ErrKeyNotFound, desc)
 }
+
+// intValue parses a non-empty value as an int.
+func intValue(value string) (int, bool) {
+	if value == "" {
+		return 0, false
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return parsed, true
+}
+
+// boolValue parses a non-empty value as a bool.
+func boolValue(value string) (bool, bool) {
+	if value == "" {
+		return false, false
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, false
+	}
+	return parsed, true
+}
@@ -0,0 +1,246 @@
+package lxenv
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// expander resolves variable references in parsed values:
+//
+//   - $VAR and ${VAR}      value of VAR, empty if unset
+//   - ${VAR:-default}      default when VAR is unset or empty
+//   - ${VAR:?message}      error with message when VAR is unset or empty
+//   - \$ and $$            a literal $
+//
+// References are resolved against the keys of the file being expanded, then
+// against lookup (previously loaded keys and the process environment).
+// A key that refers to itself, e.g. PATH=${PATH}:/opt/bin, sees its previous
+// value from lookup. Values of literal keys are never expanded.
+//
+// When pinned is set, the variables it reports take precedence over the keys
+// of the file, as process variables do over file values with WithOverride(false).
+//
+// The expander also records, for each key, the names referenced by its value
+// directly or through other keys of the file, so that a value built from a
+// secret can be masked like the secret itself.
+type expander struct {
+	pairs     map[string]string
+	literals  map[string]bool
+	pinned    func(string) (string, bool)
+	lookup    func(string) (string, bool)
+	resolved  map[string]string
+	resolving []string
+	refs      map[string][]string
+}
+
+// literalParser is implemented by parsers whose syntax marks values that must
+// not be expanded, such as single-quoted .env values. parseLiterals is like
+// parseLines but also reports the keys of those values.
+type literalParser interface {
+	parseLiterals(r io.Reader) (pairs map[string]string, lines map[string]int, literals map[string]bool, err error)
+}
+
+// expandPairs returns a copy of pairs with every variable reference resolved,
+// except in the values of literals, and the names referenced by each key.
+// pinned may be nil.
+func expandPairs(pairs map[string]string, literals map[string]bool, pinned, lookup func(string) (string, bool)) (map[string]string, map[string][]string, error) {
+	e := &expander{
+		pairs:    pairs,
+		literals: literals,
+		pinned:   pinned,
+		lookup:   lookup,
+		resolved: make(map[string]string, len(pairs)),
+		refs:     make(map[string][]string),
+	}
+
+	// sorted for deterministic error reporting
+	keys := make([]string, 0, len(pairs))
+	for k := range pairs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make(map[string]string, len(pairs))
+	for _, k := range keys {
+		v, err := e.resolve(k)
+		if err != nil {
+			return nil, nil, fmt.Errorf("key %q: %w", k, err)
+		}
+		out[k] = v
+	}
+	return out, e.refs, nil
+}
+
+// resolve returns the expanded value of a key in the file being expanded.
+func (e *expander) resolve(key string) (string, error) {
+	if v, ok := e.resolved[key]; ok {
+		return v, nil
+	}
+	if e.literals[key] {
+		return e.pairs[key], nil
+	}
+
+	for i, k := range e.resolving {
+		if k == key {
+			chain := append(append([]string{}, e.resolving[i:]...), key)
+			return "", fmt.Errorf("variable reference cycle: %s", strings.Join(chain, " -> "))
+		}
+	}
+
+	e.resolving = append(e.resolving, key)
+	v, err := e.expand(e.pairs[key], key)
+	e.resolving = e.resolving[:len(e.resolving)-1]
+	if err != nil {
+		return "", err
+	}
+
+	e.resolved[key] = v
+	return v, nil
+}
+
+// value looks up name as referenced from the value of self.
+func (e *expander) value(name, self string) (string, bool, error) {
+	e.addRef(self, name)
+	if e.pinned != nil {
+		if v, ok := e.pinned(name); ok {
+			return v, true, nil
+		}
+	}
+	if name != self {
+		if _, ok := e.pairs[name]; ok {
+			v, err := e.resolve(name)
+			for _, ref := range e.refs[name] {
+				e.addRef(self, ref)
+			}
+			return v, true, err
+		}
+	}
+	v, ok := e.lookup(name)
+	return v, ok, nil
+}
+
+// addRef records that the value of key references name.
+func (e *expander) addRef(key, name string) {
+	for _, ref := range e.refs[key] {
+		if ref == name {
+			return
+		}
+	}
+	e.refs[key] = append(e.refs[key], name)
+}
+
+// expand substitutes every reference in s, the raw value of self.
+func (e *expander) expand(s, self string) (string, error) {
+	if strings.IndexByte(s, '$') < 0 {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c == '\\' || c == '$') && i+1 < len(s) && s[i+1] == '$' {
+			b.WriteByte('$')
+			i++
+			continue
+		}
+		if c != '$' || i+1 == len(s) {
+			b.WriteByte(c)
+			continue
+		}
+
+		if s[i+1] == '{' {
+			end := matchingBrace(s, i+1)
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable reference in %q", s)
+			}
+			v, err := e.expandBraced(s[i+2:end], self)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(v)
+			i = end
+			continue
+		}
+
+		j := i + 1
+		for j < len(s) && isVarNameChar(s[j], j == i+1) {
+			j++
+		}
+		if j == i+1 {
+			// a lone $ is kept as is
+			b.WriteByte(c)
+			continue
+		}
+		v, _, err := e.value(s[i+1:j], self)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(v)
+		i = j - 1
+	}
+	return b.String(), nil
+}
+
+// expandBraced resolves the expression inside ${...}.
+func (e *expander) expandBraced(expr, self string) (string, error) {
+	name, op, arg := expr, "", ""
+	if idx := strings.IndexByte(expr, ':'); idx >= 0 {
+		name, op = expr[:idx], expr[idx:]
+		if len(op) < 2 || (op[1] != '-' && op[1] != '?') {
+			return "", fmt.Errorf("invalid variable reference ${%s}", expr)
+		}
+		op, arg = op[:2], op[2:]
+	}
+	if name == "" {
+		return "", fmt.Errorf("empty variable name in ${%s}", expr)
+	}
+
+	v, ok, err := e.value(name, self)
+	if err != nil {
+		return "", err
+	}
+	if ok && v != "" {
+		return v, nil
+	}
+
+	switch op {
+	case ":-":
+		return e.expand(arg, self)
+	case ":?":
+		msg, err := e.expand(arg, self)
+		if err != nil {
+			return "", err
+		}
+		if msg == "" {
+			msg = "is not set or empty"
+		}
+		return "", fmt.Errorf("%s: %s", name, msg)
+	}
+	return v, nil
+}
+
+// matchingBrace returns the index of the } that closes the { at open, or -1.
+func matchingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isVarNameChar(c byte, first bool) bool {
+	if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+		return true
+	}
+	return !first && c >= '0' && c <= '9'
+}
@@ -0,0 +1,136 @@
+package lxenv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpandPairs(t *testing.T) {
+	outer := map[string]string{
+		"HOME":  "/home/lx",
+		"PATH":  "/usr/bin",
+		"EMPTY": "",
+	}
+	lookup := func(key string) (string, bool) {
+		v, ok := outer[key]
+		return v, ok
+	}
+
+	tests := []struct {
+		name  string
+		pairs map[string]string
+		want  map[string]string
+	}{
+		{
+			name:  "no references",
+			pairs: map[string]string{"A": "plain value"},
+			want:  map[string]string{"A": "plain value"},
+		},
+		{
+			name: "braced and bare references to file keys",
+			pairs: map[string]string{
+				"DB_USER": "admin",
+				"DB_HOST": "localhost",
+				"URL":     "postgres://${DB_USER}@$DB_HOST/db",
+			},
+			want: map[string]string{
+				"DB_USER": "admin",
+				"DB_HOST": "localhost",
+				"URL":     "postgres://admin@localhost/db",
+			},
+		},
+		{
+			name:  "forward and chained references",
+			pairs: map[string]string{"A": "${B}-a", "B": "${C}-b", "C": "c"},
+			want:  map[string]string{"A": "c-b-a", "B": "c-b", "C": "c"},
+		},
+		{
+			name:  "process environment",
+			pairs: map[string]string{"CACHE": "$HOME/.cache"},
+			want:  map[string]string{"CACHE": "/home/lx/.cache"},
+		},
+		{
+			name:  "self reference sees previous value",
+			pairs: map[string]string{"PATH": "${PATH}:/opt/bin"},
+			want:  map[string]string{"PATH": "/usr/bin:/opt/bin"},
+		},
+		{
+			name:  "unset variable expands to empty",
+			pairs: map[string]string{"A": "[${MISSING}][$MISSING]"},
+			want:  map[string]string{"A": "[][]"},
+		},
+		{
+			name: "defaults",
+			pairs: map[string]string{
+				"A": "${MISSING:-fallback}",
+				"B": "${EMPTY:-fallback}",
+				"C": "${HOME:-fallback}",
+				"D": "${MISSING:-${HOME}/x}",
+			},
+			want: map[string]string{
+				"A": "fallback",
+				"B": "fallback",
+				"C": "/home/lx",
+				"D": "/home/lx/x",
+			},
+		},
+		{
+			name:  "dotted keys in braces",
+			pairs: map[string]string{"database.host": "db", "url": "jdbc://${database.host}:5432"},
+			want:  map[string]string{"database.host": "db", "url": "jdbc://db:5432"},
+		},
+		{
+			name:  "escapes and lone dollars",
+			pairs: map[string]string{"A": `price \$5 costs $ and \${HOME} $`},
+			want:  map[string]string{"A": "price $5 costs $ and ${HOME} $"},
+		},
+		{
+			name:  "double dollars",
+			pairs: map[string]string{"A": "pa$$word", "B": "$${HOME} $$$HOME"},
+			want:  map[string]string{"A": "pa$word", "B": "${HOME} $/home/lx"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := expandPairs(tt.pairs, nil, nil, lookup)
+			if err != nil {
+				t.Fatalf("expandPairs() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandPairs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandPairs_Errors(t *testing.T) {
+	lookup := func(string) (string, bool) { return "", false }
+
+	tests := []struct {
+		name  string
+		pairs map[string]string
+		want  string
+	}{
+		{"direct cycle", map[string]string{"A": "${B}", "B": "${A}"}, "variable reference cycle: A -> B -> A"},
+		{"long cycle", map[string]string{"A": "$B", "B": "$C", "C": "$A"}, "A -> B -> C -> A"},
+		{"required with message", map[string]string{"A": "${DB_PASS:?must be provided}"}, "DB_PASS: must be provided"},
+		{"required without message", map[string]string{"A": "${DB_PASS:?}"}, "DB_PASS: is not set or empty"},
+		{"unterminated", map[string]string{"A": "${OPEN"}, "unterminated variable reference"},
+		{"invalid operator", map[string]string{"A": "${A:+x}"}, "invalid variable reference"},
+		{"empty name", map[string]string{"A": "${:-x}"}, "empty variable name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := expandPairs(tt.pairs, nil, nil, lookup)
+			if err == nil {
+				t.Fatalf("expandPairs(%q) expected error, got nil", tt.pairs)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expandPairs(%q) error = %q, want it to contain %q", tt.pairs, err, tt.want)
+			}
+		})
+	}
+}
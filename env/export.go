@@ -0,0 +1,299 @@
+package lxenv
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Format names a configuration file format that can be written with Write.
+type Format string
+
+// Formats supported by Write, matching the formats the loaders read.
+const (
+	FormatEnv        Format = "env"
+	FormatProperties Format = "properties"
+	FormatYAML       Format = "yaml"
+	FormatTOML       Format = "toml"
+	FormatJSON       Format = "json"
+	FormatINI        Format = "ini"
+)
+
+// WriteOption configures Write.
+type WriteOption func(*writeOptions)
+
+type writeOptions struct {
+	protect bool
+}
+
+// WithProtectedReferences writes values that look like value references (see
+// Resolve), such as file:///run/secrets/db, as a base64: reference to
+// themselves, so that reading the output back returns the original text
+// instead of resolving it. Off by default: references are written as they
+// are and resolved when the output is loaded, which is what rendered
+// deployment config expects.
+func WithProtectedReferences(enabled bool) WriteOption {
+	return func(o *writeOptions) {
+		o.protect = enabled
+	}
+}
+
+// Write writes pairs to w in format, one key per entry in sorted order.
+// Keys are written flat, so "db.host" stays a single quoted key rather than a
+// nested table, and reading the output back with the matching Read or Parse
+// function returns pairs:
+//
+//   - values are quoted and escaped as the format requires
+//   - $ is written as \$ so that variable expansion leaves values unchanged
+//   - values that look like value references are written as they are and
+//     resolved again when read, unless WithProtectedReferences is set
+//
+// Returns ErrUnsupportedFormat for an unknown format and ErrInvalidValue for a
+// key or value the format cannot represent, such as a line break in a .env value.
+//
+// Example:
+//
+//	err := lxenv.Write(os.Stdout, lxenv.FormatYAML, lxenv.Export("APP_"))
+func Write(w io.Writer, format Format, pairs map[string]string, opts ...WriteOption) error {
+	var o writeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	value := func(v string) string {
+		if o.protect {
+			return protectReference(v)
+		}
+		return v
+	}
+
+	var writeEntry func(w io.Writer, key, value string) error
+	switch format {
+	case FormatEnv:
+		writeEntry = writeEnvEntry
+	case FormatProperties:
+		writeEntry = writePropertiesEntry
+	case FormatYAML:
+		writeEntry = writeYAMLEntry
+	case FormatTOML:
+		writeEntry = writeTOMLEntry
+	case FormatINI:
+		writeEntry = writeINIEntry
+	case FormatJSON:
+		return writeJSON(w, pairs, value)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	keys := make([]string, 0, len(pairs))
+	for k := range pairs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	bw := bufio.NewWriter(w)
+	for _, k := range keys {
+		if err := writeEntry(bw, k, value(pairs[k])); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// Export returns the environment variables whose names start with prefix,
+// keeping the prefix in the keys. An empty prefix returns the whole environment.
+//
+// Example:
+//
+//	lxenv.Write(f, lxenv.FormatEnv, lxenv.Export("APP_"))
+func Export(prefix string) map[string]string {
+	pairs := make(map[string]string)
+	for _, kv := range os.Environ() {
+		k, v, ok := strings.Cut(kv, "=")
+		if ok && k != "" && strings.HasPrefix(k, prefix) {
+			pairs[k] = v
+		}
+	}
+	return pairs
+}
+
+// escapeDollar protects $ from variable expansion.
+func escapeDollar(value string) string {
+	return strings.ReplaceAll(value, "$", `\$`)
+}
+
+// unescapeDollar is what variable expansion makes of a value escaped by escapeDollar.
+func unescapeDollar(value string) string {
+	return strings.ReplaceAll(value, `\$`, "$")
+}
+
+// protectReference returns value, or a base64: reference resolving to value
+// if value would otherwise be resolved when read back.
+func protectReference(value string) string {
+	if !isReference(value) {
+		return value
+	}
+	return "base64:" + base64.StdEncoding.EncodeToString([]byte(value))
+}
+
+// unrepresentable reports a key or value that format cannot hold.
+func unrepresentable(format Format, key, what string) error {
+	return fmt.Errorf("%w: %s: %s cannot be written to %s", ErrInvalidValue, key, what, format)
+}
+
+// writeEnvEntry writes KEY=value, quoting the value when needed.
+func writeEnvEntry(w io.Writer, key, value string) error {
+	if key == "" || strings.TrimSpace(key) != key || strings.ContainsAny(key, "=\r\n") || key[0] == '#' {
+		return unrepresentable(FormatEnv, key, "key")
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return unrepresentable(FormatEnv, key, "line break")
+	}
+
+	// single-quoted values are not expanded, so they keep $ unescaped
+	escaped := escapeDollar(value)
+	quoted, ok := quoteLineValue(value, func(s string) string {
+		if isSingleQuoted(defaultValueNormalizer.stripInlineComment(s)) {
+			return defaultValueNormalizer.Normalize(s)
+		}
+		return unescapeDollar(defaultValueNormalizer.Normalize(s))
+	}, escaped, `"`+escaped+`"`, `'`+value+`'`)
+	if !ok {
+		return unrepresentable(FormatEnv, key, "value")
+	}
+	_, err := fmt.Fprintf(w, "%s=%s\n", key, quoted)
+	return err
+}
+
+// writeINIEntry writes key = value, quoting the value when needed.
+func writeINIEntry(w io.Writer, key, value string) error {
+	if key == "" || strings.TrimSpace(key) != key || strings.ContainsAny(key, "=:\r\n") ||
+		strings.ContainsRune("[;#", rune(key[0])) || strings.HasSuffix(key, "[]") {
+		return unrepresentable(FormatINI, key, "key")
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return unrepresentable(FormatINI, key, "line break")
+	}
+
+	// the value follows "key = ", whose space lets ; and # start a comment
+	escaped := escapeDollar(value)
+	quoted, ok := quoteLineValue(value, func(s string) string {
+		return unescapeDollar(defaultValueNormalizer.unquote(strings.TrimSpace(stripINIComment(" " + s))))
+	}, escaped, `"`+escaped+`"`, `'`+escaped+`'`)
+	if !ok {
+		return unrepresentable(FormatINI, key, "value")
+	}
+	_, err := fmt.Fprintf(w, "%s = %s\n", key, quoted)
+	return err
+}
+
+// quoteLineValue returns the first of candidates, usually value as is, in
+// double quotes and in single quotes, that read reads back as value. It
+// reports false if none does.
+func quoteLineValue(value string, read func(string) string, candidates ...string) (string, bool) {
+	for _, candidate := range candidates {
+		if read(candidate) == value {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// writePropertiesEntry writes key=value with java.util.Properties escapes.
+func writePropertiesEntry(w io.Writer, key, value string) error {
+	_, err := fmt.Fprintf(w, "%s=%s\n", escapeProperties(key, true), escapeProperties(escapeDollar(value), false))
+	return err
+}
+
+// escapeProperties escapes s for a .properties file. Spaces are escaped
+// everywhere in keys but only at the start of values.
+func escapeProperties(s string, isKey bool) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '=', ':', '#', '!':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case ' ':
+			if isKey || i == 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(' ')
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
+
+// writeYAMLEntry writes "key": "value" with YAML double-quoted escapes.
+func writeYAMLEntry(w io.Writer, key, value string) error {
+	_, err := fmt.Fprintf(w, "%s: %s\n", quoteEscaped(key), quoteEscaped(escapeDollar(value)))
+	return err
+}
+
+// writeTOMLEntry writes "key" = "value" as TOML basic strings.
+func writeTOMLEntry(w io.Writer, key, value string) error {
+	_, err := fmt.Fprintf(w, "%s = %s\n", quoteEscaped(key), quoteEscaped(escapeDollar(value)))
+	return err
+}
+
+// quoteEscaped returns s as a double-quoted string with the escapes shared by
+// YAML and TOML: \\, \", \t, \n, \r and \uXXXX for other control characters.
+func quoteEscaped(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f || r == '\u0085' || r == '\u2028' || r == '\u2029' || r == '\ufeff' {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// writeJSON writes pairs as a flat, indented JSON object.
+func writeJSON(w io.Writer, pairs map[string]string, value func(string) string) error {
+	escaped := make(map[string]string, len(pairs))
+	for k, v := range pairs {
+		escaped[k] = escapeDollar(value(v))
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(escaped)
+}
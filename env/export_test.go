@@ -0,0 +1,171 @@
+package lxenv_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hgapdvn/lx/env"
+)
+
+// exportValues are single-line values that need quoting or escaping in at
+// least one format.
+var exportValues = map[string]string{
+	"PLAIN":        "value",
+	"EMPTY":        "",
+	"SPACES":       "hello world",
+	"PADDED":       "  padded  ",
+	"HASH":         "a #not a comment",
+	"DOUBLE_QUOTE": `say "hi"`,
+	"SINGLE_QUOTE": "it's",
+	"BOTH_QUOTES":  `'a' "b"`,
+	"DOLLAR":       "$HOME and ${USER}",
+	"BACKSLASH":    `C:\path\to\file`,
+	"SEPARATORS":   "a=b:c;d",
+	"UNICODE":      "héllo 世界",
+	"TAB":          "a\tb",
+	"BRACKETS":     "[1, 2]",
+	"BOOLISH":      "true",
+	"LEAD_HASH":    "#lead",
+	"LEAD_SEMI":    ";lead",
+	"QUOTED_HASH":  `say "hi" #1 costs $5`,
+	"BASE64_REF":   "base64:Zm9v",
+	"FILE_REF":     "file:///run/secrets/db",
+	"db.host":      "localhost",
+}
+
+func TestWrite_RoundTrip(t *testing.T) {
+	multiline := map[string]string{}
+	for k, v := range exportValues {
+		multiline[k] = v
+	}
+	multiline["MULTILINE"] = "line1\nline2\r\nline3"
+	multiline["CONTROL"] = "bell\a end"
+	multiline["key with spaces"] = "v"
+
+	tests := []struct {
+		format lxenv.Format
+		parse  func(io.Reader) (map[string]string, error)
+		pairs  map[string]string
+	}{
+		{lxenv.FormatEnv, lxenv.ParseEnv, exportValues},
+		{lxenv.FormatINI, lxenv.ParseINI, exportValues},
+		{lxenv.FormatProperties, lxenv.ParseProperties, multiline},
+		{lxenv.FormatYAML, lxenv.ParseYML, multiline},
+		{lxenv.FormatTOML, lxenv.ParseTOML, multiline},
+		{lxenv.FormatJSON, lxenv.ParseJSON, multiline},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := lxenv.Write(&buf, tt.format, tt.pairs, lxenv.WithProtectedReferences(true)); err != nil {
+				t.Fatalf("Write() unexpected error: %v", err)
+			}
+			got, err := tt.parse(bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatalf("parse(Write()) unexpected error: %v\n%s", err, buf.String())
+			}
+			if !reflect.DeepEqual(got, tt.pairs) {
+				for k, want := range tt.pairs {
+					if got[k] != want {
+						t.Errorf("%s = %q, want %q", k, got[k], want)
+					}
+				}
+				t.Fatalf("parse(Write()) = %d keys, want %d\n%s", len(got), len(tt.pairs), buf.String())
+			}
+		})
+	}
+}
+
+func TestWrite_Output(t *testing.T) {
+	pairs := map[string]string{"B": "two words", "A": "1"}
+
+	tests := []struct {
+		format lxenv.Format
+		want   string
+	}{
+		{lxenv.FormatEnv, "A=1\nB=two words\n"},
+		{lxenv.FormatProperties, "A=1\nB=two words\n"},
+		{lxenv.FormatYAML, "\"A\": \"1\"\n\"B\": \"two words\"\n"},
+		{lxenv.FormatTOML, "\"A\" = \"1\"\n\"B\" = \"two words\"\n"},
+		{lxenv.FormatINI, "A = 1\nB = two words\n"},
+		{lxenv.FormatJSON, "{\n  \"A\": \"1\",\n  \"B\": \"two words\"\n}\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := lxenv.Write(&buf, tt.format, pairs); err != nil {
+			t.Fatalf("Write(%s) unexpected error: %v", tt.format, err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("Write(%s) = %q, want %q", tt.format, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestWrite_References(t *testing.T) {
+	pairs := map[string]string{"DB_PASS": "file:///run/secrets/db"}
+
+	tests := []struct {
+		opts []lxenv.WriteOption
+		want string
+	}{
+		{nil, "DB_PASS=file:///run/secrets/db\n"},
+		{[]lxenv.WriteOption{lxenv.WithProtectedReferences(true)}, "DB_PASS=base64:ZmlsZTovLy9ydW4vc2VjcmV0cy9kYg==\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := lxenv.Write(&buf, lxenv.FormatEnv, pairs, tt.opts...); err != nil {
+			t.Fatalf("Write() unexpected error: %v", err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("Write() = %q, want %q", buf.String(), tt.want)
+		}
+	}
+}
+
+func TestWrite_Errors(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := lxenv.Write(&buf, "xml", map[string]string{"A": "1"}); !errors.Is(err, lxenv.ErrUnsupportedFormat) {
+		t.Errorf("Write(xml) error = %v, want ErrUnsupportedFormat", err)
+	}
+	for _, format := range []lxenv.Format{lxenv.FormatEnv, lxenv.FormatINI} {
+		if err := lxenv.Write(&buf, format, map[string]string{"A": "a\nb"}); !errors.Is(err, lxenv.ErrInvalidValue) {
+			t.Errorf("Write(%s) of a multi-line value error = %v, want ErrInvalidValue", format, err)
+		}
+		if err := lxenv.Write(&buf, format, map[string]string{"A=B": "1"}); !errors.Is(err, lxenv.ErrInvalidValue) {
+			t.Errorf("Write(%s) of an invalid key error = %v, want ErrInvalidValue", format, err)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	t.Setenv("TEST_EXPORT_A", "1")
+	t.Setenv("TEST_EXPORT_B", "x=y")
+	t.Setenv("TEST_EXPORTX", "other")
+
+	got := lxenv.Export("TEST_EXPORT_")
+	want := map[string]string{"TEST_EXPORT_A": "1", "TEST_EXPORT_B": "x=y"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Export() = %v, want %v", got, want)
+	}
+
+	all := lxenv.Export("")
+	if all["TEST_EXPORTX"] != "other" {
+		t.Error("Export(\"\") should return the whole environment")
+	}
+
+	var buf bytes.Buffer
+	if err := lxenv.Write(&buf, lxenv.FormatEnv, got); err != nil {
+		t.Fatalf("Write() unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "TEST_EXPORT_B=x=y\n") {
+		t.Errorf("Write(Export()) = %q", buf.String())
+	}
+}
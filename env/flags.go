@@ -0,0 +1,213 @@
+package lxenv
+
+import (
+	"flag"
+	"fmt"
+)
+
+// FlagOrigin names where the value of a flag bound by BindFlags comes from.
+type FlagOrigin string
+
+// Origins of flag values, from highest to lowest precedence.
+const (
+	FromFlag    FlagOrigin = "flag"
+	FromEnv     FlagOrigin = "environment"
+	FromFile    FlagOrigin = "file"
+	FromDefault FlagOrigin = "default"
+)
+
+// FlagValue reports the value a flag ended up with and where it comes from.
+type FlagValue struct {
+	Name   string     // flag name
+	Env    string     // environment variable consulted for the flag, empty if none
+	Value  string     // final value, as returned by the flag's String method
+	Origin FlagOrigin // FromFlag, FromEnv, FromFile or FromDefault
+	Source Source     // file and line of the value, when known
+}
+
+// String describes the flag value and its origin, masking secrets as Dump
+// does, e.g. "-port=8080 (environment PORT)" or "-db-host=db (config.yml:3)".
+func (v FlagValue) String() string {
+	secret := isSecretKey(v.Name) || v.Source.isSecret(v.Env, DefaultSecretPatterns)
+	value, _ := maskValue(v.Value, secret)
+	return fmt.Sprintf("-%s=%s (%s)", v.Name, value, v.from())
+}
+
+// from describes the origin of the value.
+func (v FlagValue) from() string {
+	switch {
+	case v.Source.File != "":
+		return v.Source.String()
+	case v.Origin == FromEnv:
+		return string(FromEnv) + " " + v.Env
+	}
+	return string(v.Origin)
+}
+
+// FlagOption configures BindFlags.
+type FlagOption func(*flagOptions)
+
+type flagOptions struct {
+	prefix   string
+	env      map[string]string
+	defaults *Config
+}
+
+// WithFlagEnvPrefix derives the environment variable of each flag as prefix
+// followed by the UpperSnakeCase flag name, e.g. APP_DB_HOST for -db-host
+// with prefix "APP_". Without a prefix, -db-host reads DB_HOST.
+func WithFlagEnvPrefix(prefix string) FlagOption {
+	return func(o *flagOptions) {
+		o.prefix = prefix
+	}
+}
+
+// WithFlagEnv makes the flag name read the environment variable key instead
+// of its derived name. An empty key disables the environment fallback for the flag.
+func WithFlagEnv(name, key string) FlagOption {
+	return func(o *flagOptions) {
+		if o.env == nil {
+			o.env = make(map[string]string)
+		}
+		o.env[name] = key
+	}
+}
+
+// WithFlagDefaults supplies file values for flags that are set neither on the
+// command line nor in the environment, typically a Config returned by Read.
+// Values are looked up by the flag's environment variable, then by its name,
+// then by any key with the same UpperSnakeCase form, so -db-host finds the
+// key db.host of a YAML file.
+//
+// Example:
+//
+//	cfg, err := lxenv.Read("config.yml")
+//	values, err := lxenv.BindFlags(fs, os.Args[1:], lxenv.WithFlagDefaults(cfg))
+func WithFlagDefaults(cfg *Config) FlagOption {
+	return func(o *flagOptions) {
+		o.defaults = cfg
+	}
+}
+
+// BindFlags parses args into fs and fills every flag that was not given on
+// the command line from, in order of precedence:
+//
+//  1. the environment variable of the flag (see WithFlagEnvPrefix and WithFlagEnv)
+//  2. the file values of WithFlagDefaults
+//  3. the flag's own default
+//
+// Values from the environment and files are resolved as in Get when
+// ResolveOnGet is enabled, and set with the flag's Set method, so they are
+// validated like command-line values.
+// BindFlags returns the final value and origin of every flag, in
+// lexicographical order.
+//
+// Returns the error of fs.Parse, such as flag.ErrHelp, or ErrInvalidValue if
+// a value from the environment or a file is rejected by its flag.
+//
+// Example:
+//
+//	fs := flag.NewFlagSet("server", flag.ExitOnError)
+//	port := fs.Int("port", 8080, "listen port")
+//	cfg, _ := lxenv.Read("config.yml")
+//
+//	values, err := lxenv.BindFlags(fs, os.Args[1:],
+//	    lxenv.WithFlagEnvPrefix("SERVER_"),
+//	    lxenv.WithFlagDefaults(cfg),
+//	)
+//	for _, v := range values {
+//	    log.Println(v) // -port=9090 (environment SERVER_PORT)
+//	}
+func BindFlags(fs *flag.FlagSet, args []string, opts ...FlagOption) ([]FlagValue, error) {
+	var o flagOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	given := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		given[f.Name] = true
+	})
+
+	var (
+		values []FlagValue
+		err    error
+	)
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil {
+			return
+		}
+		v := FlagValue{Name: f.Name, Env: o.envKey(f.Name), Origin: FromDefault}
+		if given[f.Name] {
+			v.Origin = FromFlag
+		} else if raw, src, origin, ok := o.lookup(v); ok {
+			v.Origin, v.Source = origin, src
+			if err = setFlag(f, &v, raw); err != nil {
+				return
+			}
+		}
+		v.Value = f.Value.String()
+		values = append(values, v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// envKey returns the environment variable consulted for the flag name.
+func (o flagOptions) envKey(name string) string {
+	if key, ok := o.env[name]; ok {
+		return key
+	}
+	return o.prefix + UpperSnakeCase(name)
+}
+
+// lookup finds the raw value for a flag that was not given on the command line.
+func (o flagOptions) lookup(v FlagValue) (string, Source, FlagOrigin, bool) {
+	if v.Env != "" {
+		if raw, ok := Lookup(v.Env); ok {
+			p, _ := Origin(v.Env)
+			if p.File == "" {
+				p.Source = Source{}
+			}
+			return raw, p.Source, FromEnv, true
+		}
+	}
+	if o.defaults != nil {
+		keys := []string{v.Env, v.Name}
+		name := UpperSnakeCase(v.Name)
+		for _, k := range o.defaults.Keys() {
+			if UpperSnakeCase(k) == name {
+				keys = append(keys, k)
+			}
+		}
+		for _, key := range keys {
+			if key == "" {
+				continue
+			}
+			if raw, ok := o.defaults.Lookup(key); ok {
+				p, _ := o.defaults.Origin(key)
+				return raw, p.Source, FromFile, true
+			}
+		}
+	}
+	return "", Source{}, "", false
+}
+
+// setFlag resolves raw as in Get, which comes from the origin described by v,
+// and sets it as the value of f.
+func setFlag(f *flag.Flag, v *FlagValue, raw string) error {
+	value, resolved, err := resolveForGet(raw)
+	if err != nil {
+		return fmt.Errorf("flag -%s from %s: %w", f.Name, v.from(), err)
+	}
+	v.Source.Resolved = v.Source.Resolved || resolved
+	if err := f.Value.Set(value); err != nil {
+		return fmt.Errorf("%w: flag -%s from %s: %v", ErrInvalidValue, f.Name, v.from(), err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package lxenv_test
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hgapdvn/lx/env"
+)
+
+func newTestFlagSet() (*flag.FlagSet, *int, *string, *time.Duration, *bool) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	port := fs.Int("port", 8080, "listen port")
+	host := fs.String("db-host", "localhost", "database host")
+	timeout := fs.Duration("timeout", time.Second, "request timeout")
+	debug := fs.Bool("debug", false, "debug mode")
+	return fs, port, host, timeout, debug
+}
+
+func TestBindFlags_Precedence(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{"config.yml": "port: 7000\ndb:\n  host: db.file\ntimeout: 5s\n"})
+	path := filepath.Join(dir, "config.yml")
+	cfg, err := lxenv.Read(path)
+	if err != nil {
+		t.Fatalf("Read() unexpected error: %v", err)
+	}
+	t.Setenv("TEST_FLAG_PORT", "9000")
+	t.Setenv("TEST_FLAG_TIMEOUT", "1m")
+
+	fs, port, host, timeout, debug := newTestFlagSet()
+	values, err := lxenv.BindFlags(fs, []string{"-timeout", "2m"},
+		lxenv.WithFlagEnvPrefix("TEST_FLAG_"),
+		lxenv.WithFlagDefaults(cfg),
+	)
+	if err != nil {
+		t.Fatalf("BindFlags() unexpected error: %v", err)
+	}
+
+	if *timeout != 2*time.Minute || *port != 9000 || *host != "db.file" || *debug {
+		t.Errorf("BindFlags() = timeout %v, port %d, host %q, debug %v", *timeout, *port, *host, *debug)
+	}
+
+	want := []string{
+		"-db-host=db.file (" + path + ":3)",
+		"-debug=false (default)",
+		"-port=9000 (environment TEST_FLAG_PORT)",
+		"-timeout=2m0s (flag)",
+	}
+	if len(values) != len(want) {
+		t.Fatalf("BindFlags() = %v, want %d values", values, len(want))
+	}
+	for i, v := range values {
+		if v.String() != want[i] {
+			t.Errorf("values[%d] = %q, want %q", i, v, want[i])
+		}
+	}
+	if values[0].Origin != lxenv.FromFile || values[0].Source.Format != "yaml" {
+		t.Errorf("values[0] = %+v, want a yaml file origin", values[0])
+	}
+}
+
+func TestBindFlags_ExplicitEnv(t *testing.T) {
+	t.Setenv("LISTEN_PORT", "9100")
+	t.Setenv("DEBUG", "true")
+
+	fs, port, _, _, debug := newTestFlagSet()
+	values, err := lxenv.BindFlags(fs, nil,
+		lxenv.WithFlagEnv("port", "LISTEN_PORT"),
+		lxenv.WithFlagEnv("debug", ""),
+	)
+	if err != nil {
+		t.Fatalf("BindFlags() unexpected error: %v", err)
+	}
+	if *port != 9100 {
+		t.Errorf("port = %d, want 9100", *port)
+	}
+	if *debug {
+		t.Error("debug should ignore DEBUG when its environment fallback is disabled")
+	}
+	for _, v := range values {
+		if v.Name == "debug" && (v.Env != "" || v.Origin != lxenv.FromDefault) {
+			t.Errorf("debug = %+v", v)
+		}
+	}
+}
+
+func TestBindFlags_Errors(t *testing.T) {
+	t.Setenv("TEST_FLAGERR_PORT", "http")
+
+	fs, _, _, _, _ := newTestFlagSet()
+	_, err := lxenv.BindFlags(fs, nil, lxenv.WithFlagEnvPrefix("TEST_FLAGERR_"))
+	if !errors.Is(err, lxenv.ErrInvalidValue) {
+		t.Errorf("BindFlags() error = %v, want ErrInvalidValue", err)
+	}
+
+	fs, _, _, _, _ = newTestFlagSet()
+	if _, err := lxenv.BindFlags(fs, []string{"-help"}); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("BindFlags(-help) error = %v, want flag.ErrHelp", err)
+	}
+}
+
+func TestFlagValue_MasksSecrets(t *testing.T) {
+	v := lxenv.FlagValue{Name: "db-password", Value: "hunter2", Origin: lxenv.FromFlag}
+	if got := v.String(); got != "-db-password=****** (flag)" {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -0,0 +1,84 @@
+package lxenv
+
+import (
+	"strconv"
+	"strings"
+)
+
+// flattenValue writes the leaves of a decoded document into pairs using the
+// same dot-notation rules as flattenYAML. v is built from map[string]any,
+// []any and string values; nil is stored as an empty string.
+func flattenValue(prefix string, v any, pairs map[string]string) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			flattenValue(joinKey(prefix, k), item, pairs)
+		}
+	case []any:
+		scalars := make([]string, 0, len(v))
+		for i, item := range v {
+			flattenValue(joinKey(prefix, strconv.Itoa(i)), item, pairs)
+			if s, ok := scalarValue(item); ok {
+				scalars = append(scalars, s)
+			}
+		}
+		if prefix != "" && len(scalars) == len(v) {
+			pairs[prefix] = strings.Join(scalars, ",")
+		}
+	default:
+		if s, ok := scalarValue(v); ok && prefix != "" {
+			pairs[prefix] = s
+		}
+	}
+}
+
+// literalString is a scalar leaf whose syntax marks it as literal, such as a
+// TOML literal string. Its value is never expanded.
+type literalString string
+
+// scalarValue returns the text of a scalar leaf.
+func scalarValue(v any) (string, bool) {
+	switch v := v.(type) {
+	case nil:
+		return "", true
+	case string:
+		return v, true
+	case literalString:
+		return string(v), true
+	}
+	return "", false
+}
+
+// flattenLiterals records the keys that flattenValue stores for v whose value
+// is a literalString, or a joined list made only of them.
+func flattenLiterals(prefix string, v any, literals map[string]bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			flattenLiterals(joinKey(prefix, k), item, literals)
+		}
+	case []any:
+		all := len(v) > 0
+		for i, item := range v {
+			flattenLiterals(joinKey(prefix, strconv.Itoa(i)), item, literals)
+			if _, ok := item.(literalString); !ok {
+				all = false
+			}
+		}
+		if prefix != "" && all {
+			literals[prefix] = true
+		}
+	case literalString:
+		if prefix != "" {
+			literals[prefix] = true
+		}
+	}
+}
+
+// joinKey appends key to a dot-notation prefix.
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
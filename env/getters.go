@@ -0,0 +1,573 @@
+package lxenv
+
+import (
+	"math"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetStringSlice retrieves an environment variable as a list of strings split by sep.
+// Items are trimmed of surrounding whitespace and empty items are dropped.
+// An empty sep splits on commas.
+// Returns (nil, false) if the variable is not set or contains no items.
+//
+// Example:
+//
+//	// HOSTS="a.local, b.local"
+//	hosts, ok := lxenv.GetStringSlice("HOSTS", ",")
+//	// hosts: []string{"a.local", "b.local"}
+func GetStringSlice(key, sep string) ([]string, bool) {
+	return stringSliceValue(Get(key), sep)
+}
+
+// GetStringSliceOr retrieves an environment variable as a list of strings split by sep,
+// or returns defaultValue if it is not set or contains no items.
+//
+// Example:
+//
+//	hosts := lxenv.GetStringSliceOr("HOSTS", ",", []string{"localhost"})
+func GetStringSliceOr(key, sep string, defaultValue []string) []string {
+	if value, ok := GetStringSlice(key, sep); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetStringSlice retrieves an environment variable as a list of strings split by sep.
+// Panics if the variable is not set or contains no items.
+//
+// Example:
+//
+//	hosts := lxenv.MustGetStringSlice("HOSTS", ",")
+func MustGetStringSlice(key, sep string) []string {
+	value, ok := GetStringSlice(key, sep)
+	if !ok {
+		panic("lxenv: environment variable " + key + " is not set or empty")
+	}
+	return value
+}
+
+// GetIntSlice retrieves an environment variable as a comma-separated list of integers.
+// Returns (nil, false) if the variable is not set, contains no items or any item
+// cannot be parsed.
+//
+// Example:
+//
+//	// PORTS="80, 443"
+//	ports, ok := lxenv.GetIntSlice("PORTS")
+//	// ports: []int{80, 443}
+func GetIntSlice(key string) ([]int, bool) {
+	return intSliceValue(Get(key))
+}
+
+// GetIntSliceOr retrieves an environment variable as a comma-separated list of integers,
+// or returns defaultValue if it is not set or invalid.
+//
+// Example:
+//
+//	ports := lxenv.GetIntSliceOr("PORTS", []int{8080})
+func GetIntSliceOr(key string, defaultValue []int) []int {
+	if value, ok := GetIntSlice(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetIntSlice retrieves an environment variable as a comma-separated list of integers.
+// Panics if the variable is not set or cannot be parsed.
+//
+// Example:
+//
+//	ports := lxenv.MustGetIntSlice("PORTS")
+func MustGetIntSlice(key string) []int {
+	value, ok := GetIntSlice(key)
+	if !ok {
+		panic("lxenv: environment variable " + key + " is not set or not a valid integer list")
+	}
+	return value
+}
+
+// GetMap retrieves an environment variable of the form "k1=v1,k2=v2" as a map.
+// Keys and values are trimmed of surrounding whitespace and a value may contain '='.
+// Returns (nil, false) if the variable is not set, empty, or an item has no key or no '='.
+//
+// Example:
+//
+//	// LABELS="team=core, tier=backend"
+//	labels, ok := lxenv.GetMap("LABELS")
+//	// labels: map[string]string{"team": "core", "tier": "backend"}
+func GetMap(key string) (map[string]string, bool) {
+	return mapValue(Get(key))
+}
+
+// GetMapOr retrieves an environment variable as a map, or returns defaultValue
+// if it is not set or invalid.
+//
+// Example:
+//
+//	labels := lxenv.GetMapOr("LABELS", map[string]string{"team": "none"})
+func GetMapOr(key string, defaultValue map[string]string) map[string]string {
+	if value, ok := GetMap(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetMap retrieves an environment variable as a map.
+// Panics if the variable is not set or cannot be parsed as a map.
+//
+// Example:
+//
+//	labels := lxenv.MustGetMap("LABELS")
+func MustGetMap(key string) map[string]string {
+	value, ok := GetMap(key)
+	if !ok {
+		panic("lxenv: environment variable " + key + " is not set or not a valid map")
+	}
+	return value
+}
+
+// GetURL retrieves an environment variable as an absolute URL.
+// Returns (nil, false) if the variable is not set, cannot be parsed or has no scheme.
+//
+// Example:
+//
+//	if u, ok := lxenv.GetURL("API_URL"); ok {
+//	    // u.Host: "api.example.com"
+//	}
+func GetURL(key string) (*url.URL, bool) {
+	return urlValue(Get(key))
+}
+
+// GetURLOr retrieves an environment variable as an absolute URL, or returns
+// defaultValue if it is not set or invalid.
+//
+// Example:
+//
+//	u := lxenv.GetURLOr("API_URL", &url.URL{Scheme: "http", Host: "localhost:8080"})
+func GetURLOr(key string, defaultValue *url.URL) *url.URL {
+	if value, ok := GetURL(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetURL retrieves an environment variable as an absolute URL.
+// Panics if the variable is not set or is not a valid absolute URL.
+//
+// Example:
+//
+//	u := lxenv.MustGetURL("API_URL")
+func MustGetURL(key string) *url.URL {
+	value, ok := GetURL(key)
+	if !ok {
+		panic("lxenv: environment variable " + key + " is not set or not a valid URL")
+	}
+	return value
+}
+
+// GetByteSize retrieves an environment variable as a number of bytes.
+// Accepts a non-negative number, optionally fractional, followed by a unit:
+//
+//	B                              bytes (also no unit)
+//	KB, MB, GB, TB, PB, EB         powers of 1000
+//	KiB, MiB, GiB, TiB, PiB, EiB   powers of 1024
+//	K, M, G, T, P, E               powers of 1024, as in JVM and Docker flags
+//
+// Units are case-insensitive and may be separated from the number by spaces.
+// Returns (0, false) if the variable is not set, cannot be parsed or overflows int64.
+//
+// Example:
+//
+//	// MAX_UPLOAD="1.5GB"
+//	size, ok := lxenv.GetByteSize("MAX_UPLOAD")
+//	// size: 1500000000
+func GetByteSize(key string) (int64, bool) {
+	return byteSizeValue(Get(key))
+}
+
+// GetByteSizeOr retrieves an environment variable as a number of bytes, or
+// returns defaultValue if it is not set or invalid.
+//
+// Example:
+//
+//	size := lxenv.GetByteSizeOr("MAX_UPLOAD", 512<<20)
+func GetByteSizeOr(key string, defaultValue int64) int64 {
+	if value, ok := GetByteSize(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetByteSize retrieves an environment variable as a number of bytes.
+// Panics if the variable is not set or cannot be parsed as a byte size.
+//
+// Example:
+//
+//	size := lxenv.MustGetByteSize("MAX_UPLOAD")
+func MustGetByteSize(key string) int64 {
+	value, ok := GetByteSize(key)
+	if !ok {
+		panic("lxenv: environment variable " + key + " is not set or not a valid byte size")
+	}
+	return value
+}
+
+// GetTime retrieves an environment variable as a time, trying each layout in order.
+// With no layouts the value must be in RFC 3339 format, with optional fractional seconds.
+// Returns (zero time, false) if the variable is not set or matches no layout.
+//
+// Example:
+//
+//	start, ok := lxenv.GetTime("START_AT")                 // 2024-01-02T15:04:05Z
+//	day, ok := lxenv.GetTime("RELEASE_DAY", "2006-01-02")  // 2024-01-02
+func GetTime(key string, layouts ...string) (time.Time, bool) {
+	return timeValue(Get(key), layouts)
+}
+
+// GetTimeOr retrieves an environment variable as a time, or returns defaultValue
+// if it is not set or matches none of layouts.
+//
+// Example:
+//
+//	start := lxenv.GetTimeOr("START_AT", time.Now())
+func GetTimeOr(key string, defaultValue time.Time, layouts ...string) time.Time {
+	if value, ok := GetTime(key, layouts...); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetTime retrieves an environment variable as a time.
+// Panics if the variable is not set or matches none of layouts.
+//
+// Example:
+//
+//	start := lxenv.MustGetTime("START_AT", time.RFC1123)
+func MustGetTime(key string, layouts ...string) time.Time {
+	value, ok := GetTime(key, layouts...)
+	if !ok {
+		panic("lxenv: environment variable " + key + " is not set or not a valid time")
+	}
+	return value
+}
+
+// GetLocation retrieves an environment variable as a time zone location,
+// such as "UTC", "Local" or "Europe/Paris".
+// Returns (nil, false) if the variable is not set or names an unknown location.
+//
+// Example:
+//
+//	if loc, ok := lxenv.GetLocation("TZ"); ok {
+//	    now := time.Now().In(loc)
+//	}
+func GetLocation(key string) (*time.Location, bool) {
+	return locationValue(Get(key))
+}
+
+// GetLocationOr retrieves an environment variable as a time zone location, or
+// returns defaultValue if it is not set or unknown.
+//
+// Example:
+//
+//	loc := lxenv.GetLocationOr("TZ", time.UTC)
+func GetLocationOr(key string, defaultValue *time.Location) *time.Location {
+	if value, ok := GetLocation(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetLocation retrieves an environment variable as a time zone location.
+// Panics if the variable is not set or names an unknown location.
+//
+// Example:
+//
+//	loc := lxenv.MustGetLocation("TZ")
+func MustGetLocation(key string) *time.Location {
+	value, ok := GetLocation(key)
+	if !ok {
+		panic("lxenv: environment variable " + key + " is not set or not a valid location")
+	}
+	return value
+}
+
+// GetIP retrieves an environment variable as an IPv4 or IPv6 address.
+// Returns (nil, false) if the variable is not set or cannot be parsed.
+//
+// Example:
+//
+//	if ip, ok := lxenv.GetIP("BIND_ADDR"); ok {
+//	    // ip: 10.0.0.1
+//	}
+func GetIP(key string) (net.IP, bool) {
+	return ipValue(Get(key))
+}
+
+// GetIPOr retrieves an environment variable as an IP address, or returns
+// defaultValue if it is not set or invalid.
+//
+// Example:
+//
+//	ip := lxenv.GetIPOr("BIND_ADDR", net.IPv4zero)
+func GetIPOr(key string, defaultValue net.IP) net.IP {
+	if value, ok := GetIP(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetIP retrieves an environment variable as an IP address.
+// Panics if the variable is not set or cannot be parsed as an IP address.
+//
+// Example:
+//
+//	ip := lxenv.MustGetIP("BIND_ADDR")
+func MustGetIP(key string) net.IP {
+	value, ok := GetIP(key)
+	if !ok {
+		panic("lxenv: environment variable " + key + " is not set or not a valid IP address")
+	}
+	return value
+}
+
+// GetCIDR retrieves an environment variable as a network in CIDR notation,
+// such as "10.0.0.0/8" or "2001:db8::/32".
+// Returns (nil, false) if the variable is not set or cannot be parsed.
+//
+// Example:
+//
+//	if network, ok := lxenv.GetCIDR("TRUSTED_NET"); ok {
+//	    trusted := network.Contains(ip)
+//	}
+func GetCIDR(key string) (*net.IPNet, bool) {
+	return cidrValue(Get(key))
+}
+
+// GetCIDROr retrieves an environment variable as a network in CIDR notation,
+// or returns defaultValue if it is not set or invalid.
+//
+// Example:
+//
+//	network := lxenv.GetCIDROr("TRUSTED_NET", &net.IPNet{IP: net.IPv4(127, 0, 0, 0), Mask: net.CIDRMask(8, 32)})
+func GetCIDROr(key string, defaultValue *net.IPNet) *net.IPNet {
+	if value, ok := GetCIDR(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetCIDR retrieves an environment variable as a network in CIDR notation.
+// Panics if the variable is not set or cannot be parsed as a CIDR.
+//
+// Example:
+//
+//	network := lxenv.MustGetCIDR("TRUSTED_NET")
+func MustGetCIDR(key string) *net.IPNet {
+	value, ok := GetCIDR(key)
+	if !ok {
+		panic("lxenv: environment variable " + key + " is not set or not a valid CIDR")
+	}
+	return value
+}
+
+// GetEnum retrieves an environment variable whose value must be one of allowed.
+// Values are compared exactly, so "INFO" does not match "info".
+// Returns ("", false) if the variable is not set, empty or not allowed.
+//
+// Example:
+//
+//	level, ok := lxenv.GetEnum("LOG_LEVEL", "debug", "info", "warn", "error")
+func GetEnum(key string, allowed ...string) (string, bool) {
+	return enumValue(Get(key), allowed)
+}
+
+// GetEnumOr retrieves an environment variable whose value must be one of
+// allowed, or returns defaultValue if it is not set or not allowed.
+//
+// Example:
+//
+//	level := lxenv.GetEnumOr("LOG_LEVEL", "info", "debug", "info", "warn", "error")
+func GetEnumOr(key string, defaultValue string, allowed ...string) string {
+	if value, ok := GetEnum(key, allowed...); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetEnum retrieves an environment variable whose value must be one of allowed.
+// Panics if the variable is not set or not allowed.
+//
+// Example:
+//
+//	level := lxenv.MustGetEnum("LOG_LEVEL", "debug", "info", "warn", "error")
+func MustGetEnum(key string, allowed ...string) string {
+	value, ok := GetEnum(key, allowed...)
+	if !ok {
+		panic("lxenv: environment variable " + key + " is not set or not one of " + strings.Join(allowed, ", "))
+	}
+	return value
+}
+
+// stringSliceValue splits value by sep (a comma if empty), trimming items and
+// dropping empty ones.
+func stringSliceValue(value, sep string) ([]string, bool) {
+	if sep == "" {
+		sep = ","
+	}
+	var items []string
+	for _, item := range strings.Split(value, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return nil, false
+	}
+	return items, true
+}
+
+// intSliceValue parses a comma-separated list of integers.
+func intSliceValue(value string) ([]int, bool) {
+	items, ok := stringSliceValue(value, ",")
+	if !ok {
+		return nil, false
+	}
+	ints := make([]int, len(items))
+	for i, item := range items {
+		if ints[i], ok = intValue(item); !ok {
+			return nil, false
+		}
+	}
+	return ints, true
+}
+
+// mapValue parses a comma-separated list of key=value pairs.
+func mapValue(value string) (map[string]string, bool) {
+	items, ok := stringSliceValue(value, ",")
+	if !ok {
+		return nil, false
+	}
+	m := make(map[string]string, len(items))
+	for _, item := range items {
+		k, v, found := strings.Cut(item, "=")
+		k = strings.TrimSpace(k)
+		if !found || k == "" {
+			return nil, false
+		}
+		m[k] = strings.TrimSpace(v)
+	}
+	return m, true
+}
+
+// urlValue parses a non-empty value as an absolute URL.
+func urlValue(value string) (*url.URL, bool) {
+	if value == "" {
+		return nil, false
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" {
+		return nil, false
+	}
+	return u, true
+}
+
+// byteSizeUnits maps lower-case unit names to their size in bytes.
+var byteSizeUnits = map[string]int64{
+	"": 1, "b": 1,
+	"kb": 1e3, "mb": 1e6, "gb": 1e9, "tb": 1e12, "pb": 1e15, "eb": 1e18,
+	"kib": 1 << 10, "mib": 1 << 20, "gib": 1 << 30, "tib": 1 << 40, "pib": 1 << 50, "eib": 1 << 60,
+	"k": 1 << 10, "m": 1 << 20, "g": 1 << 30, "t": 1 << 40, "p": 1 << 50, "e": 1 << 60,
+}
+
+// byteSizeValue parses a size such as "512MiB" or "1.5GB" into bytes.
+func byteSizeValue(value string) (int64, bool) {
+	value = strings.TrimSpace(value)
+	end := strings.IndexFunc(value, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if end < 0 {
+		end = len(value)
+	}
+	number, unit := value[:end], strings.ToLower(strings.TrimSpace(value[end:]))
+
+	factor, ok := byteSizeUnits[unit]
+	if !ok || number == "" {
+		return 0, false
+	}
+
+	if !strings.Contains(number, ".") {
+		n, err := strconv.ParseInt(number, 10, 64)
+		if err != nil || n > math.MaxInt64/factor {
+			return 0, false
+		}
+		return n * factor, true
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, false
+	}
+	size := math.Round(f * float64(factor))
+	if size >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(size), true
+}
+
+// timeValue parses a non-empty value with the first matching layout, or RFC 3339.
+func timeValue(value string, layouts []string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if len(layouts) == 0 {
+		layouts = []string{time.RFC3339}
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// locationValue loads the time zone named by a non-empty value.
+func locationValue(value string) (*time.Location, bool) {
+	if value == "" {
+		return nil, false
+	}
+	loc, err := time.LoadLocation(value)
+	if err != nil {
+		return nil, false
+	}
+	return loc, true
+}
+
+// ipValue parses an IPv4 or IPv6 address.
+func ipValue(value string) (net.IP, bool) {
+	ip := net.ParseIP(strings.TrimSpace(value))
+	return ip, ip != nil
+}
+
+// cidrValue parses a network in CIDR notation.
+func cidrValue(value string) (*net.IPNet, bool) {
+	_, network, err := net.ParseCIDR(strings.TrimSpace(value))
+	if err != nil {
+		return nil, false
+	}
+	return network, true
+}
+
+// enumValue reports whether value is one of allowed.
+func enumValue(value string, allowed []string) (string, bool) {
+	if value == "" {
+		return "", false
+	}
+	for _, a := range allowed {
+		if value == a {
+			return value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,326 @@
+package lxenv_test
+
+import (
+	"math"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hgapdvn/lx/env"
+)
+
+func TestGetStringSlice(t *testing.T) {
+	tests := []struct {
+		name   string
+		preset string
+		sep    string
+		want   []string
+		wantOk bool
+	}{
+		{"comma", "a, b ,c", ",", []string{"a", "b", "c"}, true},
+		{"default separator", "a,b", "", []string{"a", "b"}, true},
+		{"custom separator", "/usr/bin:/bin", ":", []string{"/usr/bin", "/bin"}, true},
+		{"multi-char separator", "a || b", "||", []string{"a", "b"}, true},
+		{"empty items dropped", "a,,b,", ",", []string{"a", "b"}, true},
+		{"only separators", " , ,", ",", nil, false},
+		{"empty", "", ",", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_GETSLICE", tt.preset)
+			got, ok := lxenv.GetStringSlice("TEST_GETSLICE", tt.sep)
+			if !reflect.DeepEqual(got, tt.want) || ok != tt.wantOk {
+				t.Errorf("GetStringSlice(%q) = (%q, %v), want (%q, %v)", tt.preset, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+
+	if got := lxenv.GetStringSliceOr("TEST_GETSLICE_UNSET", ",", []string{"x"}); !reflect.DeepEqual(got, []string{"x"}) {
+		t.Errorf("GetStringSliceOr() = %q, want [x]", got)
+	}
+}
+
+func TestGetIntSlice(t *testing.T) {
+	tests := []struct {
+		name   string
+		preset string
+		want   []int
+		wantOk bool
+	}{
+		{"valid", "80, 443,-1", []int{80, 443, -1}, true},
+		{"single", "8080", []int{8080}, true},
+		{"invalid item", "80,http", nil, false},
+		{"float item", "1,2.5", nil, false},
+		{"empty", "", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_GETINTSLICE", tt.preset)
+			got, ok := lxenv.GetIntSlice("TEST_GETINTSLICE")
+			if !reflect.DeepEqual(got, tt.want) || ok != tt.wantOk {
+				t.Errorf("GetIntSlice(%q) = (%v, %v), want (%v, %v)", tt.preset, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGetMap(t *testing.T) {
+	tests := []struct {
+		name   string
+		preset string
+		want   map[string]string
+		wantOk bool
+	}{
+		{"valid", "team=core, tier = backend", map[string]string{"team": "core", "tier": "backend"}, true},
+		{"value with equals", "q=a=b", map[string]string{"q": "a=b"}, true},
+		{"empty value", "a=", map[string]string{"a": ""}, true},
+		{"duplicate key", "a=1,a=2", map[string]string{"a": "2"}, true},
+		{"missing equals", "a=1,b", nil, false},
+		{"missing key", "=1", nil, false},
+		{"empty", "", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_GETMAP", tt.preset)
+			got, ok := lxenv.GetMap("TEST_GETMAP")
+			if !reflect.DeepEqual(got, tt.want) || ok != tt.wantOk {
+				t.Errorf("GetMap(%q) = (%v, %v), want (%v, %v)", tt.preset, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGetURL(t *testing.T) {
+	t.Setenv("TEST_GETURL", "https://api.example.com:8443/v1?x=1")
+	u, ok := lxenv.GetURL("TEST_GETURL")
+	if !ok || u.Host != "api.example.com:8443" || u.Path != "/v1" {
+		t.Errorf("GetURL() = (%v, %v), want https://api.example.com:8443/v1", u, ok)
+	}
+
+	for _, bad := range []string{"", "no-scheme/path", "http://[::1"} {
+		t.Setenv("TEST_GETURL", bad)
+		if u, ok := lxenv.GetURL("TEST_GETURL"); ok {
+			t.Errorf("GetURL(%q) = %v, want not ok", bad, u)
+		}
+	}
+
+	def := &url.URL{Scheme: "http", Host: "localhost"}
+	if got := lxenv.GetURLOr("TEST_GETURL", def); got != def {
+		t.Errorf("GetURLOr() = %v, want default", got)
+	}
+}
+
+func TestGetByteSize(t *testing.T) {
+	tests := []struct {
+		preset string
+		want   int64
+		wantOk bool
+	}{
+		{"1024", 1024, true},
+		{"10B", 10, true},
+		{"512MiB", 512 << 20, true},
+		{"512 mib", 512 << 20, true},
+		{"1.5GB", 1500000000, true},
+		{"1.5GiB", 3 << 29, true},
+		{"2kb", 2000, true},
+		{"64K", 64 << 10, true},
+		{"1M", 1 << 20, true},
+		{"7EiB", 7 << 60, true},
+		{"8EiB", 0, false},
+		{"9223372036854775807", math.MaxInt64, true},
+		{"9223372036854775808", 0, false},
+		{"-1MB", 0, false},
+		{"1.2.3MB", 0, false},
+		{"MB", 0, false},
+		{"10 parsecs", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.preset, func(t *testing.T) {
+			t.Setenv("TEST_GETBYTESIZE", tt.preset)
+			got, ok := lxenv.GetByteSize("TEST_GETBYTESIZE")
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("GetByteSize(%q) = (%d, %v), want (%d, %v)", tt.preset, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGetTime(t *testing.T) {
+	t.Setenv("TEST_GETTIME", "2024-01-02T15:04:05.5+02:00")
+	got, ok := lxenv.GetTime("TEST_GETTIME")
+	want := time.Date(2024, 1, 2, 13, 4, 5, 5e8, time.UTC)
+	if !ok || !got.Equal(want) {
+		t.Errorf("GetTime() = (%v, %v), want (%v, true)", got, ok, want)
+	}
+
+	t.Setenv("TEST_GETTIME", "02/01/2024")
+	if _, ok := lxenv.GetTime("TEST_GETTIME"); ok {
+		t.Error("GetTime() without layouts should only accept RFC 3339")
+	}
+	got, ok = lxenv.GetTime("TEST_GETTIME", time.RFC3339, "02/01/2006")
+	if !ok || !got.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("GetTime() with custom layout = (%v, %v)", got, ok)
+	}
+
+	def := time.Unix(0, 0)
+	if got := lxenv.GetTimeOr("TEST_GETTIME_UNSET", def); !got.Equal(def) {
+		t.Errorf("GetTimeOr() = %v, want default", got)
+	}
+}
+
+func TestGetLocation(t *testing.T) {
+	t.Setenv("TEST_GETLOC", "UTC")
+	if loc, ok := lxenv.GetLocation("TEST_GETLOC"); !ok || loc.String() != "UTC" {
+		t.Errorf("GetLocation() = (%v, %v), want (UTC, true)", loc, ok)
+	}
+
+	t.Setenv("TEST_GETLOC", "Mars/Olympus_Mons")
+	if _, ok := lxenv.GetLocation("TEST_GETLOC"); ok {
+		t.Error("GetLocation() of an unknown zone should report false")
+	}
+	if got := lxenv.GetLocationOr("TEST_GETLOC", time.Local); got != time.Local {
+		t.Errorf("GetLocationOr() = %v, want Local", got)
+	}
+}
+
+func TestGetIPAndCIDR(t *testing.T) {
+	t.Setenv("TEST_GETIP", "2001:db8::1")
+	if ip, ok := lxenv.GetIP("TEST_GETIP"); !ok || !ip.Equal(net.ParseIP("2001:db8::1")) {
+		t.Errorf("GetIP() = (%v, %v), want 2001:db8::1", ip, ok)
+	}
+	t.Setenv("TEST_GETIP", "10.0.0.256")
+	if _, ok := lxenv.GetIP("TEST_GETIP"); ok {
+		t.Error("GetIP() of an invalid address should report false")
+	}
+	if got := lxenv.GetIPOr("TEST_GETIP", net.IPv4zero); !got.Equal(net.IPv4zero) {
+		t.Errorf("GetIPOr() = %v, want default", got)
+	}
+
+	t.Setenv("TEST_GETCIDR", "10.1.2.3/8")
+	network, ok := lxenv.GetCIDR("TEST_GETCIDR")
+	if !ok || network.String() != "10.0.0.0/8" || !network.Contains(net.ParseIP("10.200.0.1")) {
+		t.Errorf("GetCIDR() = (%v, %v), want 10.0.0.0/8", network, ok)
+	}
+	t.Setenv("TEST_GETCIDR", "10.0.0.0")
+	if _, ok := lxenv.GetCIDR("TEST_GETCIDR"); ok {
+		t.Error("GetCIDR() without a prefix length should report false")
+	}
+}
+
+func TestGetEnum(t *testing.T) {
+	levels := []string{"debug", "info", "warn"}
+
+	t.Setenv("TEST_GETENUM", "info")
+	if got, ok := lxenv.GetEnum("TEST_GETENUM", levels...); !ok || got != "info" {
+		t.Errorf("GetEnum() = (%q, %v), want (info, true)", got, ok)
+	}
+
+	t.Setenv("TEST_GETENUM", "INFO")
+	if got, ok := lxenv.GetEnum("TEST_GETENUM", levels...); ok {
+		t.Errorf("GetEnum() = %q, want case-sensitive mismatch", got)
+	}
+	if got := lxenv.GetEnumOr("TEST_GETENUM", "warn", levels...); got != "warn" {
+		t.Errorf("GetEnumOr() = %q, want %q", got, "warn")
+	}
+	if _, ok := lxenv.GetEnum("TEST_GETENUM_UNSET", "", "a"); ok {
+		t.Error("GetEnum() of an unset variable should report false")
+	}
+}
+
+func TestMustGetTypedPanics(t *testing.T) {
+	t.Setenv("TEST_MUSTTYPED_BAD", "nope")
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"MustGetStringSlice", func() { lxenv.MustGetStringSlice("TEST_MUSTTYPED_UNSET", ",") }},
+		{"MustGetIntSlice", func() { lxenv.MustGetIntSlice("TEST_MUSTTYPED_BAD") }},
+		{"MustGetMap", func() { lxenv.MustGetMap("TEST_MUSTTYPED_BAD") }},
+		{"MustGetURL", func() { lxenv.MustGetURL("TEST_MUSTTYPED_BAD") }},
+		{"MustGetByteSize", func() { lxenv.MustGetByteSize("TEST_MUSTTYPED_BAD") }},
+		{"MustGetTime", func() { lxenv.MustGetTime("TEST_MUSTTYPED_BAD") }},
+		{"MustGetLocation", func() { lxenv.MustGetLocation("TEST_MUSTTYPED_BAD") }},
+		{"MustGetIP", func() { lxenv.MustGetIP("TEST_MUSTTYPED_BAD") }},
+		{"MustGetCIDR", func() { lxenv.MustGetCIDR("TEST_MUSTTYPED_BAD") }},
+		{"MustGetEnum", func() { lxenv.MustGetEnum("TEST_MUSTTYPED_BAD", "yes", "no") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s did not panic", tt.name)
+				}
+			}()
+			tt.fn()
+		})
+	}
+
+	t.Setenv("TEST_MUSTTYPED_OK", "a=1")
+	if got := lxenv.MustGetMap("TEST_MUSTTYPED_OK"); got["a"] != "1" {
+		t.Errorf("MustGetMap() = %v, want a=1", got)
+	}
+}
+
+func TestConfig_TypedGetters(t *testing.T) {
+	cfg := lxenv.NewConfig(map[string]string{
+		"HOSTS":  "a;b",
+		"PORTS":  "80,443",
+		"LABELS": "team=core",
+		"URL":    "postgres://db:5432/app",
+		"SIZE":   "1.5GB",
+		"START":  "2024-01-02T00:00:00Z",
+		"TZ":     "UTC",
+		"IP":     "127.0.0.1",
+		"NET":    "192.168.0.0/16",
+		"LEVEL":  "warn",
+		"BAD":    "nope",
+	})
+
+	if got := cfg.MustGetStringSlice("HOSTS", ";"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("MustGetStringSlice() = %q", got)
+	}
+	if got := cfg.GetIntSliceOr("BAD", []int{1}); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("GetIntSliceOr() = %v, want default", got)
+	}
+	if got := cfg.MustGetIntSlice("PORTS"); !reflect.DeepEqual(got, []int{80, 443}) {
+		t.Errorf("MustGetIntSlice() = %v", got)
+	}
+	if got, ok := cfg.GetMap("LABELS"); !ok || got["team"] != "core" {
+		t.Errorf("GetMap() = (%v, %v)", got, ok)
+	}
+	if got := cfg.MustGetURL("URL"); got.Scheme != "postgres" || got.Host != "db:5432" {
+		t.Errorf("MustGetURL() = %v", got)
+	}
+	if got := cfg.GetByteSizeOr("SIZE", 0); got != 1500000000 {
+		t.Errorf("GetByteSizeOr() = %d", got)
+	}
+	if got := cfg.MustGetTime("START"); got.Year() != 2024 {
+		t.Errorf("MustGetTime() = %v", got)
+	}
+	if got := cfg.MustGetLocation("TZ"); got.String() != "UTC" {
+		t.Errorf("MustGetLocation() = %v", got)
+	}
+	if got := cfg.MustGetIP("IP"); !got.IsLoopback() {
+		t.Errorf("MustGetIP() = %v", got)
+	}
+	if got := cfg.MustGetCIDR("NET"); !got.Contains(net.ParseIP("192.168.1.1")) {
+		t.Errorf("MustGetCIDR() = %v", got)
+	}
+	if got := cfg.GetEnumOr("BAD", "info", "info", "warn"); got != "info" {
+		t.Errorf("GetEnumOr() = %q, want default", got)
+	}
+	if got := cfg.MustGetEnum("LEVEL", "info", "warn"); got != "warn" {
+		t.Errorf("MustGetEnum() = %q", got)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Config.MustGetByteSize() did not panic")
+		}
+	}()
+	cfg.MustGetByteSize("BAD")
+}
@@ -0,0 +1,152 @@
+package lxenv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// iniFileParser is a struct that implements the Parser interface.
+// It reads environment variables from .ini files.
+type iniFileParser struct{}
+
+// Parse parses INI format and flattens sections using dot-notation, e.g.:
+//
+//	[database.pool]
+//	size = 10        →  database.pool.size=10
+//
+//	[remote "origin"]
+//	url = x          →  remote.origin.url=x
+//
+// Rules:
+// - Lines starting with ; or # are comments, blank lines are ignored
+// - Keys and values are separated by the first '=' or ':'
+// - Keys before the first section have no prefix
+// - Values may be quoted with " or ', and inline comments after an unquoted
+// " ;" or " #" are stripped
+// - Repeated key[] entries form an array, flattened the same way as YAML sequences
+// - Later keys override earlier ones
+func (ifp *iniFileParser) Parse(r io.Reader) (map[string]string, error) {
+	pairs, _, err := ifp.parseLines(r)
+	return pairs, err
+}
+
+// parseLines is like Parse but also reports the line of each key.
+func (ifp *iniFileParser) parseLines(r io.Reader) (map[string]string, map[string]int, error) {
+	pairs := make(map[string]string)
+	lines := make(map[string]int)
+	arrays := make(map[string][]string)
+	section := ""
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if lineNum == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+
+		if line[0] == '[' {
+			name, err := parseINISection(line)
+			if err != nil {
+				return nil, nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+			section = name
+			continue
+		}
+
+		idx := strings.IndexAny(line, "=:")
+		if idx < 0 {
+			return nil, nil, fmt.Errorf("line %d: missing '=' in %q", lineNum, line)
+		}
+		key := strings.TrimSpace(line[:idx])
+		value := strings.TrimSpace(stripINIComment(line[idx+1:]))
+		value = defaultValueNormalizer.unquote(value)
+
+		array := strings.HasSuffix(key, "[]")
+		key = strings.TrimSpace(strings.TrimSuffix(key, "[]"))
+		if key == "" {
+			return nil, nil, fmt.Errorf("line %d: empty key", lineNum)
+		}
+		key = joinKey(section, key)
+
+		if array {
+			if len(arrays[key]) == 0 {
+				lines[key] = lineNum
+			}
+			lines[joinKey(key, strconv.Itoa(len(arrays[key])))] = lineNum
+			arrays[key] = append(arrays[key], value)
+			continue
+		}
+		delete(arrays, key)
+		pairs[key] = value
+		lines[key] = lineNum
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	for key, values := range arrays {
+		items := make([]any, len(values))
+		for i, v := range values {
+			items[i] = v
+		}
+		flattenValue(key, items, pairs)
+	}
+	return pairs, lines, nil
+}
+
+// parseINISection returns the dot-notation name of a [section] header.
+// A quoted subsection, as in [remote "origin"], is joined with a dot.
+func parseINISection(line string) (string, error) {
+	end := strings.LastIndexByte(line, ']')
+	if end < 0 {
+		return "", fmt.Errorf("unterminated section header %q", line)
+	}
+	if rest := strings.TrimSpace(line[end+1:]); rest != "" && rest[0] != ';' && rest[0] != '#' {
+		return "", fmt.Errorf("unexpected content after section header %q", line)
+	}
+
+	name := strings.TrimSpace(line[1:end])
+	if i := strings.IndexByte(name, '"'); i > 0 {
+		sub, err := strconv.Unquote(strings.TrimSpace(name[i:]))
+		if err != nil {
+			return "", fmt.Errorf("invalid subsection in %q", line)
+		}
+		name = joinKey(strings.TrimSpace(name[:i]), sub)
+	}
+	if name == "" {
+		return "", fmt.Errorf("empty section name")
+	}
+	return name, nil
+}
+
+// stripINIComment removes a trailing comment that starts with ; or # after
+// whitespace and outside quotes.
+func stripINIComment(s string) string {
+	inSingle, inDouble := false, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\'':
+			if !inDouble {
+				inSingle = !inSingle
+			}
+		case '"':
+			if !inSingle {
+				inDouble = !inDouble
+			}
+		case ';', '#':
+			if !inSingle && !inDouble && i > 0 && (s[i-1] == ' ' || s[i-1] == '\t') {
+				return s[:i]
+			}
+		}
+	}
+	return s
+}
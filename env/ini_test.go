@@ -0,0 +1,89 @@
+package lxenv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestINIFileParser_Parse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]string
+	}{
+		{
+			name:  "sections",
+			input: "name = lx\n\n[database]\nhost = localhost\n\n[database.pool]\nsize = 10\n",
+			want:  map[string]string{"name": "lx", "database.host": "localhost", "database.pool.size": "10"},
+		},
+		{
+			name:  "quoted subsection",
+			input: "[remote \"origin\"]\nurl = git@example.com:lx.git\n",
+			want:  map[string]string{"remote.origin.url": "git@example.com:lx.git"},
+		},
+		{
+			name:  "separators",
+			input: "[s]\na=1\nb: 2\nc = x=y\n",
+			want:  map[string]string{"s.a": "1", "s.b": "2", "s.c": "x=y"},
+		},
+		{
+			name:  "comments and quotes",
+			input: "; comment\n# comment\n[s] ; trailing\na = value ; comment\nb = value # comment\nc = \"x ; y\"\nd = a#b;c\ne = 'single'\n",
+			want:  map[string]string{"s.a": "value", "s.b": "value", "s.c": "x ; y", "s.d": "a#b;c", "s.e": "single"},
+		},
+		{
+			name:  "arrays",
+			input: "[s]\nhosts[] = a\nhosts[] = b\nsingle[] = x\n",
+			want:  map[string]string{"s.hosts.0": "a", "s.hosts.1": "b", "s.hosts": "a,b", "s.single.0": "x", "s.single": "x"},
+		},
+		{
+			name:  "later keys override",
+			input: "[s]\na = 1\n[t]\nb = 1\n[s]\na = 2\n",
+			want:  map[string]string{"s.a": "2", "t.b": "1"},
+		},
+		{
+			name:  "empty values",
+			input: "[s]\na =\nb = \"\"\n",
+			want:  map[string]string{"s.a": "", "s.b": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := defaultINIParser.Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestINIFileParser_ParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"missing separator", "[s]\nplain\n", "line 2: missing '='"},
+		{"empty key", "= value\n", "line 1: empty key"},
+		{"unterminated section", "[s\n", "line 1: unterminated section header"},
+		{"empty section", "[]\n", "line 1: empty section name"},
+		{"content after section", "[s] x\n", "unexpected content after section header"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := defaultINIParser.Parse(strings.NewReader(tt.input))
+			if err == nil {
+				t.Fatalf("Parse(%q) expected error, got nil", tt.input)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse(%q) error = %q, want it to contain %q", tt.input, err, tt.want)
+			}
+		})
+	}
+}
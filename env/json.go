@@ -0,0 +1,174 @@
+package lxenv
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// jsonFileParser is a struct that implements the Parser interface.
+// It reads environment variables from .json files.
+type jsonFileParser struct{}
+
+// Parse parses a JSON document and flattens it using dot-notation, e.g.:
+//
+//	{"database": {"pool": {"size": 10}}}   →  database.pool.size=10
+//
+// Rules:
+// - Objects and arrays are flattened the same way as YAML mappings and sequences
+// - Numbers keep their original text, booleans become "true" or "false"
+// - null becomes an empty string
+// - The document root must be an object or an array
+// - Syntax errors are reported with their line and column
+func (jfp *jsonFileParser) Parse(r io.Reader) (map[string]string, error) {
+	pairs, _, err := jfp.parseLines(r)
+	return pairs, err
+}
+
+// parseLines is like Parse but also reports the line of each key.
+func (jfp *jsonFileParser) parseLines(r io.Reader) (map[string]string, map[string]int, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	d := &jsonDecoder{dec: dec, data: data, lines: make(map[string]int)}
+
+	doc, err := d.value("")
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return map[string]string{}, d.lines, nil
+		}
+		return nil, nil, jsonError(data, d.nextOffset(), err)
+	}
+	if offset := d.nextOffset(); offset < int64(len(data)) {
+		line, col := offsetPosition(data, offset)
+		return nil, nil, fmt.Errorf("line %d, column %d: unexpected content after the document", line, col)
+	}
+
+	switch doc.(type) {
+	case map[string]any, []any, nil:
+	default:
+		return nil, nil, errors.New("document root must be an object or an array")
+	}
+
+	pairs := make(map[string]string)
+	flattenValue("", doc, pairs)
+	return pairs, d.lines, nil
+}
+
+// jsonDecoder builds a document tree from JSON tokens, converting scalars to
+// their string form and recording the line of every key and array item.
+type jsonDecoder struct {
+	dec   *json.Decoder
+	data  []byte
+	lines map[string]int
+}
+
+// value decodes the next value, whose dot-notation key is path.
+func (d *jsonDecoder) value(path string) (any, error) {
+	tok, err := d.dec.Token()
+	if err != nil {
+		if path != "" && errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			obj := make(map[string]any)
+			for d.dec.More() {
+				keyTok, err := d.dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, _ := keyTok.(string)
+				child := joinKey(path, key)
+				line, _ := offsetPosition(d.data, d.dec.InputOffset())
+				d.lines[child] = line
+
+				if obj[key], err = d.value(child); err != nil {
+					return nil, err
+				}
+			}
+			_, err := d.dec.Token() // }
+			return obj, err
+		}
+
+		arr := []any{}
+		for d.dec.More() {
+			child := joinKey(path, strconv.Itoa(len(arr)))
+			line, _ := offsetPosition(d.data, d.nextOffset())
+			d.lines[child] = line
+
+			item, err := d.value(child)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, item)
+		}
+		_, err := d.dec.Token() // ]
+		return arr, err
+	case json.Number:
+		return t.String(), nil
+	case bool:
+		return strconv.FormatBool(t), nil
+	case string:
+		return t, nil
+	}
+	return nil, nil
+}
+
+// nextOffset returns the offset of the next token, skipping whitespace and separators.
+func (d *jsonDecoder) nextOffset() int64 {
+	offset := d.dec.InputOffset()
+	for offset < int64(len(d.data)) && strings.IndexByte(" \t\r\n,:", d.data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// jsonError adds the line and column of the token at offset, where decoding
+// stopped, to a decoding error. Only a failure at the end of data is reported
+// as unexpected end of input.
+func jsonError(data []byte, offset int64, err error) error {
+	var syntaxErr *json.SyntaxError
+	eof := errors.Is(err, io.ErrUnexpectedEOF)
+	if !eof && !errors.As(err, &syntaxErr) {
+		return err
+	}
+	line, col := offsetPosition(data, offset)
+	if eof || offset >= int64(len(data)) {
+		return fmt.Errorf("line %d, column %d: unexpected end of input", line, col)
+	}
+	c := data[offset]
+	switch msg := err.Error(); {
+	case strings.HasPrefix(msg, fmt.Sprintf("invalid character %q", c)):
+		return fmt.Errorf("line %d, column %d: %w", line, col, err)
+	case strings.HasPrefix(msg, "invalid character"):
+		// the decoder names the separator before the offending token
+		return fmt.Errorf("line %d, column %d: unexpected %q", line, col, c)
+	default:
+		return fmt.Errorf("line %d, column %d: unexpected %q: %w", line, col, c, err)
+	}
+}
+
+// offsetPosition converts a byte offset in data to a 1-based line and column.
+func offsetPosition(data []byte, offset int64) (line, col int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	col = int(offset) - (bytes.LastIndexByte(before, '\n') + 1) + 1
+	return line, col
+}
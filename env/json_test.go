@@ -0,0 +1,91 @@
+package lxenv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSONFileParser_Parse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]string
+	}{
+		{
+			name:  "nested objects",
+			input: `{"database": {"host": "localhost", "pool": {"size": 10}}}`,
+			want:  map[string]string{"database.host": "localhost", "database.pool.size": "10"},
+		},
+		{
+			name:  "scalars",
+			input: `{"i": 42, "f": 1.50, "e": 1e3, "t": true, "f2": false, "n": null, "s": "x\ty"}`,
+			want:  map[string]string{"i": "42", "f": "1.50", "e": "1e3", "t": "true", "f2": "false", "n": "", "s": "x\ty"},
+		},
+		{
+			name:  "arrays",
+			input: `{"ports": [80, 443], "empty": [], "servers": [{"host": "a"}, {"host": "b"}], "mixed": [1, {"a": 2}]}`,
+			want: map[string]string{
+				"ports.0": "80", "ports.1": "443", "ports": "80,443",
+				"empty":          "",
+				"servers.0.host": "a", "servers.1.host": "b",
+				"mixed.0": "1", "mixed.1.a": "2",
+			},
+		},
+		{
+			name:  "root array",
+			input: `[{"a": 1}, "x"]`,
+			want:  map[string]string{"0.a": "1", "1": "x"},
+		},
+		{
+			name:  "empty object",
+			input: `{"a": {}}`,
+			want:  map[string]string{},
+		},
+		{
+			name:  "empty input",
+			input: "  \n",
+			want:  map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := defaultJSONParser.Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONFileParser_ParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"syntax error", "{\n  \"a\": 1,\n  \"b\" 2\n}", "line 3, column"},
+		{"truncated", "{\"a\": [1, 2", "unexpected end of input"},
+		{"truncated string", "{\"a\": \"abc", "unexpected end of input"},
+		{"missing value", "{\"a\": }", "line 1, column 7: unexpected '}'"},
+		{"trailing comma", "{\"a\": 1,}", "line 1, column 9: unexpected '}'"},
+		{"trailing content", "{\"a\": 1} {\"b\": 2}", "line 1, column 10: unexpected content after the document"},
+		{"scalar root", "42", "document root must be an object or an array"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := defaultJSONParser.Parse(strings.NewReader(tt.input))
+			if err == nil {
+				t.Fatalf("Parse(%q) expected error, got nil", tt.input)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse(%q) error = %q, want it to contain %q", tt.input, err, tt.want)
+			}
+		})
+	}
+}
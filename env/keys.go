@@ -0,0 +1,182 @@
+package lxenv
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// KeyMapper maps a key read from a file to the name it is loaded under.
+// Returning an empty string drops the key.
+type KeyMapper func(key string) string
+
+// UpperSnakeCase maps dot, kebab and camel case keys to ENV_STYLE names:
+//
+//	database.pool.size  → DATABASE_POOL_SIZE
+//	http-server.port    → HTTP_SERVER_PORT
+//	cache.maxEntries    → CACHE_MAX_ENTRIES
+func UpperSnakeCase(key string) string {
+	var b strings.Builder
+	b.Grow(len(key) + 4)
+	var prev rune
+	for _, r := range key {
+		switch {
+		case r == '.' || r == '-' || r == ' ':
+			r = '_'
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+		prev = r
+	}
+	return b.String()
+}
+
+// SnakeCase maps kebab-case keys to snake_case, leaving dots and letter case
+// unchanged: "http-server.read-timeout" → "http_server.read_timeout".
+func SnakeCase(key string) string {
+	return strings.ReplaceAll(key, "-", "_")
+}
+
+// WithKeyMapping renames every loaded key with mapper, e.g. UpperSnakeCase to
+// load "database.pool.size" from a YAML file as DATABASE_POOL_SIZE. Keys are
+// mapped after variable expansion, so references within a file use the names
+// written in it. Two keys of the same file that map to the same name are
+// reported as an error.
+//
+// Example:
+//
+//	loader := lxenv.NewLoader(lxenv.WithKeyMapping(lxenv.UpperSnakeCase))
+//	err := loader.LoadYML("config.yml")
+//	size, _ := lxenv.GetInt("DATABASE_POOL_SIZE")
+func WithKeyMapping(mapper KeyMapper) LoadOption {
+	return func(o *loadOptions) {
+		o.keyMapper = mapper
+	}
+}
+
+// mapKeys renames the keys of pairs and lines with mapper. The returned lines
+// are keyed by the new names and already fall back to the line of the
+// enclosing key, as keyLine does.
+func mapKeys(mapper KeyMapper, pairs map[string]string, lines map[string]int) (map[string]string, map[string]int, error) {
+	keys := make([]string, 0, len(pairs))
+	for k := range pairs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	mapped := make(map[string]string, len(pairs))
+	mappedLines := make(map[string]int, len(pairs))
+	from := make(map[string]string, len(pairs))
+	for _, k := range keys {
+		name := mapper(k)
+		if name == "" {
+			continue
+		}
+		if prev, ok := from[name]; ok {
+			return nil, nil, fmt.Errorf("keys %q and %q both map to %q", prev, k, name)
+		}
+		from[name] = k
+		mapped[name] = pairs[k]
+		if line := keyLine(lines, k); line > 0 {
+			mappedLines[name] = line
+		}
+	}
+	return mapped, mappedLines, nil
+}
+
+// lookupMapped looks key up with lookup, falling back to its UpperSnakeCase
+// name when key is in dot or kebab notation.
+func lookupMapped(key string, lookup func(string) (string, bool)) (string, bool) {
+	return lookup(setKey(key, lookup))
+}
+
+// setKey returns the name under which lookupMapped finds key: key itself if
+// it is set, otherwise its fallback name.
+func setKey(key string, lookup func(string) (string, bool)) string {
+	if _, ok := lookup(key); ok || !strings.ContainsAny(key, ".-") {
+		return key
+	}
+	return UpperSnakeCase(key)
+}
+
+// Sub returns a Config holding the environment variables under prefix, with
+// the prefix and the separator after it removed. Both the dot-notation and
+// the ENV_STYLE form of prefix are matched, so Sub("database") collects
+// database.host as "host" and DATABASE_POOL_SIZE as "POOL_SIZE", and the
+// returned Config finds either through the fallback of Lookup.
+//
+// The Config is a snapshot: later changes to the environment are not reflected.
+//
+// Example:
+//
+//	db := lxenv.Sub("database")
+//	size := db.GetIntOr("pool.size", 10) // DATABASE_POOL_SIZE
+//	host := db.GetOr("host", "localhost")
+func Sub(prefix string) *Config {
+	values := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok && k != "" {
+			values[k] = v
+		}
+	}
+
+	originsMu.RLock()
+	origins := make(map[string]Provenance, len(values))
+	for k, v := range values {
+		if _, ok := trimPrefix(k, prefix); ok {
+			origins[k] = effectiveOrigin(k, v)
+		}
+	}
+	originsMu.RUnlock()
+
+	return (&Config{values: values, origins: origins}).Sub(prefix)
+}
+
+// Sub returns a Config holding the keys under prefix, with the prefix and the
+// separator after it removed, as described in Sub.
+func (c *Config) Sub(prefix string) *Config {
+	sub := &Config{values: make(map[string]string), origins: make(map[string]Provenance)}
+	if c == nil {
+		return sub
+	}
+	for k, v := range c.values {
+		name, ok := trimPrefix(k, prefix)
+		if !ok {
+			continue
+		}
+		sub.values[name] = v
+		if p, ok := c.origins[k]; ok {
+			p.Key = name
+			sub.origins[name] = p
+		}
+	}
+	return sub
+}
+
+// trimPrefix removes prefix, or its UpperSnakeCase form, and the separator
+// that follows it from key. It reports false if key is not under prefix.
+// A prefix that already ends with a separator, such as "APP_", is used as is.
+func trimPrefix(key, prefix string) (string, bool) {
+	if prefix == "" {
+		return key, true
+	}
+	for _, p := range []string{prefix, UpperSnakeCase(prefix)} {
+		if !strings.HasPrefix(key, p) {
+			continue
+		}
+		rest := key[len(p):]
+		if strings.ContainsAny(p[len(p)-1:], "._-") {
+			if rest != "" {
+				return rest, true
+			}
+			continue
+		}
+		if len(rest) > 1 && strings.ContainsAny(rest[:1], "._-") {
+			return rest[1:], true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,156 @@
+package lxenv_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hgapdvn/lx/env"
+)
+
+func TestUpperSnakeCase(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"database.pool.size", "DATABASE_POOL_SIZE"},
+		{"http-server.port", "HTTP_SERVER_PORT"},
+		{"cache.maxEntries", "CACHE_MAX_ENTRIES"},
+		{"ports.0", "PORTS_0"},
+		{"v2Api", "V2_API"},
+		{"ALREADY_SNAKE", "ALREADY_SNAKE"},
+		{"URLPath", "URLPATH"},
+	}
+
+	for _, tt := range tests {
+		if got := lxenv.UpperSnakeCase(tt.key); got != tt.want {
+			t.Errorf("UpperSnakeCase(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+	if got := lxenv.SnakeCase("http-server.read-timeout"); got != "http_server.read_timeout" {
+		t.Errorf("SnakeCase() = %q", got)
+	}
+}
+
+func TestLoader_WithKeyMapping(t *testing.T) {
+	content := "database:\n  host: db.local\n  pool-size: 10\n  url: postgres://${database.host}\nports: [80, 443]\n"
+	p := writeTestFile(t, "config.yml", content)
+
+	cfg, err := lxenv.NewLoader(lxenv.WithKeyMapping(lxenv.UpperSnakeCase)).ReadYML(p)
+	if err != nil {
+		t.Fatalf("ReadYML() unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"DATABASE_HOST":      "db.local",
+		"DATABASE_POOL_SIZE": "10",
+		"DATABASE_URL":       "postgres://db.local",
+		"PORTS":              "80,443",
+		"PORTS_0":            "80",
+		"PORTS_1":            "443",
+	}
+	if got := cfg.Map(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadYML() = %v, want %v", got, want)
+	}
+	if o, _ := cfg.Origin("DATABASE_POOL_SIZE"); o.Line != 3 {
+		t.Errorf("Origin().Line = %d, want 3", o.Line)
+	}
+	if o, _ := cfg.Origin("PORTS_1"); o.Line != 5 {
+		t.Errorf("Origin().Line = %d, want 5", o.Line)
+	}
+
+	// a custom mapper can drop keys
+	mapper := func(key string) string {
+		if strings.HasPrefix(key, "ports") {
+			return ""
+		}
+		return lxenv.SnakeCase(key)
+	}
+	cfg, err = lxenv.NewLoader(lxenv.WithKeyMapping(mapper)).ReadYML(p)
+	if err != nil {
+		t.Fatalf("ReadYML() unexpected error: %v", err)
+	}
+	if got := cfg.Keys(); !reflect.DeepEqual(got, []string{"database.host", "database.pool_size", "database.url"}) {
+		t.Errorf("ReadYML() keys = %v", got)
+	}
+}
+
+func TestLoader_WithKeyMappingCollision(t *testing.T) {
+	p := writeTestFile(t, "config.yml", "a:\n  b: 1\na_b: 2\n")
+
+	_, err := lxenv.NewLoader(lxenv.WithKeyMapping(lxenv.UpperSnakeCase)).ReadYML(p)
+	if err == nil || !strings.Contains(err.Error(), `both map to "A_B"`) {
+		t.Errorf("ReadYML() error = %v, want a collision error", err)
+	}
+}
+
+func TestLookup_UpperSnakeFallback(t *testing.T) {
+	t.Setenv("TEST_MAP_POOL_SIZE", "20")
+	t.Setenv("TEST_MAP_HOST", "env-host")
+	t.Setenv("test_map.host", "dotted-host")
+
+	if got, ok := lxenv.GetInt("test_map.pool-size"); !ok || got != 20 {
+		t.Errorf("GetInt(test_map.pool-size) = (%d, %v), want (20, true)", got, ok)
+	}
+	if got := lxenv.Get("test_map.host"); got != "dotted-host" {
+		t.Errorf("Get(test_map.host) = %q, want the exact key to win", got)
+	}
+	if lxenv.Has("test_map.missing") {
+		t.Error("Has() of an unset key = true")
+	}
+	if lxenv.Has("Test_Map_Host") {
+		t.Error("keys without dots or dashes should not fall back")
+	}
+
+	cfg := lxenv.NewConfig(map[string]string{"DATABASE_POOL_SIZE": "5"})
+	if got, ok := cfg.GetInt("database.pool.size"); !ok || got != 5 {
+		t.Errorf("Config.GetInt(database.pool.size) = (%d, %v), want (5, true)", got, ok)
+	}
+}
+
+func TestSub(t *testing.T) {
+	t.Setenv("TEST_SUB_POOL_SIZE", "10")
+	t.Setenv("TEST_SUB_HOST", "db.local")
+	t.Setenv("test_sub.user", "admin")
+	t.Setenv("TEST_SUBX", "other")
+
+	db := lxenv.Sub("test_sub")
+	want := map[string]string{"POOL_SIZE": "10", "HOST": "db.local", "user": "admin"}
+	if got := db.Map(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sub() = %v, want %v", got, want)
+	}
+	if got := db.GetIntOr("pool.size", 0); got != 10 {
+		t.Errorf("Sub().GetIntOr(pool.size) = %d, want 10", got)
+	}
+	if o, ok := db.Origin("pool.size"); !ok || o.Key != "POOL_SIZE" || o.Value != "10" {
+		t.Errorf("Sub().Origin(pool.size) = (%+v, %v)", o, ok)
+	}
+
+	if got := lxenv.Sub("TEST_SUB_").Map(); len(got) != 2 || got["HOST"] != "db.local" {
+		t.Errorf("Sub(TEST_SUB_) = %v", got)
+	}
+}
+
+func TestConfig_Sub(t *testing.T) {
+	cfg := lxenv.NewConfig(map[string]string{
+		"server.http.port": "8080",
+		"server.http.host": "0.0.0.0",
+		"server.grpc.port": "9090",
+		"serverless":       "no",
+	})
+
+	http := cfg.Sub("server").Sub("http")
+	if got := http.Map(); !reflect.DeepEqual(got, map[string]string{"port": "8080", "host": "0.0.0.0"}) {
+		t.Errorf("Sub().Sub() = %v", got)
+	}
+	if got := cfg.Sub("server").Len(); got != 3 {
+		t.Errorf("Sub(server).Len() = %d, want 3", got)
+	}
+	if got := cfg.Sub("missing").Len(); got != 0 {
+		t.Errorf("Sub(missing).Len() = %d, want 0", got)
+	}
+
+	var nilCfg *lxenv.Config
+	if got := nilCfg.Sub("x").Len(); got != 0 {
+		t.Errorf("nil Config Sub().Len() = %d, want 0", got)
+	}
+}
@@ -2,9 +2,12 @@ package lxenv
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -13,16 +16,24 @@ var (
 	defaultValueNormalizer = &simpleValueNormalizer{}
 	// Env file parsers
 	defaultEnvFileParser    = &envFileParser{valueNormalizer: defaultValueNormalizer}
-	defaultPropertiesParser = &propertiesFileParser{envFileParser: defaultEnvFileParser}
-	defaultYAMLParser       = &yamlFileParser{valueNormalizer: defaultValueNormalizer}
+	defaultPropertiesParser = &propertiesFileParser{}
+	defaultYAMLParser       = &yamlFileParser{}
+	defaultTOMLParser       = &tomlFileParser{}
+	defaultJSONParser       = &jsonFileParser{}
+	defaultINIParser        = &iniFileParser{}
 	// Env Loaders
-	defaultEnvLoader        = &envLoader{parser: defaultEnvFileParser}
-	defaultPropertiesLoader = &envLoader{parser: defaultPropertiesParser}
-	defaultYAMLLoader       = &envLoader{parser: defaultYAMLParser}
+	defaultLoader           = NewLoader()
+	defaultEnvLoader        = defaultLoader.envLoader(defaultEnvFileParser)
+	defaultPropertiesLoader = defaultLoader.envLoader(defaultPropertiesParser)
+	defaultYAMLLoader       = defaultLoader.envLoader(defaultYAMLParser)
+	defaultTOMLLoader       = defaultLoader.envLoader(defaultTOMLParser)
+	defaultJSONLoader       = defaultLoader.envLoader(defaultJSONParser)
+	defaultINILoader        = defaultLoader.envLoader(defaultINIParser)
 )
 
 // LoadEnv reads one or more .env files and sets environment variables from them.
 // Files are loaded in order — later files override earlier ones.
+// Variable references such as ${DB_HOST} are expanded (see Loader).
 //
 // Example:
 //
@@ -33,6 +44,9 @@ func LoadEnv(paths ...string) error {
 }
 
 // LoadProperties reads one or more .properties files and sets environment variables from them.
+// Files are parsed the same way as java.util.Properties: '=', ':' or whitespace
+// separate keys from values, # and ! start comments, a trailing backslash
+// continues a line and \uXXXX escapes are decoded.
 // Files are loaded in order — later files override earlier ones.
 //
 // Example:
@@ -50,6 +64,13 @@ func LoadProperties(paths ...string) error {
 //	  pool:
 //	    size: 10   →  database.pool.size=10
 //
+// Sequences are flattened to indexed keys, and sequences of scalars are also
+// joined with commas under their own key:
+//
+//	servers:
+//	  - host: a    →  servers.0.host=a
+//	tags: [x, y]   →  tags.0=x, tags.1=y, tags=x,y
+//
 // Files are loaded in order — later files override earlier ones.
 //
 // Example:
@@ -60,59 +81,511 @@ func LoadYML(paths ...string) error {
 	return defaultYAMLLoader.load(paths...)
 }
 
-// envLoader is an interface for parsing environment variables from a file.
-type envParser interface {
-	parse(io.Reader) (map[string]string, error)
+// LoadTOML reads one or more .toml files and sets environment variables from them.
+// Tables, dotted keys and arrays are flattened to the same keys as LoadYML:
+//
+//	[database.pool]
+//	size = 10            →  database.pool.size=10
+//	tags = ["x", "y"]    →  database.pool.tags.0=x, database.pool.tags.1=y, database.pool.tags=x,y
+//
+// Files are loaded in order — later files override earlier ones.
+//
+// Example:
+//
+//	lxenv.LoadTOML("config.toml")
+func LoadTOML(paths ...string) error {
+	return defaultTOMLLoader.load(paths...)
+}
+
+// LoadJSON reads one or more .json files and sets environment variables from them.
+// Objects and arrays are flattened to the same keys as LoadYML:
+//
+//	{"database": {"pool": {"size": 10}}}   →  database.pool.size=10
+//
+// Files are loaded in order — later files override earlier ones.
+//
+// Example:
+//
+//	lxenv.LoadJSON("config.json")
+func LoadJSON(paths ...string) error {
+	return defaultJSONLoader.load(paths...)
+}
+
+// LoadINI reads one or more .ini files and sets environment variables from them.
+// Section names prefix their keys, and repeated key[] entries form an array
+// flattened the same way as in LoadYML:
+//
+//	[database.pool]
+//	size = 10            →  database.pool.size=10
+//
+// Files are loaded in order — later files override earlier ones.
+//
+// Example:
+//
+//	lxenv.LoadINI("config.ini")
+func LoadINI(paths ...string) error {
+	return defaultINILoader.load(paths...)
+}
+
+// ReadEnv reads one or more .env files into a Config without calling os.Setenv.
+// Files are merged in order — later files override earlier ones.
+//
+// Example:
+//
+//	cfg, err := lxenv.ReadEnv(".env", ".env.local")
+//	port := cfg.GetIntOr("PORT", 8080)
+func ReadEnv(paths ...string) (*Config, error) {
+	return defaultLoader.ReadEnv(paths...)
+}
+
+// ReadProperties reads one or more .properties files into a Config without calling os.Setenv.
+// Files are merged in order — later files override earlier ones.
+//
+// Example:
+//
+//	cfg, err := lxenv.ReadProperties("app.properties")
+//	host := cfg.Get("database.host")
+func ReadProperties(paths ...string) (*Config, error) {
+	return defaultLoader.ReadProperties(paths...)
+}
+
+// ReadYML reads one or more .yml/.yaml files into a Config without calling os.Setenv.
+// Keys are flattened as described in LoadYML. Files are merged in order — later files override earlier ones.
+//
+// Example:
+//
+//	cfg, err := lxenv.ReadYML("config.yml")
+//	size := cfg.GetIntOr("database.pool.size", 10)
+func ReadYML(paths ...string) (*Config, error) {
+	return defaultLoader.ReadYML(paths...)
+}
+
+// ReadTOML reads one or more .toml files into a Config without calling os.Setenv.
+// Keys are flattened as described in LoadTOML. Files are merged in order — later files override earlier ones.
+func ReadTOML(paths ...string) (*Config, error) {
+	return defaultLoader.ReadTOML(paths...)
+}
+
+// ReadJSON reads one or more .json files into a Config without calling os.Setenv.
+// Keys are flattened as described in LoadJSON. Files are merged in order — later files override earlier ones.
+func ReadJSON(paths ...string) (*Config, error) {
+	return defaultLoader.ReadJSON(paths...)
+}
+
+// ReadINI reads one or more .ini files into a Config without calling os.Setenv.
+// Keys are flattened as described in LoadINI. Files are merged in order — later files override earlier ones.
+func ReadINI(paths ...string) (*Config, error) {
+	return defaultLoader.ReadINI(paths...)
+}
+
+// ParseEnv parses KEY=VALUE content in .env format and returns the pairs.
+// Variable references are expanded against the parsed keys and the process
+// environment, which is never modified.
+//
+// Example:
+//
+//	pairs, err := lxenv.ParseEnv(strings.NewReader("PORT=8080"))
+//	// pairs: map[PORT:8080]
+func ParseEnv(r io.Reader) (map[string]string, error) {
+	return defaultEnvLoader.parse(r, Lookup)
+}
+
+// ParseProperties parses content in .properties format and returns the pairs.
+// Variable references are expanded against the parsed keys and the process
+// environment, which is never modified.
+//
+// Example:
+//
+//	pairs, err := lxenv.ParseProperties(strings.NewReader("server.port=8080"))
+//	// pairs: map[server.port:8080]
+func ParseProperties(r io.Reader) (map[string]string, error) {
+	return defaultPropertiesLoader.parse(r, Lookup)
+}
+
+// ParseYML parses YAML content and returns the flattened pairs (see LoadYML).
+// Variable references are expanded against the parsed keys and the process
+// environment, which is never modified.
+//
+// Example:
+//
+//	pairs, err := lxenv.ParseYML(strings.NewReader("server:\n  port: 8080\n"))
+//	// pairs: map[server.port:8080]
+func ParseYML(r io.Reader) (map[string]string, error) {
+	return defaultYAMLLoader.parse(r, Lookup)
+}
+
+// ParseTOML parses TOML content and returns the flattened pairs (see LoadTOML).
+// The process environment is never modified.
+func ParseTOML(r io.Reader) (map[string]string, error) {
+	return defaultTOMLLoader.parse(r, Lookup)
+}
+
+// ParseJSON parses JSON content and returns the flattened pairs (see LoadJSON).
+// The process environment is never modified.
+func ParseJSON(r io.Reader) (map[string]string, error) {
+	return defaultJSONLoader.parse(r, Lookup)
+}
+
+// ParseINI parses INI content and returns the flattened pairs (see LoadINI).
+// The process environment is never modified.
+func ParseINI(r io.Reader) (map[string]string, error) {
+	return defaultINILoader.parse(r, Lookup)
+}
+
+// Loader loads configuration files into the environment using a fixed set of options.
+// The package-level Load*, Read* and Parse* functions use a Loader with default options.
+//
+// When expansion is enabled, values may reference other variables:
+//
+//	$VAR, ${VAR}        value of VAR, empty if unset
+//	${VAR:-default}     default when VAR is unset or empty
+//	${VAR:?message}     fail with message when VAR is unset or empty
+//	\$ or $$            a literal $
+//
+// Single-quoted values in .env files, such as PW='pa$$word', TOML literal
+// strings and YAML single-quoted scalars are kept literally.
+//
+// References are resolved against keys of the same file, then keys loaded from
+// earlier files in the same call, then the process environment. A key that
+// references itself (PATH=${PATH}:/opt/bin) sees its previous value, and
+// reference cycles are reported as errors. With WithOverride(false) process
+// variables come first, so references see the same values as the result.
+//
+// After expansion, values such as file:///run/secrets/db or base64:... are
+// replaced by the secret they refer to (see Resolve and WithResolution).
+// Keys can then be renamed, e.g. to ENV_STYLE names, with WithKeyMapping.
+//
+// Paths may be glob patterns such as "config/*.properties"; matches are loaded
+// in lexical order. Every file is required unless marked with WithOptional or
+// produced by the profile cascade of WithProfile.
+type Loader struct {
+	options loadOptions
+}
+
+// NewLoader creates a Loader configured with opts.
+//
+// Example:
+//
+//	loader := lxenv.NewLoader(lxenv.WithExpansion(false))
+//	err := loader.LoadEnv(".env")
+func NewLoader(opts ...LoadOption) *Loader {
+	options := loadOptions{expand: true, resolve: true, override: true}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &Loader{options: options}
+}
+
+// LoadEnv reads one or more .env files and sets environment variables from them.
+// Files are loaded in order — later files override earlier ones.
+func (l *Loader) LoadEnv(paths ...string) error {
+	return l.envLoader(defaultEnvFileParser).load(paths...)
+}
+
+// LoadProperties reads one or more .properties files and sets environment variables from them.
+// Files are loaded in order — later files override earlier ones.
+func (l *Loader) LoadProperties(paths ...string) error {
+	return l.envLoader(defaultPropertiesParser).load(paths...)
+}
+
+// LoadYML reads one or more .yml/.yaml files and sets environment variables from them.
+// Nested keys are flattened as described in LoadYML.
+// Files are loaded in order — later files override earlier ones.
+func (l *Loader) LoadYML(paths ...string) error {
+	return l.envLoader(defaultYAMLParser).load(paths...)
+}
+
+// LoadTOML reads one or more .toml files and sets environment variables from them.
+// Keys are flattened as described in LoadTOML.
+// Files are loaded in order — later files override earlier ones.
+func (l *Loader) LoadTOML(paths ...string) error {
+	return l.envLoader(defaultTOMLParser).load(paths...)
+}
+
+// LoadJSON reads one or more .json files and sets environment variables from them.
+// Keys are flattened as described in LoadJSON.
+// Files are loaded in order — later files override earlier ones.
+func (l *Loader) LoadJSON(paths ...string) error {
+	return l.envLoader(defaultJSONParser).load(paths...)
+}
+
+// LoadINI reads one or more .ini files and sets environment variables from them.
+// Keys are flattened as described in LoadINI.
+// Files are loaded in order — later files override earlier ones.
+func (l *Loader) LoadINI(paths ...string) error {
+	return l.envLoader(defaultINIParser).load(paths...)
+}
+
+// ReadEnv reads one or more .env files into a Config without modifying the process environment.
+// Files are merged in order — later files override earlier ones.
+func (l *Loader) ReadEnv(paths ...string) (*Config, error) {
+	return l.readConfig(defaultEnvFileParser, paths)
 }
 
-// valueNormalizer is an interface for cleaning up parsed values.
-type valueNormalizer interface {
-	normalize(string) string
+// ReadProperties reads one or more .properties files into a Config without modifying the process environment.
+// Files are merged in order — later files override earlier ones.
+func (l *Loader) ReadProperties(paths ...string) (*Config, error) {
+	return l.readConfig(defaultPropertiesParser, paths)
 }
 
+// ReadYML reads one or more .yml/.yaml files into a Config without modifying the process environment.
+// Files are merged in order — later files override earlier ones.
+func (l *Loader) ReadYML(paths ...string) (*Config, error) {
+	return l.readConfig(defaultYAMLParser, paths)
+}
+
+// ReadTOML reads one or more .toml files into a Config without modifying the process environment.
+// Files are merged in order — later files override earlier ones.
+func (l *Loader) ReadTOML(paths ...string) (*Config, error) {
+	return l.readConfig(defaultTOMLParser, paths)
+}
+
+// ReadJSON reads one or more .json files into a Config without modifying the process environment.
+// Files are merged in order — later files override earlier ones.
+func (l *Loader) ReadJSON(paths ...string) (*Config, error) {
+	return l.readConfig(defaultJSONParser, paths)
+}
+
+// ReadINI reads one or more .ini files into a Config without modifying the process environment.
+// Files are merged in order — later files override earlier ones.
+func (l *Loader) ReadINI(paths ...string) (*Config, error) {
+	return l.readConfig(defaultINIParser, paths)
+}
+
+func (l *Loader) readConfig(parser Parser, paths []string) (*Config, error) {
+	pairs, origins, err := l.envLoader(parser).read(paths...)
+	if err != nil {
+		return nil, err
+	}
+	return &Config{values: pairs, origins: origins}, nil
+}
+
+func (l *Loader) envLoader(parser Parser) *envLoader {
+	return &envLoader{parser: parser, options: l.options}
+}
+
+// envLoader reads files with a fixed parser, or with the parser registered
+// for each file's extension when parser is nil.
 type envLoader struct {
-	parser envParser
+	parser  Parser
+	options loadOptions
+	environ func(string) (string, bool) // Process environment as seen by the loader; Lookup if nil
 }
 
+// lookupEnv looks up key in the process environment as seen by the loader.
+func (ep *envLoader) lookupEnv(key string) (string, bool) {
+	if ep.environ != nil {
+		return ep.environ(key)
+	}
+	return Lookup(key)
+}
+
+// parserFor returns the parser used to read path and the name of its format.
+func (ep *envLoader) parserFor(path string) (Parser, string, error) {
+	if ep.parser != nil {
+		return ep.parser, formatOf(ep.parser, filepath.Ext(path)), nil
+	}
+	parser, ext, err := lookupParser(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return parser, formatOf(parser, ext), nil
+}
+
+// load reads paths and sets every resulting key in the process environment.
+// Nothing is set if any file fails to load.
 func (ep *envLoader) load(paths ...string) error {
-	for _, path := range paths {
+	pairs, origins, err := ep.read(paths...)
+	if err != nil {
+		return err
+	}
+	recordOrigins(origins)
+	for k, v := range pairs {
+		if err := Set(k, v); err != nil {
+			return fmt.Errorf("set %q: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// read parses paths in order and merges them — later files override earlier ones.
+// It also returns the provenance of every key. The process environment is never modified.
+func (ep *envLoader) read(paths ...string) (map[string]string, map[string]Provenance, error) {
+	files, err := ep.options.files(paths)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// keys loaded by earlier files, used to resolve variable references
+	loaded := make(map[string]string)
+	lookup := func(key string) (string, bool) {
+		if v, ok := loaded[key]; ok {
+			return v, true
+		}
+		return ep.lookupEnv(key)
+	}
+	origins := make(map[string]Provenance)
+
+	for _, file := range files {
+		parser, format, err := ep.parserFor(file.path)
+		if err != nil {
+			return nil, nil, err
+		}
+
 		if err := func() error {
-			f, err := os.Open(path)
+			f, err := os.Open(file.path)
 			if err != nil {
+				if file.optional && errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
 				return err
 			}
 			defer f.Close()
 
-			pairs, err := ep.parser.parse(f)
+			parsed, err := ep.parseWith(parser, f, lookup)
 			if err != nil {
-				return fmt.Errorf("parse %q: %w", path, err)
+				return fmt.Errorf("parse %q: %w", file.path, err)
 			}
-			for k, v := range pairs {
-				if err := Set(k, v); err != nil {
-					return fmt.Errorf("set %q: %w", k, err)
+			for k, v := range parsed.pairs {
+				loaded[k] = v
+
+				src := Source{File: file.path, Line: keyLine(parsed.lines, k), Format: format, Value: v, Resolved: parsed.resolved[k]}
+				for _, ref := range parsed.refs[k] {
+					// keys of earlier files bring along what they were built from
+					if prev, ok := origins[ref]; ok && ref != k {
+						src.Refs = appendRefs(src.Refs, prev.Refs...)
+					}
+					src.Refs = appendRefs(src.Refs, ref)
+				}
+				p := Provenance{Key: k, Source: src}
+				if prev, ok := origins[k]; ok {
+					p.Overridden = append([]Source{prev.Source}, prev.Overridden...)
 				}
+				origins[k] = p
 			}
 			return nil
 		}(); err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
-	return nil
+
+	if !ep.options.override {
+		// real environment variables win over file values
+		for k, p := range origins {
+			if v, ok := ep.lookupEnv(k); ok {
+				loaded[k] = v
+				p.Overridden = append([]Source{p.Source}, p.Overridden...)
+				p.Source = Source{Format: sourceEnvironment, Value: v}
+				origins[k] = p
+			}
+		}
+	}
+	return loaded, origins, nil
+}
+
+// parse parses r and, when enabled, expands variable references using lookup,
+// resolves value references and maps keys.
+func (ep *envLoader) parse(r io.Reader, lookup func(string) (string, bool)) (map[string]string, error) {
+	parsed, err := ep.parseWith(ep.parser, r, lookup)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.pairs, nil
 }
 
-// envFileParser is a struct that implements the envParser interface.
+// parsedFile is the result of parseWith.
+type parsedFile struct {
+	pairs    map[string]string
+	lines    map[string]int      // line of each key, nil if the parser does not report them
+	resolved map[string]bool     // keys whose value was produced by a resolver
+	refs     map[string][]string // variables referenced by the value of each key
+}
+
+// parseWith is like parse but uses parser instead of the loader's own. It also
+// reports the line of each key when the parser does, the keys whose value was
+// produced by a resolver and the variables each value was expanded from.
+func (ep *envLoader) parseWith(parser Parser, r io.Reader, lookup func(string) (string, bool)) (*parsedFile, error) {
+	var (
+		pairs    map[string]string
+		lines    map[string]int
+		literals map[string]bool
+		resolved map[string]bool
+		refs     map[string][]string
+		err      error
+	)
+	if lp, ok := parser.(literalParser); ok {
+		pairs, lines, literals, err = lp.parseLiterals(r)
+	} else if lp, ok := parser.(lineParser); ok {
+		pairs, lines, err = lp.parseLines(r)
+	} else {
+		pairs, err = parser.Parse(r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if ep.options.expand {
+		var pinned func(string) (string, bool)
+		if !ep.options.override {
+			// references see the value that wins the final merge
+			pinned = ep.lookupEnv
+		}
+		pairs, refs, err = expandPairs(pairs, literals, pinned, lookup)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ep.options.resolve {
+		pairs, resolved, err = resolvePairs(pairs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ep.options.keyMapper != nil {
+		mapper := ep.options.keyMapper
+		pairs, lines, err = mapKeys(mapper, pairs, lines)
+		if err != nil {
+			return nil, err
+		}
+		mappedResolved := make(map[string]bool, len(resolved))
+		for k := range resolved {
+			mappedResolved[mapper(k)] = true
+		}
+		mappedRefs := make(map[string][]string, len(refs))
+		for k, names := range refs {
+			mappedRefs[mapper(k)] = names
+		}
+		resolved, refs = mappedResolved, mappedRefs
+	}
+	return &parsedFile{pairs: pairs, lines: lines, resolved: resolved, refs: refs}, nil
+}
+
+// envFileParser is a struct that implements the Parser interface.
 // It reads environment variables from .env files.
 type envFileParser struct {
-	valueNormalizer valueNormalizer
+	valueNormalizer ValueNormalizer
 }
 
-// parse parses KEY=VALUE format (.env / .properties).
+// Parse parses KEY=VALUE format (.env).
 // - Lines starting with # are comments
 // - Blank lines are ignored
-// - Values may be quoted with " or '
+// - Values may be quoted with " or '; single-quoted values are never expanded
 // - Inline comments after # are stripped (outside quotes)
-func (elp *envFileParser) parse(r io.Reader) (map[string]string, error) {
+func (elp *envFileParser) Parse(r io.Reader) (map[string]string, error) {
+	pairs, _, err := elp.parseLines(r)
+	return pairs, err
+}
+
+// parseLines is like Parse but also reports the line of each key.
+func (elp *envFileParser) parseLines(r io.Reader) (map[string]string, map[string]int, error) {
+	pairs, lines, _, err := elp.parseLiterals(r)
+	return pairs, lines, err
+}
+
+// parseLiterals is like parseLines but also reports the keys of single-quoted values.
+func (elp *envFileParser) parseLiterals(r io.Reader) (map[string]string, map[string]int, map[string]bool, error) {
 	pairs := make(map[string]string)
+	lines := make(map[string]int)
+	literals := make(map[string]bool)
 	scanner := bufio.NewScanner(r)
 	lineNum := 0
 
@@ -127,117 +600,80 @@ func (elp *envFileParser) parse(r io.Reader) (map[string]string, error) {
 
 		idx := strings.IndexByte(line, '=')
 		if idx < 0 {
-			return nil, fmt.Errorf("line %d: missing '=' in %q", lineNum, line)
+			return nil, nil, nil, fmt.Errorf("line %d: missing '=' in %q", lineNum, line)
 		}
 
 		key := strings.TrimSpace(line[:idx])
 		val := strings.TrimSpace(line[idx+1:])
 
 		if key == "" {
-			return nil, fmt.Errorf("line %d: empty key", lineNum)
+			return nil, nil, nil, fmt.Errorf("line %d: empty key", lineNum)
 		}
 
-		val = elp.valueNormalizer.normalize(val)
+		if isSingleQuoted(defaultValueNormalizer.stripInlineComment(val)) {
+			literals[key] = true
+		} else {
+			delete(literals, key)
+		}
+		val = elp.valueNormalizer.Normalize(val)
 		pairs[key] = val
+		lines[key] = lineNum
 	}
 
-	return pairs, scanner.Err()
+	if err := scanner.Err(); err != nil {
+		return nil, nil, nil, err
+	}
+	return pairs, lines, literals, nil
 }
 
-// yamlFileParser is a struct that implements the Loader interface.
+// yamlFileParser is a struct that implements the Parser interface.
 // It reads environment variables from .yml/.yaml files.
-type yamlFileParser struct {
-	valueNormalizer valueNormalizer
-}
+type yamlFileParser struct{}
 
-// parse parses nested YAML format with unlimited nesting depth.
-// Nested keys are flattened using dot-notation, e.g.:
+// Parse parses YAML documents and flattens them using dot-notation, e.g.:
 //
 //	database:
 //	  pool:
 //	    size: 10   →  database.pool.size=10
 //
 // Rules:
-// - Lines starting with # are comments and are ignored
-// - Blank lines are ignored
-// - List items starting with - are ignored
-func (yfp *yamlFileParser) parse(r io.Reader) (map[string]string, error) {
-	pairs := make(map[string]string)
-	scanner := bufio.NewScanner(r)
-
-	type frame struct {
-		indent int
-		key    string
-	}
-	stack := make([]frame, 0, 8)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		trimmed := strings.TrimSpace(line)
-
-		// skip blank lines, comments and list items
-		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "-") {
-			continue
-		}
-
-		// measure indent (tab counts as 2 spaces)
-		indent := 0
-		for _, ch := range line {
-			if ch == ' ' {
-				indent++
-			} else if ch == '\t' {
-				indent += 2
-			} else {
-				break
-			}
-		}
-
-		idx := strings.IndexByte(trimmed, ':')
-		if idx < 0 {
-			continue
-		}
-
-		key := strings.TrimSpace(trimmed[:idx])
-		val := strings.TrimSpace(trimmed[idx+1:])
-
-		if key == "" {
-			continue
-		}
+// - Block and flow mappings and sequences are supported at any depth
+// - Sequence items are flattened to indexed keys (servers.0.host)
+// - Sequences of scalars are also stored under their own key as a comma-joined list
+// - Block scalars (| and >), quoted scalars, anchors, aliases and merge keys (<<) are resolved
+// - Single-quoted scalars are never expanded
+// - Aliases that expand to an excessive number of nodes are reported as an error
+// - Null values (empty, ~, null) become empty strings
+// - Multiple documents are merged in order — later documents override earlier ones
+// - Malformed input is reported with its line and column
+func (yfp *yamlFileParser) Parse(r io.Reader) (map[string]string, error) {
+	pairs, _, err := yfp.parseLines(r)
+	return pairs, err
+}
 
-		// pop stack frames at same or deeper indent
-		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
-			stack = stack[:len(stack)-1]
-		}
+// parseLines is like Parse but also reports the line of each key.
+func (yfp *yamlFileParser) parseLines(r io.Reader) (map[string]string, map[string]int, error) {
+	pairs, lines, _, err := yfp.parseLiterals(r)
+	return pairs, lines, err
+}
 
-		// build full dot-notation key
-		fullKey := key
-		if len(stack) > 0 {
-			parts := make([]string, 0, len(stack)+1)
-			for _, f := range stack {
-				parts = append(parts, f.key)
-			}
-			parts = append(parts, key)
-			fullKey = strings.Join(parts, ".")
-		}
+// parseLiterals is like parseLines but also reports the keys of single-quoted scalars.
+func (yfp *yamlFileParser) parseLiterals(r io.Reader) (map[string]string, map[string]int, map[string]bool, error) {
+	docs, err := parseYAML(r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-		if val == "" {
-			// mapping parent — push onto stack only, do NOT emit an env var
-			stack = append(stack, frame{indent: indent, key: key})
-		} else {
-			pairs[fullKey] = yfp.valueNormalizer.normalize(val)
+	pairs := make(map[string]string)
+	lines := make(map[string]int)
+	literals := make(map[string]bool)
+	exp := &yamlExpansion{}
+	for _, doc := range docs {
+		if err := flattenYAML("", doc, pairs, lines, literals, exp); err != nil {
+			return nil, nil, nil, err
 		}
 	}
-
-	return pairs, scanner.Err()
-}
-
-// propertiesFileParser is a wrapper around envParser.
-type propertiesFileParser struct {
-	envFileParser envParser
-}
-
-func (pfp *propertiesFileParser) parse(r io.Reader) (map[string]string, error) {
-	return pfp.envFileParser.parse(r)
+	return pairs, lines, literals, nil
 }
 
 // simpleValueNormalizer handles raw string cleanup for parsed values.
@@ -265,6 +701,11 @@ func (*simpleValueNormalizer) stripInlineComment(s string) string {
 	return s
 }
 
+// isSingleQuoted reports whether s is enclosed in single quotes.
+func isSingleQuoted(s string) bool {
+	return len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\''
+}
+
 // unquote removes surrounding single or double quotes from a value.
 func (*simpleValueNormalizer) unquote(s string) string {
 	if len(s) >= 2 {
@@ -276,9 +717,9 @@ func (*simpleValueNormalizer) unquote(s string) string {
 	return s
 }
 
-// normalize strips inline comments, trims surrounding whitespace, then unquotes the value.
+// Normalize strips inline comments, trims surrounding whitespace, then unquotes the value.
 // This is the standard pipeline for any parsed scalar value.
-func (p *simpleValueNormalizer) normalize(s string) string {
+func (p *simpleValueNormalizer) Normalize(s string) string {
 	s = p.stripInlineComment(s)
 	s = strings.TrimSpace(s)
 	return p.unquote(s)
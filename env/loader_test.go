@@ -1,7 +1,11 @@
 package lxenv_test
 
 import (
+	"io"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/hgapdvn/lx/env"
@@ -31,7 +35,7 @@ var allBasePropertiesKeys = []string{
 	"cache.type", "cache.host", "cache.port", "cache.password",
 	"mail.host", "mail.port", "mail.username", "mail.auth",
 	"logging.level", "logging.file",
-	"app.greeting", "app.single_quoted", "app.stripped",
+	"app.greeting", "app.quoted", "app.inline_hash",
 	"app.token", "app.secret", "app.empty",
 }
 
@@ -63,7 +67,7 @@ func cleanupKeys(t *testing.T, keys []string) {
 	t.Helper()
 	t.Cleanup(func() {
 		for _, k := range keys {
-			os.Unsetenv(k)
+			lxenv.Unset(k)
 		}
 	})
 }
@@ -201,8 +205,8 @@ func TestLoadProperties_Base(t *testing.T) {
 		{"logging.level", "INFO"},
 		{"logging.file", "logs/app.log"},
 		{"app.greeting", "hello world"},
-		{"app.single_quoted", "single quoted value"},
-		{"app.stripped", "value"},
+		{"app.quoted", `"hello world"`},
+		{"app.inline_hash", "value # not a comment"},
 		{"app.token", "abc#123"},
 		{"app.secret", "p@ssw0rd!"},
 		{"app.empty", ""},
@@ -488,7 +492,7 @@ func TestLoadYML_ParentKeysNotSetInEnv(t *testing.T) {
 	}
 }
 
-func TestLoadYML_ListItemsSkipped(t *testing.T) {
+func TestLoadYML_ListItems(t *testing.T) {
 	content := []byte(`server:
   host: localhost
   allowed:
@@ -509,23 +513,298 @@ func TestLoadYML_ListItemsSkipped(t *testing.T) {
 	}
 	f.Close()
 
-	keysToClean := []string{"server", "server.host", "server.allowed", "server.port"}
+	keysToClean := []string{"server", "server.host", "server.allowed", "server.allowed.0", "server.allowed.1", "server.port"}
 	cleanupKeys(t, keysToClean)
 
 	if err := lxenv.LoadYML(f.Name()); err != nil {
 		t.Fatalf("LoadYML() unexpected error: %v", err)
 	}
 
+	tests := []struct{ key, want string }{
+		{"server.host", "localhost"},
+		{"server.port", "8080"},
+		{"server.allowed.0", "item1"},
+		{"server.allowed.1", "item2"},
+		{"server.allowed", "item1,item2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := os.Getenv(tt.key); got != tt.want {
+				t.Errorf("env[%q] = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+	if _, exists := os.LookupEnv("server"); exists {
+		t.Errorf("parent key %q should not be set in env", "server")
+	}
+}
+
+// -----------------------------------------------
+// Variable expansion
+// -----------------------------------------------
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	return p
+}
+
+func TestLoadEnv_Expansion(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_EXP_USER", "TEST_EXP_HOST", "TEST_EXP_URL", "TEST_EXP_HOME_CACHE", "TEST_EXP_PORT"})
+	t.Setenv("TEST_EXP_HOME", "/home/lx")
+
+	base := writeTestFile(t, "base.env", "TEST_EXP_USER=admin\nTEST_EXP_HOST=localhost\n")
+	override := writeTestFile(t, "override.env", strings.Join([]string{
+		`TEST_EXP_URL="postgres://${TEST_EXP_USER}@${TEST_EXP_HOST}:${TEST_EXP_PORT}/db"`,
+		`TEST_EXP_PORT=${TEST_EXP_MISSING:-5432}`,
+		`TEST_EXP_HOME_CACHE=$TEST_EXP_HOME/.cache`,
+	}, "\n"))
+
+	if err := lxenv.LoadEnv(base, override); err != nil {
+		t.Fatalf("LoadEnv() unexpected error: %v", err)
+	}
+
+	tests := []struct{ key, want string }{
+		{"TEST_EXP_URL", "postgres://admin@localhost:5432/db"},
+		{"TEST_EXP_PORT", "5432"},
+		{"TEST_EXP_HOME_CACHE", "/home/lx/.cache"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := os.Getenv(tt.key); got != tt.want {
+				t.Errorf("env[%q] = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadEnv_LiteralDollars(t *testing.T) {
+	t.Setenv("TEST_EXP_HOME", "/home/lx")
+
+	pairs, err := lxenv.ParseEnv(strings.NewReader(strings.Join([]string{
+		`SINGLE='pa$$word ${TEST_EXP_HOME}' # comment`,
+		`DOUBLE="pa$$word"`,
+		`BARE=pa$$word`,
+		`REF=${SINGLE}`,
+	}, "\n")))
+	if err != nil {
+		t.Fatalf("ParseEnv() unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"SINGLE": "pa$$word ${TEST_EXP_HOME}",
+		"DOUBLE": "pa$word",
+		"BARE":   "pa$word",
+		"REF":    "pa$$word ${TEST_EXP_HOME}",
+	}
+	if !reflect.DeepEqual(pairs, want) {
+		t.Errorf("ParseEnv() = %q, want %q", pairs, want)
+	}
+}
+
+func TestParseTOML_LiteralDollars(t *testing.T) {
+	t.Setenv("TEST_EXP_HOME", "/home/lx")
+
+	pairs, err := lxenv.ParseTOML(strings.NewReader(strings.Join([]string{
+		`single = 'pa$$word ${TEST_EXP_HOME}'`,
+		`multi = '''`,
+		`pa$$word ${TEST_EXP_HOME}'''`,
+		`basic = "pa$$word"`,
+		`list = ['$$a', '${b}']`,
+		`mixed = ['$$a', "${TEST_EXP_HOME}"]`,
+	}, "\n")))
+	if err != nil {
+		t.Fatalf("ParseTOML() unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"single":  "pa$$word ${TEST_EXP_HOME}",
+		"multi":   "pa$$word ${TEST_EXP_HOME}",
+		"basic":   "pa$word",
+		"list":    "$$a,${b}",
+		"list.0":  "$$a",
+		"list.1":  "${b}",
+		"mixed":   "$a,/home/lx",
+		"mixed.0": "$$a",
+		"mixed.1": "/home/lx",
+	}
+	if !reflect.DeepEqual(pairs, want) {
+		t.Errorf("ParseTOML() = %q, want %q", pairs, want)
+	}
+}
+
+func TestParseYML_LiteralDollars(t *testing.T) {
+	t.Setenv("TEST_EXP_HOME", "/home/lx")
+
+	pairs, err := lxenv.ParseYML(strings.NewReader(strings.Join([]string{
+		`single: 'pa$$word ${TEST_EXP_HOME}'`,
+		`double: "pa$$word"`,
+		`plain: pa$$word`,
+		`flow: ['$$a', '${b}']`,
+		`ref: ${single}`,
+		`---`,
+		`plain: 'pa$$word'`,
+		`single: ${TEST_EXP_HOME}`,
+	}, "\n")))
+	if err != nil {
+		t.Fatalf("ParseYML() unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"single": "/home/lx",
+		"double": "pa$word",
+		"plain":  "pa$$word",
+		"flow":   "$$a,${b}",
+		"flow.0": "$$a",
+		"flow.1": "${b}",
+		"ref":    "/home/lx",
+	}
+	if !reflect.DeepEqual(pairs, want) {
+		t.Errorf("ParseYML() = %q, want %q", pairs, want)
+	}
+}
+
+func TestLoadYML_Expansion(t *testing.T) {
+	cleanupKeys(t, []string{"database.host", "database.url"})
+
+	p := writeTestFile(t, "app.yml", "database:\n  host: db\n  url: jdbc://${database.host}:5432\n")
+	if err := lxenv.LoadYML(p); err != nil {
+		t.Fatalf("LoadYML() unexpected error: %v", err)
+	}
+	if got := os.Getenv("database.url"); got != "jdbc://db:5432" {
+		t.Errorf("env[%q] = %q, want %q", "database.url", got, "jdbc://db:5432")
+	}
+}
+
+func TestLoadEnv_ExpansionError(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_EXP_A", "TEST_EXP_B"})
+
+	p := writeTestFile(t, "cycle.env", "TEST_EXP_A=${TEST_EXP_B}\nTEST_EXP_B=${TEST_EXP_A}\n")
+	err := lxenv.LoadEnv(p)
+	if err == nil {
+		t.Fatal("LoadEnv() expected error for reference cycle, got nil")
+	}
+	if !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("LoadEnv() error = %q, want it to mention the cycle", err)
+	}
+	if lxenv.Has("TEST_EXP_A") {
+		t.Error("no keys should be set when expansion fails")
+	}
+}
+
+func TestLoader_WithExpansionDisabled(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_EXP_RAW"})
+
+	p := writeTestFile(t, "raw.env", "TEST_EXP_RAW=${HOME}/x\n")
+	if err := lxenv.NewLoader(lxenv.WithExpansion(false)).LoadEnv(p); err != nil {
+		t.Fatalf("LoadEnv() unexpected error: %v", err)
+	}
+	if got := os.Getenv("TEST_EXP_RAW"); got != "${HOME}/x" {
+		t.Errorf("env[%q] = %q, want %q", "TEST_EXP_RAW", got, "${HOME}/x")
+	}
+}
+
+// -----------------------------------------------
+// LoadTOML / LoadJSON / LoadINI
+// -----------------------------------------------
+
+func TestFormats_FlattenConsistently(t *testing.T) {
+	want := map[string]string{
+		"app.name":           "lx",
+		"app.debug":          "true",
+		"database.host":      "localhost",
+		"database.pool.size": "10",
+		"tags.0":             "a",
+		"tags.1":             "b",
+		"tags":               "a,b",
+	}
+
+	inputs := []struct {
+		name  string
+		parse func(io.Reader) (map[string]string, error)
+		input string
+	}{
+		{"YML", lxenv.ParseYML, "app:\n  name: lx\n  debug: true\ndatabase:\n  host: localhost\n  pool:\n    size: 10\ntags: [a, b]\n"},
+		{"TOML", lxenv.ParseTOML, "tags = [\"a\", \"b\"]\n[app]\nname = \"lx\"\ndebug = true\n[database]\nhost = \"localhost\"\npool.size = 10\n"},
+		{"JSON", lxenv.ParseJSON, `{"app": {"name": "lx", "debug": true}, "database": {"host": "localhost", "pool": {"size": 10}}, "tags": ["a", "b"]}`},
+		{"INI", lxenv.ParseINI, "tags[] = a\ntags[] = b\n[app]\nname = lx\ndebug = true\n[database]\nhost = localhost\n[database.pool]\nsize = 10\n"},
+	}
+	for _, tt := range inputs {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse%s() unexpected error: %v", tt.name, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Parse%s() = %v, want %v", tt.name, got, want)
+			}
+		})
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	cleanupKeys(t, []string{"server.host", "server.port"})
+
+	base := writeTestFile(t, "base.toml", "[server]\nhost = \"localhost\"\nport = 8080\n")
+	override := writeTestFile(t, "override.toml", "server.port = 9090\n")
+	if err := lxenv.LoadTOML(base, override); err != nil {
+		t.Fatalf("LoadTOML() unexpected error: %v", err)
+	}
 	if got := os.Getenv("server.host"); got != "localhost" {
 		t.Errorf("env[%q] = %q, want %q", "server.host", got, "localhost")
 	}
-	if got := os.Getenv("server.port"); got != "8080" {
-		t.Errorf("env[%q] = %q, want %q", "server.port", got, "8080")
+	if got := os.Getenv("server.port"); got != "9090" {
+		t.Errorf("env[%q] = %q, want %q", "server.port", got, "9090")
 	}
-	if _, exists := os.LookupEnv("server.allowed"); exists {
-		t.Errorf("list parent key %q should not be set in env", "server.allowed")
+}
+
+func TestLoadJSON(t *testing.T) {
+	cleanupKeys(t, []string{"server.host", "server.url"})
+
+	p := writeTestFile(t, "app.json", `{"server": {"host": "db", "url": "http://${server.host}"}}`)
+	if err := lxenv.LoadJSON(p); err != nil {
+		t.Fatalf("LoadJSON() unexpected error: %v", err)
 	}
-	if _, exists := os.LookupEnv("server"); exists {
-		t.Errorf("parent key %q should not be set in env", "server")
+	if got := os.Getenv("server.url"); got != "http://db" {
+		t.Errorf("env[%q] = %q, want %q", "server.url", got, "http://db")
+	}
+}
+
+func TestLoadINI(t *testing.T) {
+	cleanupKeys(t, []string{"server.host"})
+
+	p := writeTestFile(t, "app.ini", "[server]\nhost = localhost\n")
+	if err := lxenv.LoadINI(p); err != nil {
+		t.Fatalf("LoadINI() unexpected error: %v", err)
+	}
+	if got := os.Getenv("server.host"); got != "localhost" {
+		t.Errorf("env[%q] = %q, want %q", "server.host", got, "localhost")
+	}
+}
+
+func TestReadFormats_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		read func(...string) (*lxenv.Config, error)
+		ext  string
+		bad  string
+	}{
+		{"TOML", lxenv.ReadTOML, ".toml", "a = \n"},
+		{"JSON", lxenv.ReadJSON, ".json", "{"},
+		{"INI", lxenv.ReadINI, ".ini", "plain\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.read("testdata/nonexistent" + tt.ext); err == nil {
+				t.Errorf("Read%s() expected error for non-existent file, got nil", tt.name)
+			}
+			p := writeTestFile(t, "bad"+tt.ext, tt.bad)
+			if _, err := tt.read(p); err == nil || !strings.Contains(err.Error(), p) {
+				t.Errorf("Read%s() error = %v, want parse error naming %s", tt.name, err, p)
+			}
+		})
 	}
 }
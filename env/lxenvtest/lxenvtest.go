@@ -0,0 +1,262 @@
+// Package lxenvtest provides helpers for tests that change environment
+// variables. Every change is undone when the test finishes, restoring the
+// previous value or removing the variable if it was unset before, so tests
+// cannot leak state into each other.
+//
+// Unlike testing.T.Setenv, the helpers may be used by parallel tests as long
+// as they change different variables. A test that changes a variable already
+// changed by another running test fails instead of silently racing with it.
+//
+// Example:
+//
+//	func TestServer(t *testing.T) {
+//	    t.Parallel()
+//	    lxenvtest.Setenv(t, "SERVER_PORT", "0")
+//	    lxenvtest.LoadFixture(t, "testdata/server.env")
+//	    ...
+//	}
+package lxenvtest
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hgapdvn/lx/env"
+)
+
+// previous is the state of a variable before it was changed.
+type previous struct {
+	value string
+	set   bool
+}
+
+// restore sets key back to p.
+func (p previous) restore(key string) error {
+	if p.set {
+		return os.Setenv(key, p.value)
+	}
+	return os.Unsetenv(key)
+}
+
+// holder is an owner of a change to a variable.
+type holder struct {
+	owner any    // testing.TB, or a unique token for WithEnv
+	name  string // test name, used in error messages
+}
+
+// holders lists, per variable, the owners of the changes in effect, innermost last.
+var (
+	holdersMu sync.Mutex
+	holders   = make(map[string][]holder)
+)
+
+// acquire records a change to key by owner. A change may be nested inside
+// one made by the same test or by one of its parents; WithEnv may nest
+// inside any change. It fails if another test holds the key.
+func acquire(owner any, name, key string) error {
+	holdersMu.Lock()
+	defer holdersMu.Unlock()
+
+	if stack := holders[key]; len(stack) > 0 {
+		top := stack[len(stack)-1]
+		_, scoped := owner.(testing.TB)
+		if top.owner != owner && scoped && !strings.HasPrefix(name, top.name+"/") {
+			return fmt.Errorf("lxenvtest: %s is already changed by %s, which runs concurrently with %s", key, top.name, name)
+		}
+	}
+	holders[key] = append(holders[key], holder{owner: owner, name: name})
+	return nil
+}
+
+// release removes the innermost change to key by owner.
+func release(owner any, key string) {
+	holdersMu.Lock()
+	defer holdersMu.Unlock()
+
+	stack := holders[key]
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i].owner == owner {
+			stack = append(stack[:i], stack[i+1:]...)
+			break
+		}
+	}
+	if len(stack) == 0 {
+		delete(holders, key)
+	} else {
+		holders[key] = stack
+	}
+}
+
+// change claims key for owner, sets or unsets it and returns its previous state.
+func change(owner any, name, key, value string, unset bool) (previous, error) {
+	if err := acquire(owner, name, key); err != nil {
+		return previous{}, err
+	}
+	var (
+		prev previous
+		err  error
+	)
+	prev.value, prev.set = os.LookupEnv(key)
+	if unset {
+		err = os.Unsetenv(key)
+	} else {
+		err = os.Setenv(key, value)
+	}
+	if err != nil {
+		release(owner, key)
+		return previous{}, fmt.Errorf("lxenvtest: %s: %w", key, err)
+	}
+	return prev, nil
+}
+
+// Setenv sets the environment variable key to value for the duration of t.
+// The previous value, or the absence of the variable, is restored by
+// t.Cleanup. A test may override variables changed by itself or by its
+// parent test; it fails if another running test has changed key with this
+// package.
+//
+// Example:
+//
+//	lxenvtest.Setenv(t, "LOG_LEVEL", "debug")
+func Setenv(t testing.TB, key, value string) {
+	t.Helper()
+	set(t, key, value, false)
+}
+
+// Unsetenv removes the environment variable key for the duration of t, as
+// Setenv does for a value.
+//
+// Example:
+//
+//	lxenvtest.Unsetenv(t, "HOME")
+func Unsetenv(t testing.TB, key string) {
+	t.Helper()
+	set(t, key, "", true)
+}
+
+// set changes key for t and restores it when t finishes.
+func set(t testing.TB, key, value string, unset bool) {
+	t.Helper()
+	prev, err := change(t, t.Name(), key, value, unset)
+	if err != nil {
+		t.Fatalf("%v", err)
+		return
+	}
+	t.Cleanup(func() {
+		defer release(t, key)
+		if err := prev.restore(key); err != nil {
+			t.Errorf("lxenvtest: restore %s: %v", key, err)
+		}
+	})
+}
+
+// WithEnv sets every variable in env, calls fn and restores the previous
+// values before returning, even if fn panics. WithEnv may override variables
+// changed by the calling test, and while fn runs, Setenv and Unsetenv fail
+// any test that changes one of its variables.
+//
+// WithEnv has no test to attribute its changes to, so it cannot tell whether
+// a variable it overrides was changed by the calling test or by another one,
+// and it does not detect concurrent WithEnv calls. Prefer Setenv in parallel
+// tests.
+//
+// Example:
+//
+//	lxenvtest.WithEnv(map[string]string{"APP_ENV": "test"}, func() {
+//	    cfg = loadConfig()
+//	})
+func WithEnv(env map[string]string, fn func()) {
+	owner := new(struct{ byte })
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	changed := make(map[string]previous, len(keys))
+	defer func() {
+		for k, prev := range changed {
+			prev.restore(k)
+			release(owner, k)
+		}
+	}()
+
+	for _, k := range keys {
+		prev, err := change(owner, "WithEnv", k, env[k], false)
+		if err != nil {
+			panic(err.Error())
+		}
+		changed[k] = prev
+	}
+	fn()
+}
+
+// LoadFixture loads the configuration file at path as lxenv.Load does and
+// sets each of its keys with Setenv, so they are restored when t finishes.
+// Fails t if the file cannot be read.
+//
+// Example:
+//
+//	lxenvtest.LoadFixture(t, "testdata/app.yml")
+func LoadFixture(t testing.TB, path string) {
+	t.Helper()
+	cfg, err := lxenv.Read(path)
+	if err != nil {
+		t.Fatalf("lxenvtest: load fixture: %v", err)
+		return
+	}
+	for _, k := range cfg.Keys() {
+		value, _ := cfg.Lookup(k)
+		Setenv(t, k, value)
+	}
+}
+
+// State is a copy of the whole process environment taken by Snapshot.
+type State struct {
+	env map[string]string
+}
+
+// Snapshot copies the current process environment and puts it back with
+// t.Cleanup when t finishes, which makes it suitable for code that changes
+// variables it does not know in advance. Snapshot does not coordinate with
+// Setenv; do not use it while parallel tests change the environment.
+//
+// Example:
+//
+//	lxenvtest.Snapshot(t)
+//	legacy.Init() // sets variables of its own
+func Snapshot(t testing.TB) *State {
+	t.Helper()
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok && k != "" {
+			env[k] = v
+		}
+	}
+	s := &State{env: env}
+	t.Cleanup(s.Restore)
+	return s
+}
+
+// Restore returns the process environment to the snapshot: variables set
+// since are removed and changed or removed variables get their old values.
+// It runs automatically when the test finishes, and may also be called
+// earlier.
+func (s *State) Restore() {
+	for _, kv := range os.Environ() {
+		if k, _, ok := strings.Cut(kv, "="); ok && k != "" {
+			if _, keep := s.env[k]; !keep {
+				os.Unsetenv(k)
+			}
+		}
+	}
+	for k, v := range s.env {
+		if current, ok := os.LookupEnv(k); !ok || current != v {
+			os.Setenv(k, v)
+		}
+	}
+}
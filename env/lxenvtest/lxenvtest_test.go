@@ -0,0 +1,216 @@
+package lxenvtest_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hgapdvn/lx/env/lxenvtest"
+)
+
+// fakeT records failures and cleanups instead of stopping the test.
+type fakeT struct {
+	testing.TB
+	name     string
+	failure  string
+	cleanups []func()
+}
+
+func (f *fakeT) Helper()      {}
+func (f *fakeT) Name() string { return f.name }
+
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.failure = fmt.Sprintf(format, args...)
+}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.failure = fmt.Sprintf(format, args...)
+}
+
+func (f *fakeT) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+// finish runs the cleanups in reverse order, as the testing package does.
+func (f *fakeT) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+	f.cleanups = nil
+}
+
+func TestSetenv_RestoresUnsetAndEmpty(t *testing.T) {
+	os.Unsetenv("LXENVTEST_UNSET")
+	os.Setenv("LXENVTEST_EMPTY", "")
+	os.Setenv("LXENVTEST_SET", "old")
+	defer os.Unsetenv("LXENVTEST_EMPTY")
+	defer os.Unsetenv("LXENVTEST_SET")
+
+	ft := &fakeT{TB: t, name: "TestA"}
+	lxenvtest.Setenv(ft, "LXENVTEST_UNSET", "1")
+	lxenvtest.Setenv(ft, "LXENVTEST_EMPTY", "2")
+	lxenvtest.Unsetenv(ft, "LXENVTEST_SET")
+	lxenvtest.Setenv(ft, "LXENVTEST_UNSET", "again")
+
+	if v, _ := os.LookupEnv("LXENVTEST_UNSET"); v != "again" {
+		t.Errorf("LXENVTEST_UNSET = %q, want again", v)
+	}
+	if _, ok := os.LookupEnv("LXENVTEST_SET"); ok {
+		t.Error("Unsetenv() left LXENVTEST_SET set")
+	}
+
+	ft.finish()
+	if ft.failure != "" {
+		t.Fatalf("unexpected failure: %s", ft.failure)
+	}
+	if _, ok := os.LookupEnv("LXENVTEST_UNSET"); ok {
+		t.Error("LXENVTEST_UNSET should be unset again")
+	}
+	if v, ok := os.LookupEnv("LXENVTEST_EMPTY"); !ok || v != "" {
+		t.Errorf("LXENVTEST_EMPTY = (%q, %v), want set and empty", v, ok)
+	}
+	if v, _ := os.LookupEnv("LXENVTEST_SET"); v != "old" {
+		t.Errorf("LXENVTEST_SET = %q, want old", v)
+	}
+}
+
+func TestSetenv_DetectsConcurrentTests(t *testing.T) {
+	a := &fakeT{TB: t, name: "TestA"}
+	b := &fakeT{TB: t, name: "TestB"}
+	sub := &fakeT{TB: t, name: "TestA/sub"}
+
+	lxenvtest.Setenv(a, "LXENVTEST_SHARED", "a")
+	lxenvtest.Setenv(sub, "LXENVTEST_SHARED", "sub")
+	if sub.failure != "" {
+		t.Errorf("a subtest overriding its parent failed: %s", sub.failure)
+	}
+
+	lxenvtest.Setenv(b, "LXENVTEST_SHARED", "b")
+	if !strings.Contains(b.failure, "already changed by TestA/sub") {
+		t.Errorf("concurrent Setenv failure = %q", b.failure)
+	}
+	if v, _ := os.LookupEnv("LXENVTEST_SHARED"); v != "sub" {
+		t.Errorf("LXENVTEST_SHARED = %q, want sub", v)
+	}
+
+	sub.finish()
+	a.finish()
+	b.failure = ""
+	lxenvtest.Setenv(b, "LXENVTEST_SHARED", "b")
+	if b.failure != "" {
+		t.Errorf("Setenv() after the other test finished failed: %s", b.failure)
+	}
+	b.finish()
+	if _, ok := os.LookupEnv("LXENVTEST_SHARED"); ok {
+		t.Error("LXENVTEST_SHARED should be unset again")
+	}
+}
+
+func TestSetenv_Parallel(t *testing.T) {
+	for i := 0; i < 8; i++ {
+		key := fmt.Sprintf("LXENVTEST_PARALLEL_%d", i)
+		t.Run(key, func(t *testing.T) {
+			t.Parallel()
+			lxenvtest.Setenv(t, key, "on")
+			if v, _ := os.LookupEnv(key); v != "on" {
+				t.Errorf("%s = %q, want on", key, v)
+			}
+		})
+	}
+}
+
+func TestWithEnv(t *testing.T) {
+	lxenvtest.Setenv(t, "LXENVTEST_WITH_A", "outer")
+
+	lxenvtest.WithEnv(map[string]string{"LXENVTEST_WITH_A": "inner", "LXENVTEST_WITH_B": ""}, func() {
+		if v, _ := os.LookupEnv("LXENVTEST_WITH_A"); v != "inner" {
+			t.Errorf("LXENVTEST_WITH_A = %q, want inner", v)
+		}
+		if v, ok := os.LookupEnv("LXENVTEST_WITH_B"); !ok || v != "" {
+			t.Errorf("LXENVTEST_WITH_B = (%q, %v), want set and empty", v, ok)
+		}
+	})
+
+	if v, _ := os.LookupEnv("LXENVTEST_WITH_A"); v != "outer" {
+		t.Errorf("LXENVTEST_WITH_A = %q, want outer", v)
+	}
+	if _, ok := os.LookupEnv("LXENVTEST_WITH_B"); ok {
+		t.Error("LXENVTEST_WITH_B should be unset again")
+	}
+}
+
+func TestWithEnv_RestoresOnPanic(t *testing.T) {
+	func() {
+		defer func() { recover() }()
+		lxenvtest.WithEnv(map[string]string{"LXENVTEST_PANIC": "1"}, func() {
+			panic("boom")
+		})
+	}()
+	if _, ok := os.LookupEnv("LXENVTEST_PANIC"); ok {
+		t.Error("WithEnv() did not restore after a panic")
+	}
+}
+
+func TestWithEnv_ConflictingSetenv(t *testing.T) {
+	lxenvtest.WithEnv(map[string]string{"LXENVTEST_WITH_HELD": "1"}, func() {
+		other := &fakeT{TB: t, name: "TestOther"}
+		lxenvtest.Setenv(other, "LXENVTEST_WITH_HELD", "2")
+		if !strings.Contains(other.failure, "already changed by WithEnv") {
+			t.Errorf("Setenv() while WithEnv holds the variable failure = %q", other.failure)
+		}
+		other.finish()
+		if v, _ := os.LookupEnv("LXENVTEST_WITH_HELD"); v != "1" {
+			t.Errorf("LXENVTEST_WITH_HELD = %q, want 1", v)
+		}
+	})
+}
+
+func TestLoadFixture(t *testing.T) {
+	ft := &fakeT{TB: t, name: "TestFixture"}
+	lxenvtest.LoadFixture(ft, "testdata/app.env")
+	if ft.failure != "" {
+		t.Fatalf("LoadFixture() failed: %s", ft.failure)
+	}
+	if v, _ := os.LookupEnv("FIXTURE_HOST"); v != "db.local" {
+		t.Errorf("FIXTURE_HOST = %q, want db.local", v)
+	}
+	if v, ok := os.LookupEnv("FIXTURE_EMPTY"); !ok || v != "" {
+		t.Errorf("FIXTURE_EMPTY = (%q, %v), want set and empty", v, ok)
+	}
+
+	ft.finish()
+	if _, ok := os.LookupEnv("FIXTURE_HOST"); ok {
+		t.Error("FIXTURE_HOST should be unset after the test")
+	}
+
+	missing := &fakeT{TB: t, name: "TestMissing"}
+	lxenvtest.LoadFixture(missing, "testdata/missing.env")
+	if !strings.Contains(missing.failure, "load fixture") {
+		t.Errorf("LoadFixture() of a missing file failure = %q", missing.failure)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	os.Setenv("LXENVTEST_SNAP_CHANGED", "before")
+	os.Setenv("LXENVTEST_SNAP_REMOVED", "before")
+	defer os.Unsetenv("LXENVTEST_SNAP_CHANGED")
+	defer os.Unsetenv("LXENVTEST_SNAP_REMOVED")
+
+	ft := &fakeT{TB: t, name: "TestSnapshot"}
+	lxenvtest.Snapshot(ft)
+	os.Setenv("LXENVTEST_SNAP_CHANGED", "after")
+	os.Unsetenv("LXENVTEST_SNAP_REMOVED")
+	os.Setenv("LXENVTEST_SNAP_ADDED", "after")
+	ft.finish() // runs the cleanup registered by Snapshot
+
+	if v, _ := os.LookupEnv("LXENVTEST_SNAP_CHANGED"); v != "before" {
+		t.Errorf("LXENVTEST_SNAP_CHANGED = %q, want before", v)
+	}
+	if v, _ := os.LookupEnv("LXENVTEST_SNAP_REMOVED"); v != "before" {
+		t.Errorf("LXENVTEST_SNAP_REMOVED = %q, want before", v)
+	}
+	if _, ok := os.LookupEnv("LXENVTEST_SNAP_ADDED"); ok {
+		t.Error("LXENVTEST_SNAP_ADDED should be removed")
+	}
+}
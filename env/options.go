@@ -0,0 +1,189 @@
+package lxenv
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LoadOption configures a Loader.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	expand     bool
+	resolve    bool
+	override   bool
+	optional   map[string]bool
+	profile    string
+	profileKey string
+	keyMapper  KeyMapper
+}
+
+// WithExpansion enables or disables variable expansion in loaded values.
+// Expansion is enabled by default.
+//
+// Example:
+//
+//	loader := lxenv.NewLoader(lxenv.WithExpansion(false))
+//	loader.LoadEnv(".env") // "${HOME}" is stored literally
+func WithExpansion(enabled bool) LoadOption {
+	return func(o *loadOptions) {
+		o.expand = enabled
+	}
+}
+
+// WithResolution enables or disables resolving value references such as
+// file:///run/secrets/db and base64:... in loaded values (see Resolve).
+// References are resolved after variable expansion. Resolution is enabled by default.
+//
+// Example:
+//
+//	loader := lxenv.NewLoader(lxenv.WithResolution(false))
+//	loader.LoadEnv(".env") // "file:///run/secrets/db" is stored literally
+func WithResolution(enabled bool) LoadOption {
+	return func(o *loadOptions) {
+		o.resolve = enabled
+	}
+}
+
+// WithOverride controls whether loaded values replace variables that are
+// already set in the process environment. Override is enabled by default;
+// with WithOverride(false) real environment variables win over file values,
+// which is the usual dotenv behaviour.
+//
+// Example:
+//
+//	// PORT=9000 in the environment is kept even if .env sets PORT=8080
+//	loader := lxenv.NewLoader(lxenv.WithOverride(false))
+//	err := loader.LoadEnv(".env")
+func WithOverride(enabled bool) LoadOption {
+	return func(o *loadOptions) {
+		o.override = enabled
+	}
+}
+
+// WithOptional marks paths as optional: a missing file, or a glob pattern
+// that matches nothing, is skipped instead of failing the load.
+// Paths are matched exactly as they are passed to the Loader.
+//
+// Example:
+//
+//	loader := lxenv.NewLoader(lxenv.WithOptional(".env.local"))
+//	err := loader.LoadEnv(".env", ".env.local")
+func WithOptional(paths ...string) LoadOption {
+	return func(o *loadOptions) {
+		if o.optional == nil {
+			o.optional = make(map[string]bool, len(paths))
+		}
+		for _, path := range paths {
+			o.optional[path] = true
+		}
+	}
+}
+
+// WithProfile enables the profile cascade for profile. Each path is followed
+// by its profile-specific variants, both optional:
+//
+//	.env        → .env, .env.{profile}, .env.{profile}.local
+//	config.yml  → config.yml, config.{profile}.yml, config.{profile}.local.yml
+//
+// An empty profile disables the cascade.
+//
+// Example:
+//
+//	loader := lxenv.NewLoader(lxenv.WithProfile("production"))
+//	err := loader.LoadEnv(".env")
+func WithProfile(profile string) LoadOption {
+	return func(o *loadOptions) {
+		o.profile, o.profileKey = profile, ""
+	}
+}
+
+// WithProfileEnv is like WithProfile but reads the profile name from the
+// environment variable key each time files are loaded.
+//
+// Example:
+//
+//	// APP_ENV=staging loads .env, .env.staging and .env.staging.local
+//	loader := lxenv.NewLoader(lxenv.WithProfileEnv("APP_ENV"))
+//	err := loader.LoadEnv(".env")
+func WithProfileEnv(key string) LoadOption {
+	return func(o *loadOptions) {
+		o.profile, o.profileKey = "", key
+	}
+}
+
+// loadFile is a single file to be loaded.
+type loadFile struct {
+	path     string
+	optional bool
+}
+
+// files resolves paths into the ordered list of files to load, applying the
+// profile cascade and expanding glob patterns.
+func (o loadOptions) files(paths []string) ([]loadFile, error) {
+	profile := o.profile
+	if o.profileKey != "" {
+		profile = Get(o.profileKey)
+	}
+
+	var files []loadFile
+	for _, path := range paths {
+		candidates := []loadFile{{path: path, optional: o.optional[path]}}
+		if profile != "" {
+			for _, p := range profilePaths(path, profile) {
+				candidates = append(candidates, loadFile{path: p, optional: true})
+			}
+		}
+
+		for _, c := range candidates {
+			matches, err := globPaths(c.path)
+			if err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 {
+				if c.optional {
+					continue
+				}
+				return nil, &fs.PathError{Op: "glob", Path: c.path, Err: fs.ErrNotExist}
+			}
+			for _, m := range matches {
+				files = append(files, loadFile{path: m, optional: c.optional})
+			}
+		}
+	}
+	return files, nil
+}
+
+// profilePaths returns the profile-specific variants of path.
+func profilePaths(path, profile string) []string {
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	if ext == "" || ext == base {
+		// dotfiles such as .env take the profile as a suffix
+		return []string{path + "." + profile, path + "." + profile + ".local"}
+	}
+	stem := strings.TrimSuffix(path, ext)
+	return []string{stem + "." + profile + ext, stem + "." + profile + ".local" + ext}
+}
+
+// globPaths expands path if it is a glob pattern, returning matches in lexical order.
+// Paths without pattern characters, and files whose name happens to contain
+// them, are returned as is.
+func globPaths(path string) ([]string, error) {
+	if !strings.ContainsAny(path, "*?[") {
+		return []string{path}, nil
+	}
+	if _, err := os.Stat(path); err == nil {
+		return []string{path}, nil
+	}
+	matches, err := filepath.Glob(path)
+	if err != nil {
+		return nil, fmt.Errorf("glob %q: %w", path, err)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
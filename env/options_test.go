@@ -0,0 +1,186 @@
+package lxenv_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hgapdvn/lx/env"
+)
+
+// writeTestFiles writes files into a fresh temporary directory and returns it.
+func writeTestFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+			t.Fatalf("failed to create temp dir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write temp file: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestLoader_WithOverrideDisabled(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_OPT_NEW"})
+	t.Setenv("TEST_OPT_EXISTING", "from-env")
+
+	p := writeTestFile(t, "app.env", "TEST_OPT_EXISTING=from-file\nTEST_OPT_NEW=from-file\n")
+	if err := lxenv.NewLoader(lxenv.WithOverride(false)).LoadEnv(p); err != nil {
+		t.Fatalf("LoadEnv() unexpected error: %v", err)
+	}
+
+	if got := os.Getenv("TEST_OPT_EXISTING"); got != "from-env" {
+		t.Errorf("env[%q] = %q, want %q", "TEST_OPT_EXISTING", got, "from-env")
+	}
+	if got := os.Getenv("TEST_OPT_NEW"); got != "from-file" {
+		t.Errorf("env[%q] = %q, want %q", "TEST_OPT_NEW", got, "from-file")
+	}
+
+	// the default loader overrides
+	if err := lxenv.LoadEnv(p); err != nil {
+		t.Fatalf("LoadEnv() unexpected error: %v", err)
+	}
+	if got := os.Getenv("TEST_OPT_EXISTING"); got != "from-file" {
+		t.Errorf("env[%q] = %q, want %q", "TEST_OPT_EXISTING", got, "from-file")
+	}
+}
+
+func TestLoader_WithOverrideDisabled_Expansion(t *testing.T) {
+	t.Setenv("TEST_OPT_HOST", "from-env")
+
+	base := writeTestFile(t, "base.env", "TEST_OPT_HOST=from-base\n")
+	app := writeTestFile(t, "app.env", "TEST_OPT_HOST=from-file\nTEST_OPT_URL=http://${TEST_OPT_HOST}\n")
+	cfg, err := lxenv.NewLoader(lxenv.WithOverride(false)).ReadEnv(base, app)
+	if err != nil {
+		t.Fatalf("ReadEnv() unexpected error: %v", err)
+	}
+
+	// the reference sees the value that wins the merge
+	if got, want := cfg.Get("TEST_OPT_URL"), "http://"+cfg.Get("TEST_OPT_HOST"); got != want || got != "http://from-env" {
+		t.Errorf("cfg.Get(%q) = %q, want %q", "TEST_OPT_URL", got, "http://from-env")
+	}
+}
+
+func TestLoader_WithOptional(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{".env": "A=1\n"})
+	base := filepath.Join(dir, ".env")
+	local := filepath.Join(dir, ".env.local")
+
+	cfg, err := lxenv.NewLoader(lxenv.WithOptional(local)).ReadEnv(base, local)
+	if err != nil {
+		t.Fatalf("ReadEnv() unexpected error: %v", err)
+	}
+	if got := cfg.Get("A"); got != "1" {
+		t.Errorf("cfg.Get(%q) = %q, want %q", "A", got, "1")
+	}
+
+	_, err = lxenv.ReadEnv(base, local)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadEnv() without WithOptional error = %v, want fs.ErrNotExist", err)
+	}
+
+	// an optional file that exists but is malformed still fails
+	bad := writeTestFile(t, "bad.env", "NOEQUALS\n")
+	if _, err := lxenv.NewLoader(lxenv.WithOptional(bad)).ReadEnv(bad); err == nil {
+		t.Error("ReadEnv() expected parse error for optional file, got nil")
+	}
+}
+
+func TestLoader_Glob(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"config/a.properties": "name=a\nonly.a=1\n",
+		"config/b.properties": "name=b\n",
+		"config/ignored.txt":  "name=txt\n",
+	})
+
+	cfg, err := lxenv.ReadProperties(filepath.Join(dir, "config", "*.properties"))
+	if err != nil {
+		t.Fatalf("ReadProperties() unexpected error: %v", err)
+	}
+	if got := cfg.Get("name"); got != "b" {
+		t.Errorf("cfg.Get(%q) = %q, want %q (matches load in lexical order)", "name", got, "b")
+	}
+	if got := cfg.Get("only.a"); got != "1" {
+		t.Errorf("cfg.Get(%q) = %q, want %q", "only.a", got, "1")
+	}
+
+	pattern := filepath.Join(dir, "missing", "*.properties")
+	if _, err := lxenv.ReadProperties(pattern); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadProperties() with no matches error = %v, want fs.ErrNotExist", err)
+	}
+	if _, err := lxenv.NewLoader(lxenv.WithOptional(pattern)).ReadProperties(pattern); err != nil {
+		t.Errorf("ReadProperties() with optional pattern unexpected error: %v", err)
+	}
+	if _, err := lxenv.ReadProperties(filepath.Join(dir, "[")); err == nil {
+		t.Error("ReadProperties() expected error for malformed pattern, got nil")
+	}
+}
+
+func TestLoader_WithProfile(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		".env":                  "A=base\nB=base\nC=base\n",
+		".env.production":       "B=production\nC=production\n",
+		".env.production.local": "C=local\n",
+		"config.yml":            "db:\n  host: base\n",
+		"config.production.yml": "db:\n  host: production\n",
+	})
+	env := filepath.Join(dir, ".env")
+	yml := filepath.Join(dir, "config.yml")
+
+	cfg, err := lxenv.NewLoader(lxenv.WithProfile("production")).ReadEnv(env)
+	if err != nil {
+		t.Fatalf("ReadEnv() unexpected error: %v", err)
+	}
+	for key, want := range map[string]string{"A": "base", "B": "production", "C": "local"} {
+		if got := cfg.Get(key); got != want {
+			t.Errorf("cfg.Get(%q) = %q, want %q", key, got, want)
+		}
+	}
+
+	cfg, err = lxenv.NewLoader(lxenv.WithProfile("production")).ReadYML(yml)
+	if err != nil {
+		t.Fatalf("ReadYML() unexpected error: %v", err)
+	}
+	if got := cfg.Get("db.host"); got != "production" {
+		t.Errorf("cfg.Get(%q) = %q, want %q", "db.host", got, "production")
+	}
+
+	// profile files are optional, the base file is not
+	if _, err := lxenv.NewLoader(lxenv.WithProfile("staging")).ReadEnv(env); err != nil {
+		t.Errorf("ReadEnv() with missing profile files unexpected error: %v", err)
+	}
+	if _, err := lxenv.NewLoader(lxenv.WithProfile("staging")).ReadEnv(filepath.Join(dir, "missing.env")); err == nil {
+		t.Error("ReadEnv() expected error for missing base file, got nil")
+	}
+}
+
+func TestLoader_WithProfileEnv(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		".env":         "MODE=base\n",
+		".env.staging": "MODE=staging\n",
+	})
+	loader := lxenv.NewLoader(lxenv.WithProfileEnv("TEST_OPT_APP_ENV"))
+
+	cfg, err := loader.ReadEnv(filepath.Join(dir, ".env"))
+	if err != nil {
+		t.Fatalf("ReadEnv() unexpected error: %v", err)
+	}
+	if got := cfg.Get("MODE"); got != "base" {
+		t.Errorf("without profile cfg.Get(%q) = %q, want %q", "MODE", got, "base")
+	}
+
+	t.Setenv("TEST_OPT_APP_ENV", "staging")
+	cfg, err = loader.ReadEnv(filepath.Join(dir, ".env"))
+	if err != nil {
+		t.Fatalf("ReadEnv() unexpected error: %v", err)
+	}
+	if got := cfg.Get("MODE"); got != "staging" {
+		t.Errorf("with profile cfg.Get(%q) = %q, want %q", "MODE", got, "staging")
+	}
+}
@@ -0,0 +1,237 @@
+package lxenv
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period is a calendar period of years, months and days. Unlike a
+// time.Duration it is applied with time.Time.AddDate, so a month is one
+// calendar month whatever its length in days.
+type Period struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// AddTo returns t shifted by the period, as t.AddDate(p.Years, p.Months, p.Days).
+func (p Period) AddTo(t time.Time) time.Time {
+	return t.AddDate(p.Years, p.Months, p.Days)
+}
+
+// IsZero reports whether every component of the period is zero.
+func (p Period) IsZero() bool {
+	return p == Period{}
+}
+
+// String returns the period in ISO-8601 form, e.g. "P1Y2M10D", "-P3M" or "P0D".
+// Components with mixed signs are written with their own sign, e.g. "P1M-1D".
+func (p Period) String() string {
+	if p.IsZero() {
+		return "P0D"
+	}
+	sign := ""
+	if p.Years <= 0 && p.Months <= 0 && p.Days <= 0 {
+		sign, p = "-", Period{-p.Years, -p.Months, -p.Days}
+	}
+	var b strings.Builder
+	b.WriteString(sign + "P")
+	for _, c := range []struct {
+		value      int
+		designator byte
+	}{{p.Years, 'Y'}, {p.Months, 'M'}, {p.Days, 'D'}} {
+		if c.value != 0 {
+			b.WriteString(strconv.Itoa(c.value))
+			b.WriteByte(c.designator)
+		}
+	}
+	return b.String()
+}
+
+// MarshalText implements encoding.TextMarshaler using the ISO-8601 form.
+func (p Period) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting every form
+// GetPeriod does. It lets GetAs and Bind decode Period values.
+func (p *Period) UnmarshalText(text []byte) error {
+	parsed, err := parsePeriod(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// GetPeriod retrieves an environment variable as a calendar period.
+// Accepts ISO-8601 periods such as P1Y6M or P2W, and units y (years),
+// mo (months), w (weeks of 7 days) and d (days) such as "1y 6mo". Components
+// must be whole numbers; a leading minus negates the period.
+// Returns (Period{}, false) if the variable is not set or cannot be parsed.
+//
+// Example:
+//
+//	// RETENTION=P1M
+//	if retention, ok := lxenv.GetPeriod("RETENTION"); ok {
+//	    cutoff := retention.AddTo(time.Now()) // same day next month
+//	}
+func GetPeriod(key string) (Period, bool) {
+	return periodValue(Get(key))
+}
+
+// GetPeriodOr retrieves an environment variable as a calendar period, or
+// returns defaultValue if it is not set or invalid.
+//
+// Example:
+//
+//	retention := lxenv.GetPeriodOr("RETENTION", lxenv.Period{Months: 1})
+func GetPeriodOr(key string, defaultValue Period) Period {
+	if value, ok := GetPeriod(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// MustGetPeriod retrieves an environment variable as a calendar period.
+// Panics if the variable is not set or cannot be parsed as a period.
+//
+// Example:
+//
+//	retention := lxenv.MustGetPeriod("RETENTION")
+func MustGetPeriod(key string) Period {
+	value, ok := GetPeriod(key)
+	if !ok {
+		panic("lxenv: environment variable " + key + " is not set or not a valid period")
+	}
+	return value
+}
+
+// periodValue parses a non-empty value with parsePeriod.
+func periodValue(value string) (Period, bool) {
+	if value == "" {
+		return Period{}, false
+	}
+	parsed, err := parsePeriod(value)
+	if err != nil {
+		return Period{}, false
+	}
+	return parsed, true
+}
+
+// maxPeriodComponent bounds each component of a period so that AddDate
+// cannot overflow.
+const maxPeriodComponent = math.MaxInt32
+
+// parsePeriod parses an ISO-8601 period or a list of y, mo, w and d
+// components, optionally preceded by a sign.
+func parsePeriod(s string) (Period, error) {
+	orig := strings.TrimSpace(s)
+	if orig == "" {
+		return Period{}, errors.New("lxenv: empty period")
+	}
+	negative, remaining, err := splitSign(orig)
+	if err != nil {
+		return Period{}, fmt.Errorf("lxenv: invalid period %q", orig)
+	}
+
+	var p Period
+	if isISODuration(remaining) {
+		p, err = isoToPeriod(remaining, orig)
+	} else {
+		p, err = unitsToPeriod(remaining, orig)
+	}
+	if err != nil {
+		return Period{}, err
+	}
+	if negative {
+		p = Period{-p.Years, -p.Months, -p.Days}
+	}
+	return p, nil
+}
+
+// isoToPeriod converts the ISO-8601 duration s, without sign, to a Period.
+func isoToPeriod(s, orig string) (Period, error) {
+	iso, err := parseISODuration(s)
+	if err != nil {
+		return Period{}, fmt.Errorf("lxenv: invalid period %q: %v", orig, err)
+	}
+	if iso.hours != 0 || iso.minutes != 0 || iso.seconds != 0 {
+		return Period{}, fmt.Errorf("lxenv: period %q has a time component; use a duration", orig)
+	}
+
+	var p Period
+	for _, c := range []struct {
+		value  float64
+		target *int
+		factor int
+	}{
+		{iso.years, &p.Years, 1},
+		{iso.months, &p.Months, 1},
+		{iso.weeks, &p.Days, 7},
+		{iso.days, &p.Days, 1},
+	} {
+		if c.value != math.Trunc(c.value) || c.value > maxPeriodComponent {
+			return Period{}, fmt.Errorf("lxenv: invalid value %v in period %q", c.value, orig)
+		}
+		if !addPeriodComponent(c.target, int(c.value), c.factor) {
+			return Period{}, fmt.Errorf("lxenv: period %q is too large", orig)
+		}
+	}
+	return p, nil
+}
+
+// unitsToPeriod adds up the value-unit components of s, e.g. "1y 6mo".
+func unitsToPeriod(s, orig string) (Period, error) {
+	var p Period
+	remaining := s
+	for remaining != "" {
+		matches := durationRe.FindStringSubmatch(remaining)
+		if matches == nil {
+			return Period{}, fmt.Errorf("lxenv: invalid period component in %q", orig)
+		}
+		valStr, unit := matches[1], strings.ToLower(matches[2])
+
+		val, err := strconv.Atoi(valStr)
+		if err != nil || val > maxPeriodComponent {
+			return Period{}, fmt.Errorf("lxenv: invalid value %q in period %q", valStr, orig)
+		}
+
+		var (
+			target *int
+			factor = 1
+		)
+		switch unit {
+		case "y", "yr", "year", "years":
+			target = &p.Years
+		case "mo", "mon", "month", "months":
+			target = &p.Months
+		case "w", "wk", "week", "weeks":
+			target, factor = &p.Days, 7
+		case "d", "day", "days":
+			target = &p.Days
+		default:
+			return Period{}, fmt.Errorf("lxenv: unknown unit %q in period %q", unit, orig)
+		}
+		if !addPeriodComponent(target, val, factor) {
+			return Period{}, fmt.Errorf("lxenv: period %q is too large", orig)
+		}
+
+		remaining = strings.TrimLeft(remaining[len(matches[0]):], " \t\n\r")
+	}
+	return p, nil
+}
+
+// addPeriodComponent adds value*factor to *target, reporting false if the
+// result exceeds maxPeriodComponent.
+func addPeriodComponent(target *int, value, factor int) bool {
+	if value > (maxPeriodComponent-*target)/factor {
+		return false
+	}
+	*target += value * factor
+	return true
+}
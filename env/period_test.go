@@ -0,0 +1,95 @@
+package lxenv_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hgapdvn/lx/env"
+)
+
+func TestGetPeriod(t *testing.T) {
+	tests := []struct {
+		name   string
+		preset string
+		want   lxenv.Period
+		wantOk bool
+	}{
+		{"iso years months days", "P1Y2M10D", lxenv.Period{Years: 1, Months: 2, Days: 10}, true},
+		{"iso weeks", "P2W", lxenv.Period{Days: 14}, true},
+		{"iso lower case", "p3m", lxenv.Period{Months: 3}, true},
+		{"iso negative", "-P1M", lxenv.Period{Months: -1}, true},
+		{"units", "1y 6mo", lxenv.Period{Years: 1, Months: 6}, true},
+		{"units weeks and days", "1 week 2 days", lxenv.Period{Days: 9}, true},
+		{"units negative", "-30d", lxenv.Period{Days: -30}, true},
+		{"iso time component", "P1DT2H", lxenv.Period{}, false},
+		{"fractional", "P1.5M", lxenv.Period{}, false},
+		{"minutes are not months", "1m", lxenv.Period{}, false},
+		{"too large", "P2147483648D", lxenv.Period{}, false},
+		{"weeks overflow", "306783379w", lxenv.Period{}, false},
+		{"invalid", "soon", lxenv.Period{}, false},
+		{"empty", "", lxenv.Period{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_PERIOD", tt.preset)
+			got, ok := lxenv.GetPeriod("TEST_PERIOD")
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("GetPeriod(%q) = (%+v, %v), want (%+v, %v)", tt.preset, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGetPeriodOr(t *testing.T) {
+	t.Setenv("TEST_PERIODOR_BAD", "PT1H")
+	def := lxenv.Period{Days: 7}
+
+	if got := lxenv.GetPeriodOr("TEST_PERIODOR_BAD", def); got != def {
+		t.Errorf("GetPeriodOr() = %+v, want default", got)
+	}
+	if got := lxenv.GetPeriodOr("TEST_PERIODOR_UNSET", def); got != def {
+		t.Errorf("GetPeriodOr() = %+v, want default", got)
+	}
+}
+
+func TestMustGetPeriod(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("MustGetPeriod() of an unset key did not panic")
+		}
+	}()
+	lxenv.MustGetPeriod("TEST_MUSTGETPERIOD_UNSET")
+}
+
+func TestPeriod(t *testing.T) {
+	jan31 := time.Date(2024, time.January, 31, 12, 0, 0, 0, time.UTC)
+	if got := (lxenv.Period{Months: 1}).AddTo(jan31); !got.Equal(time.Date(2024, time.March, 2, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("AddTo() = %v, want AddDate semantics", got)
+	}
+
+	tests := []struct {
+		period lxenv.Period
+		want   string
+	}{
+		{lxenv.Period{}, "P0D"},
+		{lxenv.Period{Years: 1, Months: 2, Days: 10}, "P1Y2M10D"},
+		{lxenv.Period{Months: -3}, "-P3M"},
+		{lxenv.Period{Months: 1, Days: -1}, "P1M-1D"},
+	}
+	for _, tt := range tests {
+		if got := tt.period.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.period, got, tt.want)
+		}
+	}
+
+	t.Setenv("TEST_PERIOD_GETAS", "P1Y")
+	if got, err := lxenv.GetAs[lxenv.Period]("TEST_PERIOD_GETAS"); err != nil || got != (lxenv.Period{Years: 1}) {
+		t.Errorf("GetAs[Period]() = (%+v, %v)", got, err)
+	}
+
+	cfg := lxenv.NewConfig(map[string]string{"RETENTION": "90d"})
+	if got, ok := cfg.GetPeriod("RETENTION"); !ok || got.Days != 90 {
+		t.Errorf("Config.GetPeriod() = (%+v, %v)", got, ok)
+	}
+}
@@ -0,0 +1,231 @@
+package lxenv
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// propertiesFileParser is a struct that implements the Parser interface.
+// It reads environment variables from Java .properties files.
+type propertiesFileParser struct{}
+
+// Parse parses the .properties format the same way java.util.Properties.load(Reader) does:
+//
+//	# comment          ! comment
+//	key=value          key = value
+//	key:value          key value
+//	key\ with\ spaces=value
+//	multi=first, \
+//	      second       →  multi=first, second
+//
+// Rules:
+// - Lines whose first non-blank character is # or ! are comments
+// - The key ends at the first unescaped '=', ':' or whitespace; one '=' or ':'
+// and the whitespace around it separate the key from the value
+// - A line ending in an odd number of backslashes continues on the next line,
+// whose leading whitespace is skipped
+// - \t, \n, \r, \f and \uXXXX are decoded; any other escaped character stands for itself
+// - Quotes and # inside values are literal, and trailing whitespace is kept
+// - A key without a value maps to an empty string
+//
+// Unlike java.util.Properties, an empty key is reported as an error since it
+// cannot be set as an environment variable.
+func (pfp *propertiesFileParser) Parse(r io.Reader) (map[string]string, error) {
+	pairs, _, err := pfp.parseLines(r)
+	return pairs, err
+}
+
+// parseLines is like Parse but also reports the line of each key.
+func (pfp *propertiesFileParser) parseLines(r io.Reader) (map[string]string, map[string]int, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pairs := make(map[string]string)
+	lines := make(map[string]int)
+	for _, line := range propertiesLines(string(data)) {
+		rawKey, rawValue := splitPropertiesLine(line.text)
+
+		key, err := unescapeProperties(rawKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line.num, err)
+		}
+		if key == "" {
+			return nil, nil, fmt.Errorf("line %d: empty key", line.num)
+		}
+		value, err := unescapeProperties(rawValue)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line.num, err)
+		}
+		pairs[key] = value
+		lines[key] = line.num
+	}
+	return pairs, lines, nil
+}
+
+// propertiesLine is a logical line: continuation lines are already joined.
+type propertiesLine struct {
+	text string
+	num  int
+}
+
+// propertiesLines splits data into logical lines, dropping blank and comment
+// lines and joining continuations. \n, \r and \r\n all terminate a line.
+func propertiesLines(data string) []propertiesLine {
+	var (
+		lines      []propertiesLine
+		current    strings.Builder
+		start      int
+		continuing bool
+	)
+
+	num := 0
+	for len(data) > 0 {
+		num++
+		end := strings.IndexAny(data, "\r\n")
+		physical := data
+		if end < 0 {
+			data = ""
+		} else {
+			physical = data[:end]
+			if data[end] == '\r' && end+1 < len(data) && data[end+1] == '\n' {
+				end++
+			}
+			data = data[end+1:]
+		}
+
+		physical = strings.TrimLeft(physical, " \t\f")
+		if !continuing {
+			if physical == "" || physical[0] == '#' || physical[0] == '!' {
+				continue
+			}
+			start = num
+		}
+
+		// an odd number of trailing backslashes escapes the line break
+		backslashes := len(physical) - len(strings.TrimRight(physical, `\`))
+		continuing = backslashes%2 == 1
+		if continuing {
+			physical = physical[:len(physical)-1]
+		}
+		current.WriteString(physical)
+
+		if !continuing {
+			lines = append(lines, propertiesLine{text: current.String(), num: start})
+			current.Reset()
+		}
+	}
+	if continuing {
+		lines = append(lines, propertiesLine{text: current.String(), num: start})
+	}
+	return lines
+}
+
+// splitPropertiesLine splits a logical line into its raw (still escaped) key and value.
+func splitPropertiesLine(line string) (key, value string) {
+	keyEnd, valueStart := len(line), len(line)
+	hasSeparator, escaped := false, false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if !escaped {
+			if c == '=' || c == ':' {
+				keyEnd, valueStart, hasSeparator = i, i+1, true
+				break
+			}
+			if isPropertiesSpace(c) {
+				keyEnd, valueStart = i, i+1
+				break
+			}
+		}
+		escaped = c == '\\' && !escaped
+	}
+
+	for valueStart < len(line) {
+		c := line[valueStart]
+		if !isPropertiesSpace(c) {
+			if hasSeparator || (c != '=' && c != ':') {
+				break
+			}
+			hasSeparator = true
+		}
+		valueStart++
+	}
+	return line[:keyEnd], line[valueStart:]
+}
+
+// unescapeProperties decodes the escape sequences of a .properties key or value.
+func unescapeProperties(s string) (string, error) {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 == len(s) {
+			b.WriteByte(c)
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			r, ok := decodeUnicodeEscape(s[i+1:])
+			if !ok {
+				return "", fmt.Errorf("malformed \\uxxxx encoding in %q", s)
+			}
+			i += 4
+			// a surrogate pair is written as two consecutive escapes
+			if utf16.IsSurrogate(r) && strings.HasPrefix(s[i+1:], `\u`) {
+				if low, ok := decodeUnicodeEscape(s[i+3:]); ok {
+					if pair := utf16.DecodeRune(r, low); pair != unicode.ReplacementChar {
+						r = pair
+						i += 6
+					}
+				}
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// decodeUnicodeEscape decodes the four hex digits at the start of s.
+func decodeUnicodeEscape(s string) (rune, bool) {
+	if len(s) < 4 {
+		return 0, false
+	}
+	var r rune
+	for i := 0; i < 4; i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			r = r<<4 | rune(c-'0')
+		case c >= 'a' && c <= 'f':
+			r = r<<4 | rune(c-'a'+10)
+		case c >= 'A' && c <= 'F':
+			r = r<<4 | rune(c-'A'+10)
+		default:
+			return 0, false
+		}
+	}
+	return r, true
+}
+
+func isPropertiesSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\f'
+}
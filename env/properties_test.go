@@ -0,0 +1,168 @@
+package lxenv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestPropertiesFileParser_JavaConformance checks the parser against the
+// output of java.util.Properties.load(Reader) for the same input.
+func TestPropertiesFileParser_JavaConformance(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]string
+	}{
+		{
+			name:  "separators",
+			input: "a=1\nb:2\nc 3\nd = 4\ne : 5\nf\t6\ng   =   7\n",
+			want:  map[string]string{"a": "1", "b": "2", "c": "3", "d": "4", "e": "5", "f": "6", "g": "7"},
+		},
+		{
+			name:  "only the first separator counts",
+			input: "a=b=c\nd:e:f\ng = = h\ni : =j\nk l m\n",
+			want:  map[string]string{"a": "b=c", "d": "e:f", "g": "= h", "i": "=j", "k": "l m"},
+		},
+		{
+			name:  "comments",
+			input: "# hash\n! bang\n   # indented\n\t! tab indented\na=1\n",
+			want:  map[string]string{"a": "1"},
+		},
+		{
+			name:  "comment markers inside values are literal",
+			input: "a=value # not a comment\nb=x!y\n",
+			want:  map[string]string{"a": "value # not a comment", "b": "x!y"},
+		},
+		{
+			name:  "quotes are literal",
+			input: "a=\"quoted\"\nb='single'\n",
+			want:  map[string]string{"a": `"quoted"`, "b": "'single'"},
+		},
+		{
+			name:  "leading whitespace stripped, trailing kept",
+			input: "   a=1   \n\t\fb = 2\t\n",
+			want:  map[string]string{"a": "1   ", "b": "2\t"},
+		},
+		{
+			name:  "key without value",
+			input: "a\nb=\nc:\nd   \n",
+			want:  map[string]string{"a": "", "b": "", "c": "", "d": ""},
+		},
+		{
+			name:  "escaped separators in keys",
+			input: "a\\=b=1\nc\\:d:2\ne\\ f 3\n\\#g=4\n\\!h=5\n",
+			want:  map[string]string{"a=b": "1", "c:d": "2", "e f": "3", "#g": "4", "!h": "5"},
+		},
+		{
+			name:  "escape sequences",
+			input: "a=tab\\there\nb=line\\nbreak\nc=cr\\rx\nd=ff\\fx\ne=\\q\\z\nf=c:\\\\dir\\\\file\n",
+			want: map[string]string{
+				"a": "tab\there", "b": "line\nbreak", "c": "cr\rx", "d": "ff\fx",
+				"e": "qz", "f": `c:\dir\file`,
+			},
+		},
+		{
+			name:  "escaped leading whitespace in value",
+			input: "a=\\  padded\n",
+			want:  map[string]string{"a": "  padded"},
+		},
+		{
+			name:  "unicode escapes",
+			input: "a=\\u0041\\u00e9\\u4E2D\nb=\\uD83D\\uDE00\n\\u006Bey=v\n",
+			want:  map[string]string{"a": "Aé中", "b": "😀", "key": "v"},
+		},
+		{
+			name:  "utf-8 input",
+			input: "grüße=héllo wörld\n",
+			want:  map[string]string{"grüße": "héllo wörld"},
+		},
+		{
+			name:  "line continuation",
+			input: "fruits = apple, banana, pear, \\\n         cantaloupe, watermelon, \\\n         kiwi, mango\nnext=1\n",
+			want:  map[string]string{"fruits": "apple, banana, pear, cantaloupe, watermelon, kiwi, mango", "next": "1"},
+		},
+		{
+			name:  "continuation in key",
+			input: "long\\\n  key=v\n",
+			want:  map[string]string{"longkey": "v"},
+		},
+		{
+			name:  "even backslashes do not continue",
+			input: "a=x\\\\\nb=y\n",
+			want:  map[string]string{"a": `x\`, "b": "y"},
+		},
+		{
+			name:  "continued line may start with comment marker",
+			input: "a=1\\\n  #2\n",
+			want:  map[string]string{"a": "1#2"},
+		},
+		{
+			name:  "comment lines do not continue",
+			input: "# comment \\\na=1\n",
+			want:  map[string]string{"a": "1"},
+		},
+		{
+			name:  "blank line ends continuation",
+			input: "a=1\\\n   \nb=2\n",
+			want:  map[string]string{"a": "1", "b": "2"},
+		},
+		{
+			name:  "continuation at end of input",
+			input: "a=1\\",
+			want:  map[string]string{"a": "1"},
+		},
+		{
+			name:  "line terminators",
+			input: "a=1\r\nb=2\rc=3\nd=4",
+			want:  map[string]string{"a": "1", "b": "2", "c": "3", "d": "4"},
+		},
+		{
+			name:  "duplicate keys keep the last value",
+			input: "a=1\na=2\n",
+			want:  map[string]string{"a": "2"},
+		},
+		{
+			name:  "empty input",
+			input: "\n\n# nothing\n",
+			want:  map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := defaultPropertiesParser.Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPropertiesFileParser_ParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"malformed unicode escape", "a=1\nb=\\u00g1\n", "line 2: malformed \\uxxxx encoding"},
+		{"truncated unicode escape", "a=\\u12", "line 1: malformed \\uxxxx encoding"},
+		{"malformed escape on continued line", "a=1\\\n  \\uZZZZ\nb=2\n", "line 1: malformed"},
+		{"empty key", "a=1\n=value\n", "line 2: empty key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := defaultPropertiesParser.Parse(strings.NewReader(tt.input))
+			if err == nil {
+				t.Fatalf("Parse(%q) expected error, got nil", tt.input)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse(%q) error = %q, want it to contain %q", tt.input, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package lxenv_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hgapdvn/lx/env"
+)
+
+func TestOrigin_FileLineAndFormat(t *testing.T) {
+	keys := []string{"TEST_ORIG_ENV", "test_orig.yaml.port", "test_orig.toml", "test_orig.json.list.1", "test_orig.props", "test_orig.ini.key"}
+	cleanupKeys(t, keys)
+
+	dir := writeTestFiles(t, map[string]string{
+		"a.env":        "# comment\n\nTEST_ORIG_ENV=1\n",
+		"b.yml":        "test_orig:\n  yaml:\n    port: 8080\n",
+		"c.toml":       "[other]\nx = 1\n\n[test_orig]\ntoml = true\n",
+		"d.json":       "{\n  \"test_orig\": {\n    \"json\": {\n      \"list\": [\n        1,\n        2\n      ]\n    }\n  }\n}\n",
+		"e.properties": "# comment\ntest_orig.props = \\\n  continued\n",
+		"f.ini":        "[test_orig.ini]\n; comment\nkey = value\n",
+	})
+	files := []string{"a.env", "b.yml", "c.toml", "d.json", "e.properties", "f.ini"}
+	for i, f := range files {
+		files[i] = filepath.Join(dir, f)
+	}
+	if err := lxenv.Load(files...); err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		key    string
+		file   string
+		line   int
+		format string
+	}{
+		{"TEST_ORIG_ENV", "a.env", 3, "env"},
+		{"test_orig.yaml.port", "b.yml", 3, "yaml"},
+		{"test_orig.toml", "c.toml", 5, "toml"},
+		{"test_orig.json.list.1", "d.json", 6, "json"},
+		{"test_orig.props", "e.properties", 2, "properties"},
+		{"test_orig.ini.key", "f.ini", 3, "ini"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			p, ok := lxenv.Origin(tt.key)
+			if !ok {
+				t.Fatalf("Origin(%q) not found", tt.key)
+			}
+			want := filepath.Join(dir, tt.file)
+			if p.Key != tt.key || p.File != want || p.Line != tt.line || p.Format != tt.format {
+				t.Errorf("Origin(%q) = %s:%d (%s), want %s:%d (%s)", tt.key, p.File, p.Line, p.Format, want, tt.line, tt.format)
+			}
+			if len(p.Overridden) != 0 {
+				t.Errorf("Origin(%q).Overridden = %v, want none", tt.key, p.Overridden)
+			}
+		})
+	}
+}
+
+func TestOrigin_OverrideChain(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_ORIG_HOST"})
+	t.Setenv("TEST_ORIG_HOST", "from-env")
+
+	dir := writeTestFiles(t, map[string]string{
+		"base.yml":   "TEST_ORIG_HOST: base\n",
+		"local.env":  "\nTEST_ORIG_HOST=local\n",
+		"extra.json": `{"TEST_ORIG_HOST": "extra"}`,
+	})
+	base, local, extra := filepath.Join(dir, "base.yml"), filepath.Join(dir, "local.env"), filepath.Join(dir, "extra.json")
+
+	if err := lxenv.Load(base, local); err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if err := lxenv.Load(extra); err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	p, ok := lxenv.Origin("TEST_ORIG_HOST")
+	if !ok {
+		t.Fatal("Origin() not found")
+	}
+	if p.Source.String() != extra+":1" || p.Value != "extra" {
+		t.Errorf("Origin() source = %s (%q), want %s:1", p.Source, p.Value, extra)
+	}
+
+	want := []string{local + ":2", base + ":1", "environment"}
+	if len(p.Overridden) != len(want) {
+		t.Fatalf("Origin().Overridden = %v, want %v", p.Overridden, want)
+	}
+	for i, s := range p.Overridden {
+		if s.String() != want[i] {
+			t.Errorf("Overridden[%d] = %s, want %s", i, s, want[i])
+		}
+	}
+	if p.Overridden[2].Value != "from-env" {
+		t.Errorf("environment value = %q, want %q", p.Overridden[2].Value, "from-env")
+	}
+}
+
+func TestOrigin_ReloadDoesNotGrowChain(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_ORIG_RELOAD"})
+	t.Setenv("TEST_ORIG_RELOAD", "from-env")
+
+	base := writeTestFile(t, "base.env", "TEST_ORIG_RELOAD=base\n")
+	local := writeTestFile(t, "local.env", "TEST_ORIG_RELOAD=local\n")
+	for i := 0; i < 3; i++ {
+		if err := lxenv.Load(base, local); err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+	}
+
+	p, ok := lxenv.Origin("TEST_ORIG_RELOAD")
+	if !ok {
+		t.Fatal("Origin() not found")
+	}
+	want := []string{base + ":1", "environment"}
+	if len(p.Overridden) != len(want) {
+		t.Fatalf("Origin().Overridden = %v, want %v", p.Overridden, want)
+	}
+	for i, s := range p.Overridden {
+		if s.String() != want[i] {
+			t.Errorf("Overridden[%d] = %s, want %s", i, s, want[i])
+		}
+	}
+}
+
+func TestResetOrigins(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_ORIG_RESET"})
+
+	p := writeTestFile(t, "a.env", "TEST_ORIG_RESET=file\n")
+	if err := lxenv.Load(p); err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	lxenv.ResetOrigins()
+
+	got, ok := lxenv.Origin("TEST_ORIG_RESET")
+	if !ok {
+		t.Fatal("Origin() not found")
+	}
+	if got.Format != "environment" || len(got.Overridden) != 0 {
+		t.Errorf("Origin() after ResetOrigins() = %+v, want environment source only", got)
+	}
+}
+
+func TestOrigin_ChangedAfterLoad(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_ORIG_CHANGED"})
+
+	p := writeTestFile(t, "a.env", "TEST_ORIG_CHANGED=file\n")
+	if err := lxenv.Load(p); err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if err := lxenv.Set("TEST_ORIG_CHANGED", "manual"); err != nil {
+		t.Fatalf("Set() unexpected error: %v", err)
+	}
+
+	got, ok := lxenv.Origin("TEST_ORIG_CHANGED")
+	if !ok {
+		t.Fatal("Origin() not found")
+	}
+	if got.Format != "environment" || got.Value != "manual" || got.File != "" {
+		t.Errorf("Origin() = %+v, want environment source", got.Source)
+	}
+	if len(got.Overridden) != 1 || got.Overridden[0].File != p {
+		t.Errorf("Origin().Overridden = %v, want [%s:1]", got.Overridden, p)
+	}
+
+	lxenv.Unset("TEST_ORIG_CHANGED")
+	if _, ok := lxenv.Origin("TEST_ORIG_CHANGED"); ok {
+		t.Error("Origin() of an unset key should report false")
+	}
+}
+
+func TestOrigin_WithoutOverride(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_ORIG_KEEP"})
+	t.Setenv("TEST_ORIG_KEEP", "real")
+
+	p := writeTestFile(t, "a.env", "TEST_ORIG_KEEP=file\n")
+	cfg, err := lxenv.NewLoader(lxenv.WithOverride(false)).ReadEnv(p)
+	if err != nil {
+		t.Fatalf("ReadEnv() unexpected error: %v", err)
+	}
+
+	got, ok := cfg.Origin("TEST_ORIG_KEEP")
+	if !ok {
+		t.Fatal("Config.Origin() not found")
+	}
+	if got.Format != "environment" || got.Value != "real" {
+		t.Errorf("Config.Origin() = %+v, want environment source", got.Source)
+	}
+	if len(got.Overridden) != 1 || got.Overridden[0].String() != p+":1" {
+		t.Errorf("Config.Origin().Overridden = %v, want [%s:1]", got.Overridden, p)
+	}
+}
+
+func TestDump_MasksSecrets(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_DUMP_HOST", "TEST_DUMP_PASSWORD", "TEST_DUMP_API_TOKEN"})
+
+	p := writeTestFile(t, "a.env", "TEST_DUMP_HOST=db.local\nTEST_DUMP_PASSWORD=hunter2\nTEST_DUMP_API_TOKEN=abc123\n")
+	if err := lxenv.Load(p); err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := lxenv.Dump(&buf); err != nil {
+		t.Fatalf("Dump() unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "KEY") {
+		t.Errorf("Dump() should start with a header, got:\n%s", out)
+	}
+	for _, want := range []string{"db.local", p + ":1", "TEST_DUMP_PASSWORD", "******"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Dump() output missing %q:\n%s", want, out)
+		}
+	}
+	for _, secret := range []string{"hunter2", "abc123"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("Dump() leaked secret %q:\n%s", secret, out)
+		}
+	}
+}
+
+func TestConfig_OriginAndDump(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"a.toml": "[db]\nhost = \"a\"\npassword = \"s3cret\"\n",
+		"b.ini":  "[db]\n\nhost = b\n",
+	})
+	cfg, err := lxenv.Read(filepath.Join(dir, "a.toml"), filepath.Join(dir, "b.ini"))
+	if err != nil {
+		t.Fatalf("Read() unexpected error: %v", err)
+	}
+
+	p, ok := cfg.Origin("db.host")
+	if !ok || p.Format != "ini" || p.Line != 3 {
+		t.Errorf("Config.Origin() = %+v, want b.ini line 3", p)
+	}
+	if len(p.Overridden) != 1 || p.Overridden[0].Format != "toml" || p.Overridden[0].Line != 2 {
+		t.Errorf("Config.Origin().Overridden = %+v, want a.toml line 2", p.Overridden)
+	}
+	if _, ok := cfg.Origin("missing"); ok {
+		t.Error("Config.Origin() of a missing key should report false")
+	}
+
+	var buf bytes.Buffer
+	if err := cfg.Dump(&buf); err != nil {
+		t.Fatalf("Config.Dump() unexpected error: %v", err)
+	}
+	if out := buf.String(); strings.Contains(out, "s3cret") || !strings.Contains(out, "db.password") {
+		t.Errorf("Config.Dump() should list db.password masked:\n%s", out)
+	}
+
+	plain, _ := lxenv.NewConfig(map[string]string{"A": "1"}).Origin("A")
+	if plain.Format != "environment" {
+		t.Errorf("NewConfig Origin().Format = %q, want %q", plain.Format, "environment")
+	}
+}
@@ -0,0 +1,165 @@
+package lxenv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("lxenv: unsupported config file format")
+)
+
+// Parser parses the content of a configuration file into flat key/value pairs.
+// Implementations are registered per file extension with RegisterParser.
+type Parser interface {
+	Parse(r io.Reader) (map[string]string, error)
+}
+
+// ParserFunc adapts an ordinary function to the Parser interface.
+//
+// Example:
+//
+//	lxenv.RegisterParser(".conf", lxenv.ParserFunc(func(r io.Reader) (map[string]string, error) {
+//	    return parseConf(r)
+//	}))
+type ParserFunc func(r io.Reader) (map[string]string, error)
+
+// Parse calls f(r).
+func (f ParserFunc) Parse(r io.Reader) (map[string]string, error) {
+	return f(r)
+}
+
+// ValueNormalizer cleans up a raw value read by the .env parser,
+// e.g. by stripping inline comments and surrounding quotes.
+type ValueNormalizer interface {
+	Normalize(value string) string
+}
+
+// ValueNormalizerFunc adapts an ordinary function to the ValueNormalizer interface.
+type ValueNormalizerFunc func(value string) string
+
+// Normalize calls f(value).
+func (f ValueNormalizerFunc) Normalize(value string) string {
+	return f(value)
+}
+
+// NewEnvParser creates a .env parser that cleans up values with normalizer.
+// A nil normalizer uses the default one, which strips inline # comments,
+// surrounding whitespace and surrounding quotes.
+//
+// Example:
+//
+//	// keep values exactly as written
+//	raw := lxenv.NewEnvParser(lxenv.ValueNormalizerFunc(func(v string) string { return v }))
+//	lxenv.RegisterParser(".env", raw)
+func NewEnvParser(normalizer ValueNormalizer) Parser {
+	if normalizer == nil {
+		normalizer = defaultValueNormalizer
+	}
+	return &envFileParser{valueNormalizer: normalizer}
+}
+
+var (
+	parsersMu sync.RWMutex
+	parsers   = map[string]Parser{
+		".env":        defaultEnvFileParser,
+		".properties": defaultPropertiesParser,
+		".yml":        defaultYAMLParser,
+		".yaml":       defaultYAMLParser,
+		".toml":       defaultTOMLParser,
+		".json":       defaultJSONParser,
+		".ini":        defaultINIParser,
+	}
+)
+
+// RegisterParser makes parser available to Load for files with extension ext.
+// The extension is matched case-insensitively, with or without a leading dot.
+// Registering an extension that already has a parser replaces it.
+// Panics if ext is empty or parser is nil.
+//
+// Example:
+//
+//	lxenv.RegisterParser(".hcl", hclParser)
+//	err := lxenv.Load("base.yml", "service.hcl")
+func RegisterParser(ext string, parser Parser) {
+	ext = normalizeExt(ext)
+	if ext == "." {
+		panic("lxenv: RegisterParser called with an empty extension")
+	}
+	if parser == nil {
+		panic("lxenv: RegisterParser called with a nil parser for " + ext)
+	}
+
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+	parsers[ext] = parser
+}
+
+// lookupParser returns the parser registered for the extension of path and
+// the extension it was registered for.
+// Extensions are tried from the right, so ".env.local" and "app.env.production"
+// fall back to the ".env" parser when ".local" and ".production" are not registered.
+func lookupParser(path string) (Parser, string, error) {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+
+	name := filepath.Base(path)
+	for {
+		ext := filepath.Ext(name)
+		if ext == "" {
+			break
+		}
+		if parser, ok := parsers[normalizeExt(ext)]; ok {
+			return parser, normalizeExt(ext), nil
+		}
+		name = strings.TrimSuffix(name, ext)
+	}
+	return nil, "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, path)
+}
+
+func normalizeExt(ext string) string {
+	return "." + strings.ToLower(strings.TrimPrefix(ext, "."))
+}
+
+// Load reads one or more configuration files and sets environment variables from them.
+// The format of each file is chosen by its extension (.env, .properties, .yml,
+// .yaml, .toml, .json, .ini or any extension added with RegisterParser), so
+// formats can be mixed freely. Files are loaded in order — later files
+// override earlier ones, whatever their format.
+//
+// Returns ErrUnsupportedFormat if no parser is registered for a file.
+//
+// Example:
+//
+//	lxenv.Load("config.yml", "secrets.properties", ".env.local")
+func Load(paths ...string) error {
+	return defaultLoader.Load(paths...)
+}
+
+// Read reads one or more configuration files into a Config without calling os.Setenv.
+// Formats are chosen by extension as described in Load.
+//
+// Example:
+//
+//	cfg, err := lxenv.Read("config.toml", ".env")
+func Read(paths ...string) (*Config, error) {
+	return defaultLoader.Read(paths...)
+}
+
+// Load reads one or more configuration files, choosing the format of each by
+// its extension as described in Load, and sets environment variables from them.
+// Files are loaded in order — later files override earlier ones.
+func (l *Loader) Load(paths ...string) error {
+	return l.envLoader(nil).load(paths...)
+}
+
+// Read reads one or more configuration files into a Config without modifying
+// the process environment, choosing the format of each by its extension.
+// Files are merged in order — later files override earlier ones.
+func (l *Loader) Read(paths ...string) (*Config, error) {
+	return l.readConfig(nil, paths)
+}
@@ -0,0 +1,131 @@
+package lxenv_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hgapdvn/lx/env"
+)
+
+func TestLoad_MixedFormats(t *testing.T) {
+	cleanupKeys(t, []string{"app.name", "app.port", "app.env", "app.debug", "APP_TOKEN"})
+
+	dir := writeTestFiles(t, map[string]string{
+		"config.yml":         "app:\n  name: lx\n  port: 8080\n  env: yml\n",
+		"config.properties":  "app.port=8081\napp.env=properties\n",
+		"config.TOML":        "[app]\nenv = \"toml\"\ndebug = false\n",
+		"overrides.json":     `{"app": {"debug": true}}`,
+		".env.local":         "APP_TOKEN=${app.name}-token\n",
+		"ignored.properties": "app.env=never\n",
+	})
+	paths := []string{"config.yml", "config.properties", "config.TOML", "overrides.json", ".env.local"}
+	for i, p := range paths {
+		paths[i] = filepath.Join(dir, p)
+	}
+
+	if err := lxenv.Load(paths...); err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	tests := []struct{ key, want string }{
+		{"app.name", "lx"},
+		{"app.port", "8081"},
+		{"app.env", "toml"},
+		{"app.debug", "true"},
+		{"APP_TOKEN", "lx-token"},
+	}
+	for _, tt := range tests {
+		if got := os.Getenv(tt.key); got != tt.want {
+			t.Errorf("env[%q] = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestLoad_UnsupportedFormat(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_REG_A"})
+
+	good := writeTestFile(t, "a.env", "TEST_REG_A=1\n")
+	bad := writeTestFile(t, "notes.txt", "hello\n")
+
+	err := lxenv.Load(good, bad)
+	if !errors.Is(err, lxenv.ErrUnsupportedFormat) {
+		t.Fatalf("Load() error = %v, want ErrUnsupportedFormat", err)
+	}
+	if lxenv.Has("TEST_REG_A") {
+		t.Error("no keys should be set when a file has an unsupported format")
+	}
+	if _, err := lxenv.Read(filepath.Join(t.TempDir(), "Makefile")); !errors.Is(err, lxenv.ErrUnsupportedFormat) {
+		t.Errorf("Read() error = %v, want ErrUnsupportedFormat", err)
+	}
+}
+
+func TestRegisterParser(t *testing.T) {
+	lxenv.RegisterParser("LXTEST", lxenv.ParserFunc(func(r io.Reader) (map[string]string, error) {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		pairs := make(map[string]string)
+		for _, field := range strings.Fields(string(data)) {
+			if k, v, ok := strings.Cut(field, "->"); ok {
+				pairs[k] = v
+			}
+		}
+		return pairs, nil
+	}))
+
+	p := writeTestFile(t, "custom.lxtest", "a->1 b->2")
+	cfg, err := lxenv.Read(writeTestFile(t, "base.yml", "a: 0\nc: 3\n"), p)
+	if err != nil {
+		t.Fatalf("Read() unexpected error: %v", err)
+	}
+	if want := map[string]string{"a": "1", "b": "2", "c": "3"}; !reflect.DeepEqual(cfg.Map(), want) {
+		t.Errorf("Read() = %v, want %v", cfg.Map(), want)
+	}
+}
+
+func TestRegisterParser_Panics(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"empty extension", func() { lxenv.RegisterParser("", lxenv.NewEnvParser(nil)) }},
+		{"nil parser", func() { lxenv.RegisterParser(".x", nil) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("RegisterParser() with %s did not panic", tt.name)
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
+
+func TestNewEnvParser(t *testing.T) {
+	input := "A=\"quoted\" # comment\n"
+
+	got, err := lxenv.NewEnvParser(nil).Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if got["A"] != "quoted" {
+		t.Errorf("default normalizer A = %q, want %q", got["A"], "quoted")
+	}
+
+	raw := lxenv.NewEnvParser(lxenv.ValueNormalizerFunc(func(v string) string { return v }))
+	got, err = raw.Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if want := `"quoted" # comment`; got["A"] != want {
+		t.Errorf("raw normalizer A = %q, want %q", got["A"], want)
+	}
+}
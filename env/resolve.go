@@ -0,0 +1,242 @@
+package lxenv
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	ErrUnresolvedReference = errors.New("lxenv: cannot resolve value reference")
+)
+
+// Resolver resolves value references of the form "scheme:ref" into the
+// actual value, e.g. by reading a secret from a file or a secret store.
+// Implementations are registered per scheme with RegisterResolver.
+type Resolver interface {
+	Resolve(ref string) (string, error)
+}
+
+// ResolverFunc adapts an ordinary function to the Resolver interface.
+//
+// Example:
+//
+//	lxenv.RegisterResolver("upper", lxenv.ResolverFunc(func(ref string) (string, error) {
+//	    return strings.ToUpper(ref), nil
+//	}))
+type ResolverFunc func(ref string) (string, error)
+
+// Resolve calls f(ref).
+func (f ResolverFunc) Resolve(ref string) (string, error) {
+	return f(ref)
+}
+
+var (
+	resolversMu sync.RWMutex
+	resolvers   = map[string]Resolver{
+		"file":   ResolverFunc(resolveFile),
+		"base64": ResolverFunc(resolveBase64),
+	}
+)
+
+// RegisterResolver makes resolver handle values starting with "scheme:".
+// The scheme is matched case-insensitively. Registering a scheme that already
+// has a resolver replaces it. Panics if scheme is empty or resolver is nil.
+//
+// Two schemes are registered by default:
+//
+//	file:///run/secrets/db   content of the file, without a trailing newline
+//	base64:c2VjcmV0          standard base64 decoding of the rest of the value
+//
+// Example:
+//
+//	lxenv.RegisterResolver("vault", lxenv.NewDirResolver("/mnt/vault"))
+//	// DB_PASS=vault://db/password reads /mnt/vault/db/password
+func RegisterResolver(scheme string, resolver Resolver) {
+	scheme = strings.ToLower(scheme)
+	if scheme == "" {
+		panic("lxenv: RegisterResolver called with an empty scheme")
+	}
+	if resolver == nil {
+		panic("lxenv: RegisterResolver called with a nil resolver for " + scheme)
+	}
+
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+	resolvers[scheme] = resolver
+}
+
+// resolveOnGet is 1 when the getters resolve value references, see ResolveOnGet.
+var resolveOnGet int32
+
+// ResolveOnGet controls whether Get, MustGet, GetAs, the typed getters, the
+// getters of Config, Bind, BindFlags and Validate resolve value references in
+// the values they read, as the loaders do. It is disabled by default, since
+// values loaded from files are resolved once already; enable it when values
+// such as DB_PASS=file:///run/secrets/db are set directly in the process
+// environment, e.g. by an orchestrator.
+//
+// Example:
+//
+//	lxenv.ResolveOnGet(true)
+//	pass := lxenv.Get("DB_PASS") // content of /run/secrets/db
+func ResolveOnGet(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&resolveOnGet, v)
+}
+
+// resolveForGet resolves value if ResolveOnGet is enabled, and reports whether
+// a resolver produced the result.
+func resolveForGet(value string) (string, bool, error) {
+	if atomic.LoadInt32(&resolveOnGet) == 0 || !isReference(value) {
+		return value, false, nil
+	}
+	resolved, err := Resolve(value)
+	return resolved, err == nil, err
+}
+
+// Resolve returns value with its reference resolved. A value of the form
+// "scheme:ref" or "scheme://ref" whose scheme has a registered resolver is
+// replaced by the result of that resolver; any other value is returned as is.
+//
+// Returns ErrUnresolvedReference if the resolver fails. The error names the
+// scheme but does not repeat the value, which may itself be a secret.
+//
+// Example:
+//
+//	pass, err := lxenv.Resolve("file:///run/secrets/db")
+func Resolve(value string) (string, error) {
+	scheme, ref, ok := splitReference(value)
+	if !ok {
+		return value, nil
+	}
+
+	resolversMu.RLock()
+	resolver, ok := resolvers[scheme]
+	resolversMu.RUnlock()
+	if !ok {
+		return value, nil
+	}
+
+	resolved, err := resolver.Resolve(ref)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %v", ErrUnresolvedReference, scheme, err)
+	}
+	return resolved, nil
+}
+
+// splitReference splits "scheme:ref" and "scheme://ref" into a lower-case
+// scheme and ref. It reports false if value does not start with a valid scheme.
+func splitReference(value string) (scheme, ref string, ok bool) {
+	i := strings.IndexByte(value, ':')
+	if i <= 0 {
+		return "", "", false
+	}
+	for j, c := range value[:i] {
+		isLetter := 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+		if !isLetter && (j == 0 || !('0' <= c && c <= '9' || c == '+' || c == '-' || c == '.')) {
+			return "", "", false
+		}
+	}
+	return strings.ToLower(value[:i]), strings.TrimPrefix(value[i+1:], "//"), true
+}
+
+// isReference reports whether Resolve would replace value, i.e. whether it
+// starts with a scheme that has a registered resolver.
+func isReference(value string) bool {
+	scheme, _, ok := splitReference(value)
+	if !ok {
+		return false
+	}
+	resolversMu.RLock()
+	defer resolversMu.RUnlock()
+	_, ok = resolvers[scheme]
+	return ok
+}
+
+// resolvePairs returns a copy of pairs with every value reference resolved,
+// and the keys whose value was resolved.
+func resolvePairs(pairs map[string]string) (map[string]string, map[string]bool, error) {
+	// sorted for deterministic error reporting
+	keys := make([]string, 0, len(pairs))
+	for k := range pairs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make(map[string]string, len(pairs))
+	resolved := make(map[string]bool)
+	for _, k := range keys {
+		if !isReference(pairs[k]) {
+			out[k] = pairs[k]
+			continue
+		}
+		v, err := Resolve(pairs[k])
+		if err != nil {
+			return nil, nil, fmt.Errorf("key %q: %w", k, err)
+		}
+		out[k] = v
+		resolved[k] = true
+	}
+	return out, resolved, nil
+}
+
+// NewDirResolver creates a Resolver that reads references as file names
+// relative to dir, like a secret store mounted as a directory. References may
+// contain slashes but cannot escape dir. It is also a convenient offline
+// stand-in for a real secret store in tests.
+//
+// Example:
+//
+//	lxenv.RegisterResolver("vault", lxenv.NewDirResolver("testdata/vault"))
+//	// API_KEY=vault:payments/api-key reads testdata/vault/payments/api-key
+func NewDirResolver(dir string) Resolver {
+	return ResolverFunc(func(ref string) (string, error) {
+		name := filepath.Clean(filepath.FromSlash(ref))
+		if name == "." || filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("invalid secret name %q", ref)
+		}
+		return readSecretFile(filepath.Join(dir, name))
+	})
+}
+
+// resolveFile reads the secret stored in the file at path.
+func resolveFile(path string) (string, error) {
+	if path == "" {
+		return "", errors.New("empty file path")
+	}
+	return readSecretFile(filepath.FromSlash(path))
+}
+
+// readSecretFile returns the content of the file at path without a single
+// trailing newline, which editors and secret mounts usually add.
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	s := strings.TrimSuffix(string(data), "\n")
+	return strings.TrimSuffix(s, "\r"), nil
+}
+
+// resolveBase64 decodes standard base64, with or without padding.
+func resolveBase64(ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	decoded, err := base64.StdEncoding.DecodeString(ref)
+	if err != nil {
+		if raw, rawErr := base64.RawStdEncoding.DecodeString(ref); rawErr == nil {
+			return string(raw), nil
+		}
+		return "", errors.New("invalid base64 data")
+	}
+	return string(decoded), nil
+}
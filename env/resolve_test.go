@@ -0,0 +1,223 @@
+package lxenv_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hgapdvn/lx/env"
+)
+
+func TestResolve(t *testing.T) {
+	secret := writeTestFile(t, "db", "s3cret\n")
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"file url", "file://" + filepath.ToSlash(secret), "s3cret"},
+		{"base64", "base64:aGVsbG8gd29ybGQ=", "hello world"},
+		{"base64 without padding", "BASE64:aGVsbG8", "hello"},
+		{"plain value", "plain", "plain"},
+		{"unregistered scheme", "postgres://localhost:5432/db", "postgres://localhost:5432/db"},
+		{"not a scheme", "12:30", "12:30"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lxenv.Resolve(tt.value)
+			if err != nil {
+				t.Fatalf("Resolve(%q) unexpected error: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve_Errors(t *testing.T) {
+	missing := filepath.ToSlash(filepath.Join(t.TempDir(), "missing"))
+
+	_, err := lxenv.Resolve("file://" + missing)
+	if !errors.Is(err, lxenv.ErrUnresolvedReference) {
+		t.Errorf("Resolve() error = %v, want ErrUnresolvedReference", err)
+	}
+
+	_, err = lxenv.Resolve("base64:not*base64")
+	if !errors.Is(err, lxenv.ErrUnresolvedReference) {
+		t.Errorf("Resolve() error = %v, want ErrUnresolvedReference", err)
+	}
+	if err != nil && strings.Contains(err.Error(), "not*base64") {
+		t.Errorf("Resolve() error should not repeat the value: %v", err)
+	}
+}
+
+func TestRegisterResolver_DirResolver(t *testing.T) {
+	lxenv.RegisterResolver("Vault", lxenv.NewDirResolver("testdata/vault"))
+
+	got, err := lxenv.Resolve("vault://db/password")
+	if err != nil {
+		t.Fatalf("Resolve() unexpected error: %v", err)
+	}
+	if got != "vault-pass" {
+		t.Errorf("Resolve() = %q, want %q", got, "vault-pass")
+	}
+
+	for _, ref := range []string{"vault:../resolve_test.go", "vault:/etc/passwd", "vault:"} {
+		if _, err := lxenv.Resolve(ref); !errors.Is(err, lxenv.ErrUnresolvedReference) {
+			t.Errorf("Resolve(%q) error = %v, want ErrUnresolvedReference", ref, err)
+		}
+	}
+	if _, err := lxenv.Resolve("vault:db/missing"); !errors.Is(err, lxenv.ErrUnresolvedReference) {
+		t.Errorf("Resolve() of a missing secret error = %v, want ErrUnresolvedReference", err)
+	}
+}
+
+func TestRegisterResolver_Panics(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"empty scheme", func() { lxenv.RegisterResolver("", lxenv.NewDirResolver(".")) }},
+		{"nil resolver", func() { lxenv.RegisterResolver("x", nil) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("RegisterResolver() with %s did not panic", tt.name)
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
+
+func TestLoad_ResolvesReferences(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_RES_PASS", "TEST_RES_KEY", "TEST_RES_DIR", "TEST_RES_PLAIN"})
+
+	dir := writeTestFiles(t, map[string]string{"secrets/db": "from-file\n"})
+	p := writeTestFile(t, "app.env", strings.Join([]string{
+		"TEST_RES_DIR=" + filepath.ToSlash(filepath.Join(dir, "secrets")),
+		"TEST_RES_PASS=file://${TEST_RES_DIR}/db",
+		"TEST_RES_KEY=base64:a2V5",
+		"TEST_RES_PLAIN=http://example.com",
+	}, "\n"))
+
+	if err := lxenv.Load(p); err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	tests := []struct{ key, want string }{
+		{"TEST_RES_PASS", "from-file"},
+		{"TEST_RES_KEY", "key"},
+		{"TEST_RES_PLAIN", "http://example.com"},
+	}
+	for _, tt := range tests {
+		if got, _ := lxenv.Lookup(tt.key); got != tt.want {
+			t.Errorf("env[%q] = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+
+	cfg, err := lxenv.NewLoader(lxenv.WithResolution(false)).ReadEnv(p)
+	if err != nil {
+		t.Fatalf("ReadEnv() unexpected error: %v", err)
+	}
+	if got, _ := cfg.Lookup("TEST_RES_KEY"); got != "base64:a2V5" {
+		t.Errorf("WithResolution(false) Lookup = %q, want the literal reference", got)
+	}
+}
+
+func TestLoad_UnresolvedReference(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_RES_OK", "TEST_RES_BAD"})
+
+	missing := filepath.ToSlash(filepath.Join(t.TempDir(), "missing"))
+	p := writeTestFile(t, "app.env", "TEST_RES_OK=1\nTEST_RES_BAD=file://"+missing+"\n")
+
+	err := lxenv.Load(p)
+	if !errors.Is(err, lxenv.ErrUnresolvedReference) || !strings.Contains(err.Error(), "TEST_RES_BAD") {
+		t.Fatalf("Load() error = %v, want ErrUnresolvedReference for TEST_RES_BAD", err)
+	}
+	if lxenv.Has("TEST_RES_OK") {
+		t.Error("no keys should be set when a reference cannot be resolved")
+	}
+}
+
+func TestGet_ReturnsRawValues(t *testing.T) {
+	t.Setenv("TEST_RES_REPO", "file:///srv/repo")
+	t.Setenv("TEST_RES_APP_KEY", "base64:dGVzdA==")
+
+	if got := lxenv.Get("TEST_RES_REPO"); got != "file:///srv/repo" {
+		t.Errorf("Get() = %q, want the raw value", got)
+	}
+	if got := lxenv.MustGet("TEST_RES_APP_KEY"); got != "base64:dGVzdA==" {
+		t.Errorf("MustGet() = %q, want the raw value", got)
+	}
+	if got, err := lxenv.GetAs[string]("TEST_RES_APP_KEY"); err != nil || got != "base64:dGVzdA==" {
+		t.Errorf("GetAs() = %q, %v, want the raw value", got, err)
+	}
+
+	cfg := lxenv.NewConfig(map[string]string{"A": "base64:dHJ1ZQ=="})
+	if got := cfg.Get("A"); got != "base64:dHJ1ZQ==" {
+		t.Errorf("Config.Get() = %q, want the raw value", got)
+	}
+}
+
+func TestResolveOnGet(t *testing.T) {
+	secret := writeTestFile(t, "db", "hunter2\n")
+	t.Setenv("TEST_RES_DB_PASS", "file://"+filepath.ToSlash(secret))
+	t.Setenv("TEST_RES_MISSING", "file:///nonexistent/secret")
+	lxenv.ResolveOnGet(true)
+	t.Cleanup(func() { lxenv.ResolveOnGet(false) })
+
+	if got := lxenv.Get("TEST_RES_DB_PASS"); got != "hunter2" {
+		t.Errorf("Get() = %q, want %q", got, "hunter2")
+	}
+	if got, err := lxenv.GetAs[string]("TEST_RES_DB_PASS"); err != nil || got != "hunter2" {
+		t.Errorf("GetAs() = %q, %v, want %q", got, err, "hunter2")
+	}
+	var cfg struct {
+		Pass string `env:"TEST_RES_DB_PASS"`
+	}
+	if err := lxenv.Bind(&cfg); err != nil || cfg.Pass != "hunter2" {
+		t.Errorf("Bind() = %q, %v, want %q", cfg.Pass, err, "hunter2")
+	}
+	if got := lxenv.NewConfig(map[string]string{"A": "base64:dHJ1ZQ=="}).Get("A"); got != "true" {
+		t.Errorf("Config.Get() = %q, want %q", got, "true")
+	}
+
+	if got := lxenv.Get("TEST_RES_MISSING"); got != "" {
+		t.Errorf("Get() of an unresolvable reference = %q, want empty", got)
+	}
+	if _, err := lxenv.GetAs[string]("TEST_RES_MISSING"); !errors.Is(err, lxenv.ErrUnresolvedReference) {
+		t.Errorf("GetAs() error = %v, want %v", err, lxenv.ErrUnresolvedReference)
+	}
+}
+
+func TestLoad_ResolvesOnce(t *testing.T) {
+	secret := writeTestFile(t, "secret.txt", "s3cret\n")
+	ref := "file://" + filepath.ToSlash(secret)
+	p := writeTestFile(t, "app.env", strings.Join([]string{
+		"LITERAL=" + ref,
+		"NESTED=base64:" + base64.StdEncoding.EncodeToString([]byte(ref)),
+	}, "\n"))
+
+	cfg, err := lxenv.NewLoader(lxenv.WithResolution(false)).ReadEnv(p)
+	if err != nil {
+		t.Fatalf("ReadEnv() unexpected error: %v", err)
+	}
+	if got := cfg.Get("LITERAL"); got != ref {
+		t.Errorf("WithResolution(false) Get() = %q, want %q", got, ref)
+	}
+
+	cfg, err = lxenv.ReadEnv(p)
+	if err != nil {
+		t.Fatalf("ReadEnv() unexpected error: %v", err)
+	}
+	if got := cfg.Get("NESTED"); got != ref {
+		t.Errorf("Get() of a base64 encoded reference = %q, want %q", got, ref)
+	}
+}
@@ -0,0 +1,678 @@
+package lxenv
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// tomlFileParser is a struct that implements the Parser interface.
+// It reads environment variables from .toml files.
+type tomlFileParser struct{}
+
+// Parse parses a TOML v1.0 document and flattens it using dot-notation, e.g.:
+//
+//	[database.pool]
+//	size = 10        →  database.pool.size=10
+//
+// Rules:
+// - Tables, dotted keys and inline tables become dot-notation keys
+// - Arrays and arrays of tables are flattened the same way as YAML sequences
+// - Strings are decoded, integers are written in decimal, floats, booleans
+// and dates keep their text
+// - Literal strings, single-line or multi-line, are never expanded
+// - Duplicate keys and redefined tables are errors
+// - Malformed input is reported with its line and column
+func (tfp *tomlFileParser) Parse(r io.Reader) (map[string]string, error) {
+	pairs, _, err := tfp.parseLines(r)
+	return pairs, err
+}
+
+// parseLines is like Parse but also reports the line of each key.
+func (tfp *tomlFileParser) parseLines(r io.Reader) (map[string]string, map[string]int, error) {
+	pairs, lines, _, err := tfp.parseLiterals(r)
+	return pairs, lines, err
+}
+
+// parseLiterals is like parseLines but also reports the keys of literal strings.
+func (tfp *tomlFileParser) parseLiterals(r io.Reader) (map[string]string, map[string]int, map[string]bool, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	p := &tomlParser{src: text, root: newTOMLTable(""), lines: make(map[string]int)}
+	if err := p.parseDocument(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	tree := p.root.tree()
+	pairs := make(map[string]string)
+	literals := make(map[string]bool)
+	flattenValue("", tree, pairs)
+	flattenLiterals("", tree, literals)
+	return pairs, p.lines, literals, nil
+}
+
+// tomlTable is a TOML table under construction.
+type tomlTable struct {
+	path     string         // dot-notation key of the table
+	values   map[string]any // string, []any, *tomlTable or *tomlTableArray
+	explicit bool           // defined by a [table] header
+	dotted   bool           // defined by a dotted key
+	inline   bool           // inline tables cannot be extended
+}
+
+// tomlTableArray is an array of tables built from [[array]] headers.
+type tomlTableArray struct {
+	tables []*tomlTable
+}
+
+func newTOMLTable(path string) *tomlTable {
+	return &tomlTable{path: path, values: make(map[string]any)}
+}
+
+// tree converts the table to the map[string]any form used by flattenValue.
+func (t *tomlTable) tree() map[string]any {
+	out := make(map[string]any, len(t.values))
+	for k, v := range t.values {
+		out[k] = tomlTree(v)
+	}
+	return out
+}
+
+func tomlTree(v any) any {
+	switch v := v.(type) {
+	case *tomlTable:
+		return v.tree()
+	case *tomlTableArray:
+		items := make([]any, len(v.tables))
+		for i, t := range v.tables {
+			items[i] = t.tree()
+		}
+		return items
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = tomlTree(item)
+		}
+		return items
+	}
+	return v
+}
+
+var (
+	tomlIntegerPattern = regexp.MustCompile(`^(?:[+-]?(?:0|[1-9](?:_?[0-9])*)|0x[0-9A-Fa-f](?:_?[0-9A-Fa-f])*|0o[0-7](?:_?[0-7])*|0b[01](?:_?[01])*)$`)
+	tomlFloatPattern   = regexp.MustCompile(`^[+-]?(?:0|[1-9](?:_?[0-9])*)(?:\.[0-9](?:_?[0-9])*)?(?:[eE][+-]?[0-9](?:_?[0-9])*)?$`)
+	tomlSpecialFloat   = regexp.MustCompile(`^[+-]?(?:inf|nan)$`)
+	tomlDatePattern    = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+	tomlTimePattern    = regexp.MustCompile(`^[0-9]{2}:[0-9]{2}:[0-9]{2}(?:\.[0-9]+)?$`)
+	tomlDateTime       = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}[Tt ][0-9]{2}:[0-9]{2}:[0-9]{2}(?:\.[0-9]+)?(?:[Zz]|[+-][0-9]{2}:[0-9]{2})?$`)
+)
+
+// tomlParser is a recursive-descent TOML parser working on the whole input.
+type tomlParser struct {
+	src     string
+	pos     int
+	root    *tomlTable
+	current *tomlTable
+	lines   map[string]int // line of each key defined outside inline tables
+	inline  int            // depth of inline tables being parsed
+}
+
+func (p *tomlParser) errorf(format string, args ...any) error {
+	before := p.src[:p.pos]
+	col := utf8.RuneCountInString(before[strings.LastIndexByte(before, '\n')+1:]) + 1
+	return fmt.Errorf("line %d, column %d: %s", p.line(p.pos), col, fmt.Sprintf(format, args...))
+}
+
+// line returns the 1-based line of the byte at pos.
+func (p *tomlParser) line(pos int) int {
+	return strings.Count(p.src[:pos], "\n") + 1
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *tomlParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+// skipSpace skips spaces and tabs.
+func (p *tomlParser) skipSpace() {
+	for !p.eof() && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// skipComment skips a comment up to, but not including, the end of the line.
+func (p *tomlParser) skipComment() {
+	if p.peek() != '#' {
+		return
+	}
+	if end := strings.IndexByte(p.src[p.pos:], '\n'); end >= 0 {
+		p.pos += end
+	} else {
+		p.pos = len(p.src)
+	}
+}
+
+// skipBlank skips whitespace, newlines and comments.
+func (p *tomlParser) skipBlank() {
+	for {
+		p.skipSpace()
+		p.skipComment()
+		if p.peek() != '\n' {
+			return
+		}
+		p.pos++
+	}
+}
+
+// endLine expects the rest of the line to be blank or a comment.
+func (p *tomlParser) endLine() error {
+	p.skipSpace()
+	p.skipComment()
+	if p.eof() {
+		return nil
+	}
+	if p.src[p.pos] != '\n' {
+		return p.errorf("expected end of line, found %q", p.src[p.pos])
+	}
+	p.pos++
+	return nil
+}
+
+func (p *tomlParser) parseDocument() error {
+	p.current = p.root
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil
+		}
+
+		var err error
+		if p.peek() == '[' {
+			err = p.parseHeader()
+		} else {
+			err = p.parseKeyValue(p.current)
+		}
+		if err != nil {
+			return err
+		}
+		if err := p.endLine(); err != nil {
+			return err
+		}
+	}
+}
+
+// parseHeader parses a [table] or [[array]] header and makes it current.
+func (p *tomlParser) parseHeader() error {
+	start := p.pos
+	array := strings.HasPrefix(p.src[p.pos:], "[[")
+	if array {
+		p.pos += 2
+	} else {
+		p.pos++
+	}
+
+	p.skipSpace()
+	key, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipSpace()
+
+	closing := "]"
+	if array {
+		closing = "]]"
+	}
+	if !strings.HasPrefix(p.src[p.pos:], closing) {
+		return p.errorf("expected %q to close table header", closing)
+	}
+	p.pos += len(closing)
+
+	parent := p.root
+	for _, part := range key[:len(key)-1] {
+		if parent, err = p.descend(parent, part, false); err != nil {
+			p.pos = start
+			return err
+		}
+	}
+
+	last := key[len(key)-1]
+	name := strings.Join(key, ".")
+	existing, ok := parent.values[last]
+	if array {
+		path := joinKey(parent.path, last)
+		if !ok {
+			table := newTOMLTable(joinKey(path, "0"))
+			parent.values[last] = &tomlTableArray{tables: []*tomlTable{table}}
+			p.current = table
+			p.lines[path] = p.line(start)
+			p.lines[table.path] = p.line(start)
+			return nil
+		}
+		switch v := existing.(type) {
+		case *tomlTableArray:
+			table := newTOMLTable(joinKey(path, strconv.Itoa(len(v.tables))))
+			v.tables = append(v.tables, table)
+			p.current = table
+			p.lines[table.path] = p.line(start)
+		default:
+			p.pos = start
+			return p.errorf("key %q is already defined and is not an array of tables", name)
+		}
+		return nil
+	}
+
+	if !ok {
+		table := newTOMLTable(joinKey(parent.path, last))
+		table.explicit = true
+		parent.values[last] = table
+		p.current = table
+		p.lines[table.path] = p.line(start)
+		return nil
+	}
+	if v, isTable := existing.(*tomlTable); isTable && !v.explicit && !v.dotted && !v.inline {
+		v.explicit = true
+		p.current = v
+		return nil
+	}
+	p.pos = start
+	return p.errorf("table %q is already defined", name)
+}
+
+// descend returns the table stored under key in t, creating it when missing.
+// Arrays of tables resolve to their last element.
+func (p *tomlParser) descend(t *tomlTable, key string, dotted bool) (*tomlTable, error) {
+	existing, ok := t.values[key]
+	if !ok {
+		table := newTOMLTable(joinKey(t.path, key))
+		table.dotted = dotted
+		t.values[key] = table
+		return table, nil
+	}
+	switch v := existing.(type) {
+	case *tomlTable:
+		if v.inline || (dotted && v.explicit) {
+			break
+		}
+		return v, nil
+	case *tomlTableArray:
+		if !dotted {
+			return v.tables[len(v.tables)-1], nil
+		}
+	}
+	return nil, p.errorf("key %q is already defined", key)
+}
+
+// parseKeyValue parses key = value and stores it in t.
+func (p *tomlParser) parseKeyValue(t *tomlTable) error {
+	start := p.pos
+	key, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipSpace()
+	if p.peek() != '=' {
+		return p.errorf("expected '=' after key")
+	}
+	p.pos++
+	p.skipSpace()
+
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	end := p.pos
+	p.pos = start
+	for _, part := range key[:len(key)-1] {
+		if t, err = p.descend(t, part, true); err != nil {
+			return err
+		}
+	}
+	last := key[len(key)-1]
+	if _, ok := t.values[last]; ok {
+		return p.errorf("duplicate key %q", strings.Join(key, "."))
+	}
+	t.values[last] = value
+	if p.inline == 0 {
+		p.lines[joinKey(t.path, last)] = p.line(start)
+	}
+	p.pos = end
+	return nil
+}
+
+// parseKey parses a bare, quoted or dotted key.
+func (p *tomlParser) parseKey() ([]string, error) {
+	var parts []string
+	for {
+		p.skipSpace()
+		var (
+			part string
+			err  error
+		)
+		switch p.peek() {
+		case '"':
+			if strings.HasPrefix(p.src[p.pos:], `"""`) {
+				return nil, p.errorf("multi-line strings are not allowed in keys")
+			}
+			part, err = p.parseBasicString()
+		case '\'':
+			if strings.HasPrefix(p.src[p.pos:], "'''") {
+				return nil, p.errorf("multi-line strings are not allowed in keys")
+			}
+			part, err = p.parseLiteralString()
+		default:
+			start := p.pos
+			for !p.eof() && isTOMLBareKeyChar(p.src[p.pos]) {
+				p.pos++
+			}
+			if p.pos == start {
+				return nil, p.errorf("invalid key")
+			}
+			part = p.src[start:p.pos]
+		}
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+
+		p.skipSpace()
+		if p.peek() != '.' {
+			return parts, nil
+		}
+		p.pos++
+	}
+}
+
+// parseValue parses any TOML value.
+func (p *tomlParser) parseValue() (any, error) {
+	switch c := p.peek(); c {
+	case '"':
+		if strings.HasPrefix(p.src[p.pos:], `"""`) {
+			return p.parseMultiLineString('"')
+		}
+		return p.parseBasicString()
+	case '\'':
+		var (
+			s   string
+			err error
+		)
+		if strings.HasPrefix(p.src[p.pos:], "'''") {
+			s, err = p.parseMultiLineString('\'')
+		} else {
+			s, err = p.parseLiteralString()
+		}
+		if err != nil {
+			return nil, err
+		}
+		return literalString(s), nil
+	case '[':
+		return p.parseArray()
+	case '{':
+		return p.parseInlineTable()
+	case 0, '\n', '#':
+		return nil, p.errorf("missing value")
+	}
+	return p.parseScalar()
+}
+
+// parseScalar parses a boolean, number or date/time.
+func (p *tomlParser) parseScalar() (any, error) {
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(" \t\n,]}#", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	token := p.src[start:p.pos]
+
+	// a date and time may be separated by a space
+	if tomlDatePattern.MatchString(token) && p.pos+3 < len(p.src) && p.src[p.pos] == ' ' &&
+		isDigit(p.src[p.pos+1]) && isDigit(p.src[p.pos+2]) && p.src[p.pos+3] == ':' {
+		p.pos++
+		for !p.eof() && !strings.ContainsRune(" \t\n,]}#", rune(p.src[p.pos])) {
+			p.pos++
+		}
+		token = p.src[start:p.pos]
+	}
+
+	switch {
+	case token == "true" || token == "false":
+		return token, nil
+	case tomlIntegerPattern.MatchString(token):
+		n, err := strconv.ParseInt(token, 0, 64)
+		if err != nil {
+			p.pos = start
+			return nil, p.errorf("integer %s is out of range", token)
+		}
+		return strconv.FormatInt(n, 10), nil
+	case tomlFloatPattern.MatchString(token), tomlSpecialFloat.MatchString(token):
+		return strings.ReplaceAll(token, "_", ""), nil
+	case tomlDateTime.MatchString(token), tomlDatePattern.MatchString(token), tomlTimePattern.MatchString(token):
+		return token, nil
+	}
+	p.pos = start
+	return nil, p.errorf("invalid value %q", token)
+}
+
+// parseArray parses [a, b, ...], which may span lines and contain comments.
+func (p *tomlParser) parseArray() (any, error) {
+	p.pos++ // [
+	items := []any{}
+	for {
+		p.skipBlank()
+		if p.peek() == ']' {
+			p.pos++
+			return items, nil
+		}
+		if p.eof() {
+			return nil, p.errorf("unterminated array")
+		}
+
+		item, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		p.skipBlank()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			return items, nil
+		case 0:
+			return nil, p.errorf("unterminated array")
+		default:
+			return nil, p.errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+// parseInlineTable parses {a = 1, b.c = 2} on a single line.
+func (p *tomlParser) parseInlineTable() (any, error) {
+	p.pos++ // {
+	p.inline++
+	defer func() { p.inline-- }()
+
+	table := newTOMLTable("")
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.pos++
+		table.inline = true
+		return table, nil
+	}
+	for {
+		p.skipSpace()
+		if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			sealTOMLTable(table)
+			return table, nil
+		default:
+			return nil, p.errorf("expected ',' or '}' in inline table")
+		}
+	}
+}
+
+// sealTOMLTable marks an inline table and the tables it defines as closed.
+func sealTOMLTable(t *tomlTable) {
+	t.inline = true
+	for _, v := range t.values {
+		if sub, ok := v.(*tomlTable); ok {
+			sealTOMLTable(sub)
+		}
+	}
+}
+
+// parseBasicString parses a single-line "..." string.
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.pos++ // "
+	var b strings.Builder
+	for {
+		if p.eof() || p.src[p.pos] == '\n' {
+			return "", p.errorf("unterminated string")
+		}
+		c := p.src[p.pos]
+		switch c {
+		case '"':
+			p.pos++
+			return b.String(), nil
+		case '\\':
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+// parseLiteralString parses a single-line '...' string.
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.pos++ // '
+	end := strings.IndexAny(p.src[p.pos:], "'\n")
+	if end < 0 || p.src[p.pos+end] == '\n' {
+		return "", p.errorf("unterminated string")
+	}
+	s := p.src[p.pos : p.pos+end]
+	p.pos += end + 1
+	return s, nil
+}
+
+// parseMultiLineString parses a multi-line string delimited by three quote characters.
+// A newline right after the opening delimiter is trimmed.
+func (p *tomlParser) parseMultiLineString(quote byte) (string, error) {
+	start := p.pos
+	p.pos += 3
+	if p.peek() == '\n' {
+		p.pos++
+	}
+
+	delim := strings.Repeat(string(quote), 3)
+	var b strings.Builder
+	for {
+		if p.eof() {
+			p.pos = start
+			return "", p.errorf("unterminated multi-line string")
+		}
+		if strings.HasPrefix(p.src[p.pos:], delim) {
+			// up to two quotes may directly precede the closing delimiter
+			n := 3
+			for n < 5 && p.pos+n < len(p.src) && p.src[p.pos+n] == quote {
+				n++
+			}
+			b.WriteString(p.src[p.pos : p.pos+n-3])
+			p.pos += n
+			return b.String(), nil
+		}
+
+		c := p.src[p.pos]
+		if c == '\\' && quote == '"' {
+			// a backslash at the end of a line trims the line break and following whitespace
+			rest := strings.TrimLeft(p.src[p.pos+1:], " \t")
+			if strings.HasPrefix(rest, "\n") {
+				p.pos = len(p.src) - len(strings.TrimLeft(rest, " \t\n"))
+				continue
+			}
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+			continue
+		}
+		b.WriteByte(c)
+		p.pos++
+	}
+}
+
+// parseEscape decodes the escape sequence at p.pos into b.
+func (p *tomlParser) parseEscape(b *strings.Builder) error {
+	if p.pos+1 >= len(p.src) {
+		return p.errorf("invalid escape sequence")
+	}
+	c := p.src[p.pos+1]
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case '"':
+		b.WriteByte('"')
+	case '\\':
+		b.WriteByte('\\')
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		hex := p.src[p.pos+2:]
+		if len(hex) < size {
+			return p.errorf("invalid unicode escape")
+		}
+		n, err := strconv.ParseUint(hex[:size], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(n)) {
+			return p.errorf("invalid unicode escape \\%c%s", c, hex[:size])
+		}
+		b.WriteRune(rune(n))
+		p.pos += 2 + size
+		return nil
+	default:
+		return p.errorf("invalid escape sequence \\%c", c)
+	}
+	p.pos += 2
+	return nil
+}
+
+func isTOMLBareKeyChar(c byte) bool {
+	return c == '_' || c == '-' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
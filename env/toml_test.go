@@ -0,0 +1,154 @@
+package lxenv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTOMLFileParser_Parse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]string
+	}{
+		{
+			name:  "tables",
+			input: "title = \"lx\"\n\n[database]\nhost = \"localhost\"\n\n[database.pool]\nsize = 10\n",
+			want:  map[string]string{"title": "lx", "database.host": "localhost", "database.pool.size": "10"},
+		},
+		{
+			name:  "dotted and quoted keys",
+			input: "a.b.c = 1\nsite.\"google.com\" = true\n'literal key' = 2\n3.14 = \"pi\"\n",
+			want:  map[string]string{"a.b.c": "1", "site.google.com": "true", "literal key": "2", "3.14": "pi"},
+		},
+		{
+			name:  "implicit super table defined later",
+			input: "[x.y.z]\na = 1\n[x]\nb = 2\n",
+			want:  map[string]string{"x.y.z.a": "1", "x.b": "2"},
+		},
+		{
+			name:  "arrays",
+			input: "ports = [80, 443]\nempty = []\nnested = [[1, 2], [\"a\"]]\nmulti = [\n  \"x\", # first\n  \"y\",\n]\n",
+			want: map[string]string{
+				"ports.0": "80", "ports.1": "443", "ports": "80,443",
+				"empty":    "",
+				"nested.0": "1,2", "nested.0.0": "1", "nested.0.1": "2",
+				"nested.1": "a", "nested.1.0": "a",
+				"multi.0": "x", "multi.1": "y", "multi": "x,y",
+			},
+		},
+		{
+			name:  "arrays of tables",
+			input: "[[servers]]\nhost = \"a\"\n[servers.tls]\nenabled = true\n\n[[servers]]\nhost = \"b\"\n",
+			want:  map[string]string{"servers.0.host": "a", "servers.0.tls.enabled": "true", "servers.1.host": "b"},
+		},
+		{
+			name:  "inline tables",
+			input: "db = { host = \"h\", port = 5432, opts.ssl = true }\nlist = [{ a = 1 }, { a = 2 }]\nnone = {}\n",
+			want:  map[string]string{"db.host": "h", "db.port": "5432", "db.opts.ssl": "true", "list.0.a": "1", "list.1.a": "2"},
+		},
+		{
+			name:  "basic strings",
+			input: `a = "tab\there \"q\" \\ \u00e9 \U0001F600"` + "\nb = \"# not a comment\" # comment\n",
+			want:  map[string]string{"a": "tab\there \"q\" \\ é 😀", "b": "# not a comment"},
+		},
+		{
+			name:  "literal strings",
+			input: `path = 'C:\Users\lx'` + "\nregex = '<\\i\\c*\\s*>'\n",
+			want:  map[string]string{"path": `C:\Users\lx`, "regex": `<\i\c*\s*>`},
+		},
+		{
+			name:  "multi-line basic strings",
+			input: "a = \"\"\"\nRoses are red\nViolets are blue\"\"\"\nb = \"\"\"\\\n  The quick \\\n  brown fox.\\\n  \"\"\"\nc = \"\"\"\"quoted\"\"\"\"\n",
+			want:  map[string]string{"a": "Roses are red\nViolets are blue", "b": "The quick brown fox.", "c": `"quoted"`},
+		},
+		{
+			name:  "multi-line literal strings",
+			input: "a = '''\nfirst\n  \\second\n'''\nb = ''''one quote''''\n",
+			want:  map[string]string{"a": "first\n  \\second\n", "b": "'one quote'"},
+		},
+		{
+			name:  "integers",
+			input: "a = +99\nb = -17\nc = 1_000\nd = 0xDEAD_beef\ne = 0o755\nf = 0b1101\ng = 0\n",
+			want:  map[string]string{"a": "99", "b": "-17", "c": "1000", "d": "3735928559", "e": "493", "f": "13", "g": "0"},
+		},
+		{
+			name:  "floats",
+			input: "a = 3.14\nb = -0.01\nc = 5e+22\nd = 6.626e-34\ne = 224_617.445_991\nf = inf\ng = -nan\n",
+			want:  map[string]string{"a": "3.14", "b": "-0.01", "c": "5e+22", "d": "6.626e-34", "e": "224617.445991", "f": "inf", "g": "-nan"},
+		},
+		{
+			name:  "booleans and dates",
+			input: "t = true\nf = false\nodt = 1979-05-27T07:32:00Z\nspace = 1979-05-27 07:32:00.999-07:00\nld = 1979-05-27\nlt = 00:32:00.5\n",
+			want: map[string]string{
+				"t": "true", "f": "false",
+				"odt":   "1979-05-27T07:32:00Z",
+				"space": "1979-05-27 07:32:00.999-07:00",
+				"ld":    "1979-05-27", "lt": "00:32:00.5",
+			},
+		},
+		{
+			name:  "crlf line endings",
+			input: "[a]\r\nb = 1\r\n",
+			want:  map[string]string{"a.b": "1"},
+		},
+		{
+			name:  "empty input",
+			input: "# only a comment\n\n",
+			want:  map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := defaultTOMLParser.Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTOMLFileParser_ParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"duplicate key", "a = 1\na = 2\n", "line 2, column 1: duplicate key \"a\""},
+		{"duplicate table", "[a]\n[a]\n", "line 2, column 1: table \"a\" is already defined"},
+		{"table over dotted key", "a.b = 1\n[a]\n", "table \"a\" is already defined"},
+		{"extend inline table", "a = {b = 1}\na.c = 2\n", "key \"a\" is already defined"},
+		{"table over value", "a = 1\n[a.b]\n", "key \"a\" is already defined"},
+		{"array of tables over table", "[a]\n[[a]]\n", "is not an array of tables"},
+		{"missing value", "a =\n", "line 1, column 4: missing value"},
+		{"missing equals", "a 1\n", "expected '=' after key"},
+		{"invalid value", "a = yes\n", "line 1, column 5: invalid value \"yes\""},
+		{"leading zero", "a = 007\n", "invalid value"},
+		{"integer overflow", "a = 9223372036854775808\n", "out of range"},
+		{"unterminated string", "a = \"open\n", "unterminated string"},
+		{"unterminated multi-line string", "a = \"\"\"open\n", "line 1, column 5: unterminated multi-line string"},
+		{"invalid escape", "a = \"\\q\"\n", "invalid escape sequence"},
+		{"unterminated array", "a = [1, 2\n", "unterminated array"},
+		{"missing array separator", "a = [1 2]\n", "expected ',' or ']'"},
+		{"newline in inline table", "a = {b = 1,\nc = 2}\n", "invalid key"},
+		{"content after value", "a = 1 b = 2\n", "expected end of line"},
+		{"unterminated header", "[a\n", "expected \"]\" to close table header"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := defaultTOMLParser.Parse(strings.NewReader(tt.input))
+			if err == nil {
+				t.Fatalf("Parse(%q) expected error, got nil", tt.input)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse(%q) error = %q, want it to contain %q", tt.input, err, tt.want)
+			}
+		})
+	}
+}
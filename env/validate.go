@@ -0,0 +1,246 @@
+package lxenv
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Rule describes the constraints on a single key. Rules are built with Key
+// and checked with Validate. Checks run in the order they were added and stop
+// at the first failure, so each key reports at most one violation per rule.
+//
+// Example:
+//
+//	err := lxenv.Validate(
+//	    lxenv.Key("PORT").Required().IntRange(1, 65535),
+//	    lxenv.Key("LOG_LEVEL").OneOf("debug", "info", "warn"),
+//	    lxenv.Key("API_URL").Required().URL(),
+//	    lxenv.Key("REGION").Matches(`^[a-z]{2}-[a-z]+-[0-9]$`),
+//	)
+type Rule struct {
+	key      string
+	required bool
+	checks   []check
+}
+
+// check tests a value and returns the reason it is invalid, or "" if it is valid.
+type check func(value string) string
+
+// Key starts a rule for key. Without Required, an unset or empty key passes
+// every check.
+func Key(key string) *Rule {
+	return &Rule{key: key}
+}
+
+// Required makes the rule fail with ErrKeyNotFound when the key is not set.
+func (r *Rule) Required() *Rule {
+	r.required = true
+	return r
+}
+
+// Int requires the value to be an integer.
+func (r *Rule) Int() *Rule {
+	return r.add(func(value string) string {
+		if _, ok := intValue(value); !ok {
+			return "must be an integer"
+		}
+		return ""
+	})
+}
+
+// IntRange requires the value to be an integer between min and max inclusive.
+func (r *Rule) IntRange(min, max int) *Rule {
+	return r.add(func(value string) string {
+		if n, ok := intValue(value); !ok || n < min || n > max {
+			return fmt.Sprintf("must be an integer between %d and %d", min, max)
+		}
+		return ""
+	})
+}
+
+// Bool requires the value to be a boolean as accepted by GetBool.
+func (r *Rule) Bool() *Rule {
+	return r.add(func(value string) string {
+		if _, ok := boolValue(value); !ok {
+			return "must be a boolean"
+		}
+		return ""
+	})
+}
+
+// Duration requires the value to be a duration as accepted by GetDuration.
+func (r *Rule) Duration() *Rule {
+	return r.add(func(value string) string {
+		if _, ok := durationValue(value); !ok {
+			return "must be a duration"
+		}
+		return ""
+	})
+}
+
+// OneOf requires the value to be exactly one of allowed.
+func (r *Rule) OneOf(allowed ...string) *Rule {
+	return r.add(func(value string) string {
+		if _, ok := enumValue(value, allowed); !ok {
+			return "must be one of " + strings.Join(allowed, ", ")
+		}
+		return ""
+	})
+}
+
+// URL requires the value to be an absolute URL.
+func (r *Rule) URL() *Rule {
+	return r.add(func(value string) string {
+		if _, ok := urlValue(value); !ok {
+			return "must be an absolute URL"
+		}
+		return ""
+	})
+}
+
+// Matches requires the value to match the regular expression pattern.
+// Panics if pattern does not compile, like regexp.MustCompile.
+func (r *Rule) Matches(pattern string) *Rule {
+	re := regexp.MustCompile(pattern)
+	return r.add(func(value string) string {
+		if !re.MatchString(value) {
+			return "must match " + strconv.Quote(pattern)
+		}
+		return ""
+	})
+}
+
+// Check adds a custom check. A non-nil error fails the rule with the error's message.
+//
+// Example:
+//
+//	lxenv.Key("WORKERS").Check(func(v string) error {
+//	    if n, _ := strconv.Atoi(v); n%2 != 0 {
+//	        return errors.New("must be even")
+//	    }
+//	    return nil
+//	})
+func (r *Rule) Check(fn func(value string) error) *Rule {
+	return r.add(func(value string) string {
+		if err := fn(value); err != nil {
+			return err.Error()
+		}
+		return ""
+	})
+}
+
+func (r *Rule) add(c check) *Rule {
+	r.checks = append(r.checks, c)
+	return r
+}
+
+// validate checks the rule against the value of its key and returns the
+// violation, or nil if the rule holds.
+func (r *Rule) validate(lookup func(string) (string, bool)) *Violation {
+	value, ok := lookup(r.key)
+	if !ok {
+		if r.required {
+			return &Violation{Key: r.key, Reason: "is required", Err: ErrKeyNotFound}
+		}
+		return nil
+	}
+	if value == "" && !r.required {
+		return nil
+	}
+	value, _, err := resolveForGet(value)
+	if err != nil {
+		return &Violation{Key: r.key, Reason: "cannot be resolved", Err: err}
+	}
+	for _, c := range r.checks {
+		if reason := c(value); reason != "" {
+			return &Violation{Key: r.key, Reason: reason, Err: ErrInvalidValue}
+		}
+	}
+	return nil
+}
+
+// Violation is a rule that does not hold for a key.
+type Violation struct {
+	Key    string
+	Reason string // e.g. "is required" or "must be an integer between 1 and 65535"
+	Err    error  // ErrKeyNotFound, ErrInvalidValue or ErrUnresolvedReference
+}
+
+// Error returns the key followed by the reason, e.g. "PORT must be an integer".
+func (v *Violation) Error() string {
+	return v.Key + " " + v.Reason
+}
+
+// Unwrap returns the sentinel error of the violation.
+func (v *Violation) Unwrap() error {
+	return v.Err
+}
+
+// ValidationError lists every violated rule, in the order the rules were given.
+// It matches ErrKeyNotFound and ErrInvalidValue with errors.Is when any of
+// its violations do.
+type ValidationError struct {
+	Violations []*Violation
+}
+
+// Error joins the violations with "; ".
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Error()
+	}
+	return "lxenv: invalid configuration: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the violations.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Violations))
+	for i, v := range e.Violations {
+		errs[i] = v
+	}
+	return errs
+}
+
+// Is reports whether any violation matches target.
+func (e *ValidationError) Is(target error) bool {
+	for _, v := range e.Violations {
+		if errors.Is(v, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks every rule against the process environment and returns a
+// *ValidationError listing all violations, or nil if every rule holds.
+// Values are resolved as in Get before they are checked when ResolveOnGet is
+// enabled.
+//
+// Example:
+//
+//	if err := lxenv.Validate(
+//	    lxenv.Key("DB_HOST").Required(),
+//	    lxenv.Key("DB_PORT").IntRange(1, 65535),
+//	); err != nil {
+//	    log.Fatal(err) // lxenv: invalid configuration: DB_HOST is required; DB_PORT must be ...
+//	}
+func Validate(rules ...*Rule) error {
+	return validateRules(rules, Lookup)
+}
+
+// validateRules checks rules with values read from lookup.
+func validateRules(rules []*Rule, lookup func(string) (string, bool)) error {
+	var violations []*Violation
+	for _, r := range rules {
+		if v := r.validate(lookup); v != nil {
+			violations = append(violations, v)
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: violations}
+}
@@ -0,0 +1,131 @@
+package lxenv_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hgapdvn/lx/env"
+)
+
+func TestValidate(t *testing.T) {
+	t.Setenv("TEST_VAL_PORT", "8080")
+	t.Setenv("TEST_VAL_LEVEL", "info")
+	t.Setenv("TEST_VAL_URL", "https://example.com")
+	t.Setenv("TEST_VAL_REGION", "eu-west-1")
+	t.Setenv("TEST_VAL_EMPTY", "")
+
+	err := lxenv.Validate(
+		lxenv.Key("TEST_VAL_PORT").Required().IntRange(1, 65535),
+		lxenv.Key("TEST_VAL_LEVEL").OneOf("debug", "info", "warn"),
+		lxenv.Key("TEST_VAL_URL").URL(),
+		lxenv.Key("TEST_VAL_REGION").Matches(`^[a-z]{2}-[a-z]+-[0-9]$`),
+		lxenv.Key("TEST_VAL_OPTIONAL").Int(),
+		lxenv.Key("TEST_VAL_EMPTY").Bool().Duration(),
+	)
+	if err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
+
+func TestValidate_ReportsEveryViolation(t *testing.T) {
+	t.Setenv("TEST_VAL_PORT", "70000")
+	t.Setenv("TEST_VAL_LEVEL", "trace")
+	t.Setenv("TEST_VAL_URL", "example.com")
+	t.Setenv("TEST_VAL_REGION", "EU")
+	t.Setenv("TEST_VAL_WORKERS", "3")
+
+	err := lxenv.Validate(
+		lxenv.Key("TEST_VAL_HOST").Required(),
+		lxenv.Key("TEST_VAL_PORT").Required().IntRange(1, 65535),
+		lxenv.Key("TEST_VAL_LEVEL").OneOf("debug", "info"),
+		lxenv.Key("TEST_VAL_URL").URL(),
+		lxenv.Key("TEST_VAL_REGION").Matches(`^[a-z]{2}$`),
+		lxenv.Key("TEST_VAL_WORKERS").Int().Check(func(v string) error {
+			if n, _ := strconv.Atoi(v); n%2 != 0 {
+				return errors.New("must be even")
+			}
+			return nil
+		}),
+	)
+
+	var verr *lxenv.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Validate() error = %v, want *ValidationError", err)
+	}
+	want := []string{
+		"TEST_VAL_HOST is required",
+		"TEST_VAL_PORT must be an integer between 1 and 65535",
+		"TEST_VAL_LEVEL must be one of debug, info",
+		"TEST_VAL_URL must be an absolute URL",
+		`TEST_VAL_REGION must match "^[a-z]{2}$"`,
+		"TEST_VAL_WORKERS must be even",
+	}
+	if len(verr.Violations) != len(want) {
+		t.Fatalf("Validate() violations = %v, want %d", verr.Violations, len(want))
+	}
+	for i, v := range verr.Violations {
+		if v.Error() != want[i] {
+			t.Errorf("violation %d = %q, want %q", i, v, want[i])
+		}
+	}
+
+	if !errors.Is(err, lxenv.ErrKeyNotFound) || !errors.Is(err, lxenv.ErrInvalidValue) {
+		t.Error("ValidationError should match ErrKeyNotFound and ErrInvalidValue")
+	}
+	if !errors.Is(verr.Violations[0], lxenv.ErrKeyNotFound) || errors.Is(verr.Violations[0], lxenv.ErrInvalidValue) {
+		t.Error("a missing key violation should only match ErrKeyNotFound")
+	}
+	if msg := err.Error(); !strings.HasPrefix(msg, "lxenv: invalid configuration: TEST_VAL_HOST is required; ") {
+		t.Errorf("Error() = %q", msg)
+	}
+}
+
+func TestValidate_StopsAtFirstFailedCheck(t *testing.T) {
+	t.Setenv("TEST_VAL_PORT", "http")
+
+	err := lxenv.Validate(lxenv.Key("TEST_VAL_PORT").Int().IntRange(1, 10))
+	var verr *lxenv.ValidationError
+	if !errors.As(err, &verr) || len(verr.Violations) != 1 || verr.Violations[0].Reason != "must be an integer" {
+		t.Errorf("Validate() = %v, want a single integer violation", err)
+	}
+}
+
+func TestValidate_RawValues(t *testing.T) {
+	t.Setenv("TEST_VAL_SECRET_PORT", "base64:NDQz") // 443
+
+	err := lxenv.Validate(lxenv.Key("TEST_VAL_SECRET_PORT").Int())
+	if !errors.Is(err, lxenv.ErrInvalidValue) {
+		t.Errorf("Validate() error = %v, want ErrInvalidValue for an unresolved reference", err)
+	}
+}
+
+func TestMatches_InvalidPatternPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Matches() with an invalid pattern did not panic")
+		}
+	}()
+	lxenv.Key("X").Matches("(")
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := lxenv.NewConfig(map[string]string{"PORT": "0", "HOST": "db"})
+
+	err := cfg.Validate(
+		lxenv.Key("HOST").Required(),
+		lxenv.Key("PORT").IntRange(1, 65535),
+		lxenv.Key("NAME").Required(),
+	)
+	var verr *lxenv.ValidationError
+	if !errors.As(err, &verr) || len(verr.Violations) != 2 {
+		t.Fatalf("Config.Validate() = %v, want 2 violations", err)
+	}
+	if verr.Violations[0].Key != "PORT" || verr.Violations[1].Key != "NAME" {
+		t.Errorf("Config.Validate() keys = %s, %s", verr.Violations[0].Key, verr.Violations[1].Key)
+	}
+	if err := cfg.Validate(lxenv.Key("HOST").OneOf("db")); err != nil {
+		t.Errorf("Config.Validate() unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,368 @@
+package lxenv
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultPollInterval is how often Watch checks files when no interval is given.
+const defaultPollInterval = time.Second
+
+// Change holds the previous and current value of a changed key.
+type Change struct {
+	Old string
+	New string
+}
+
+// Diff describes how the configuration changed between two reads.
+type Diff struct {
+	Added   map[string]string // new keys and their values
+	Changed map[string]Change // keys whose value changed
+	Removed map[string]string // removed keys and their last value
+}
+
+// IsEmpty reports whether the diff contains no changes.
+func (d Diff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// Keys returns every added, changed and removed key in sorted order.
+func (d Diff) Keys() []string {
+	keys := make([]string, 0, len(d.Added)+len(d.Changed)+len(d.Removed))
+	for k := range d.Added {
+		keys = append(keys, k)
+	}
+	for k := range d.Changed {
+		keys = append(keys, k)
+	}
+	for k := range d.Removed {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// diffPairs compares two sets of loaded pairs.
+func diffPairs(before, after map[string]string) Diff {
+	d := Diff{
+		Added:   make(map[string]string),
+		Changed: make(map[string]Change),
+		Removed: make(map[string]string),
+	}
+	for k, v := range after {
+		old, ok := before[k]
+		switch {
+		case !ok:
+			d.Added[k] = v
+		case old != v:
+			d.Changed[k] = Change{Old: old, New: v}
+		}
+	}
+	for k, v := range before {
+		if _, ok := after[k]; !ok {
+			d.Removed[k] = v
+		}
+	}
+	return d
+}
+
+// WatchOption configures Watch.
+type WatchOption func(*watchOptions)
+
+type watchOptions struct {
+	interval time.Duration
+	apply    bool
+	hash     bool
+	onError  func(error)
+}
+
+// WithPollInterval sets how often watched files are checked. Defaults to one second.
+func WithPollInterval(d time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.interval = d
+	}
+}
+
+// WithApply controls whether changes are applied to the process environment:
+// added and changed keys are set and removed keys are unset. When enabled, the
+// initial state is also set when watching starts, as LoadEnv would.
+//
+// With a loader using WithOverride(false), variables that were set before
+// watching started still win over file values, but the keys the watcher set
+// itself keep following the files. References to those keys, such as
+// PATH=${PATH}:/opt/bin, expand against their value from before watching.
+func WithApply(enabled bool) WatchOption {
+	return func(o *watchOptions) {
+		o.apply = enabled
+	}
+}
+
+// WithContentHash compares file contents by SHA-256 instead of modification
+// time and size. This catches edits that keep the same mtime and ignores
+// touches that do not change the content, at the cost of reading every file
+// on each poll.
+func WithContentHash(enabled bool) WatchOption {
+	return func(o *watchOptions) {
+		o.hash = enabled
+	}
+}
+
+// WithErrorHandler sets a function called with errors that happen while
+// watching, such as a file that fails to parse. The previous configuration
+// stays in effect until the files parse again.
+func WithErrorHandler(fn func(error)) WatchOption {
+	return func(o *watchOptions) {
+		o.onError = fn
+	}
+}
+
+// Watch reads paths like Load and then polls them in the background until ctx
+// is done. Whenever a file changes it is re-read and onChange receives the keys
+// that were added, changed or removed. Formats are chosen by extension as in Load.
+//
+// Watching uses polling only, so it works on every platform. A file that fails
+// to parse, or goes missing, leaves the previous good state in place and is
+// reported to the WithErrorHandler function.
+//
+// Watch returns an error if the initial read fails. Otherwise the returned
+// Watcher reports when polling has stopped after ctx is done.
+//
+// Example:
+//
+//	w, err := lxenv.Watch(ctx, []string{"config.yml", ".env"}, func(d lxenv.Diff) {
+//	    log.Printf("config changed: %v", d.Keys())
+//	}, lxenv.WithApply(true), lxenv.WithPollInterval(5*time.Second))
+//	...
+//	cancel()
+//	w.Wait() // no more changes are applied or reported
+func Watch(ctx context.Context, paths []string, onChange func(Diff), opts ...WatchOption) (*Watcher, error) {
+	return defaultLoader.Watch(ctx, paths, onChange, opts...)
+}
+
+// Watch is like the package-level Watch but reads files with the loader's options.
+func (l *Loader) Watch(ctx context.Context, paths []string, onChange func(Diff), opts ...WatchOption) (*Watcher, error) {
+	options := watchOptions{interval: defaultPollInterval}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.interval <= 0 {
+		options.interval = defaultPollInterval
+	}
+
+	w := &Watcher{
+		loader:   l.envLoader(nil),
+		paths:    append([]string(nil), paths...),
+		onChange: onChange,
+		options:  options,
+		owned:    make(map[string]envValue),
+		done:     make(chan struct{}),
+	}
+	w.loader.environ = w.environ
+	if err := w.start(); err != nil {
+		return nil, err
+	}
+	go w.run(ctx)
+	return w, nil
+}
+
+// Watcher polls a set of files and reports changes. It is created by Watch.
+type Watcher struct {
+	loader      *envLoader
+	paths       []string
+	onChange    func(Diff)
+	options     watchOptions
+	state       map[string]string
+	fingerprint string
+	owned       map[string]envValue // Keys set from file values by the watcher, with their value before
+	done        chan struct{}       // Closed when polling stops
+}
+
+// envValue is the value of an environment variable, or the lack of one.
+type envValue struct {
+	value string
+	ok    bool
+}
+
+// Done returns a channel that is closed once polling has stopped, after the
+// context of Watch is done and any poll in progress has finished.
+func (w *Watcher) Done() <-chan struct{} {
+	return w.done
+}
+
+// Wait blocks until polling has stopped.
+func (w *Watcher) Wait() {
+	<-w.done
+}
+
+// start reads the initial state.
+func (w *Watcher) start() error {
+	w.fingerprint = w.fingerprintFiles()
+	pairs, origins, err := w.loader.read(w.paths...)
+	if err != nil {
+		return err
+	}
+	w.state = pairs
+
+	if w.options.apply {
+		recordOrigins(origins)
+		return w.apply(diffPairs(nil, pairs), origins)
+	}
+	return nil
+}
+
+func (w *Watcher) run(ctx context.Context) {
+	defer close(w.done)
+	ticker := time.NewTicker(w.options.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+// poll re-reads the files if they changed since the last poll.
+func (w *Watcher) poll() {
+	fingerprint := w.fingerprintFiles()
+	if fingerprint == w.fingerprint {
+		return
+	}
+	w.fingerprint = fingerprint
+
+	pairs, origins, err := w.loader.read(w.paths...)
+	if err != nil {
+		w.reportError(err)
+		return
+	}
+
+	diff := diffPairs(w.state, pairs)
+	w.state = pairs
+	if diff.IsEmpty() {
+		return
+	}
+	if w.options.apply {
+		replaceOrigins(origins, diff.Removed)
+		if err := w.apply(diff, origins); err != nil {
+			w.reportError(err)
+		}
+	}
+	if w.onChange != nil {
+		w.onChange(diff)
+	}
+}
+
+// fingerprintFiles summarises the current state of every watched file.
+// Any difference between two fingerprints triggers a re-read.
+func (w *Watcher) fingerprintFiles() string {
+	files, err := w.loader.options.files(w.paths)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+
+	var b strings.Builder
+	for _, file := range files {
+		b.WriteString(file.path)
+		b.WriteByte('|')
+		if w.options.hash {
+			b.WriteString(hashFile(file.path))
+		} else if info, err := os.Stat(file.path); err == nil {
+			fmt.Fprintf(&b, "%d|%d", info.ModTime().UnixNano(), info.Size())
+		} else {
+			b.WriteString("missing")
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func (w *Watcher) reportError(err error) {
+	if w.options.onError != nil {
+		w.options.onError(err)
+	}
+}
+
+// hashFile returns the hex SHA-256 of the file at path, or "missing" if it cannot be read.
+func hashFile(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return "missing"
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "missing"
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// environ is the process environment as seen by the loader. Keys the watcher
+// set itself report the value they had before the watcher set them, so that
+// they are not mistaken for real environment variables winning over file
+// values, and a reference such as PATH=${PATH}:/opt/bin keeps expanding
+// against the original PATH.
+func (w *Watcher) environ(key string) (string, bool) {
+	if prev, ok := w.owned[key]; ok {
+		return prev.value, prev.ok
+	}
+	return Lookup(key)
+}
+
+// apply applies d to the process environment and records which keys now hold
+// file values, along with their value before the watcher first set them.
+func (w *Watcher) apply(d Diff, origins map[string]Provenance) error {
+	for k := range d.Removed {
+		delete(w.owned, k)
+	}
+	for k := range d.Added {
+		w.own(k, origins[k])
+	}
+	for k := range d.Changed {
+		w.own(k, origins[k])
+	}
+	return applyDiff(d)
+}
+
+// own records whether key, about to be set to the value described by p, holds
+// a file value. It must be called before the value is set.
+func (w *Watcher) own(key string, p Provenance) {
+	if p.Source.Format == sourceEnvironment {
+		delete(w.owned, key)
+		return
+	}
+	if _, ok := w.owned[key]; !ok {
+		value, ok := Lookup(key)
+		w.owned[key] = envValue{value: value, ok: ok}
+	}
+}
+
+// applyDiff sets added and changed keys and unsets removed ones.
+func applyDiff(d Diff) error {
+	for k, v := range d.Added {
+		if err := Set(k, v); err != nil {
+			return fmt.Errorf("set %q: %w", k, err)
+		}
+	}
+	for k, c := range d.Changed {
+		if err := Set(k, c.New); err != nil {
+			return fmt.Errorf("set %q: %w", k, err)
+		}
+	}
+	for k := range d.Removed {
+		if err := Unset(k); err != nil {
+			return fmt.Errorf("unset %q: %w", k, err)
+		}
+	}
+	return nil
+}
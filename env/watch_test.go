@@ -0,0 +1,246 @@
+package lxenv_test
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hgapdvn/lx/env"
+)
+
+const watchInterval = 5 * time.Millisecond
+
+// startWatch starts watching paths and returns channels receiving diffs and errors.
+func startWatch(t *testing.T, paths []string, opts ...lxenv.WatchOption) (<-chan lxenv.Diff, <-chan error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	diffs := make(chan lxenv.Diff, 10)
+	errs := make(chan error, 10)
+	opts = append([]lxenv.WatchOption{
+		lxenv.WithPollInterval(watchInterval),
+		lxenv.WithErrorHandler(func(err error) {
+			select {
+			case errs <- err:
+			case <-ctx.Done():
+			}
+		}),
+	}, opts...)
+
+	w, err := lxenv.Watch(ctx, paths, func(d lxenv.Diff) {
+		select {
+		case diffs <- d:
+		case <-ctx.Done():
+		}
+	}, opts...)
+	if err != nil {
+		t.Fatalf("Watch() unexpected error: %v", err)
+	}
+	t.Cleanup(func() {
+		cancel()
+		w.Wait() // no poll may touch the environment after the test
+	})
+	return diffs, errs
+}
+
+// rewriteFile replaces the content of path and moves its mtime forward so the
+// change is visible even on file systems with coarse timestamps.
+func rewriteFile(t *testing.T, path, content string, mtime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to rewrite file: %v", err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatalf("failed to set mtime: %v", err)
+	}
+}
+
+func receive[T any](t *testing.T, ch <-chan T, what string) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for %s", what)
+		panic("unreachable")
+	}
+}
+
+func TestWatch_AppliesDiff(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_WATCH_A", "TEST_WATCH_B", "TEST_WATCH_C"})
+
+	p := writeTestFile(t, "watch.env", "TEST_WATCH_A=1\nTEST_WATCH_B=2\n")
+	diffs, _ := startWatch(t, []string{p}, lxenv.WithApply(true))
+
+	if got := os.Getenv("TEST_WATCH_A"); got != "1" {
+		t.Fatalf("initial env[%q] = %q, want %q", "TEST_WATCH_A", got, "1")
+	}
+
+	rewriteFile(t, p, "TEST_WATCH_A=10\nTEST_WATCH_C=3\n", time.Now().Add(time.Hour))
+	d := receive(t, diffs, "diff")
+
+	want := lxenv.Diff{
+		Added:   map[string]string{"TEST_WATCH_C": "3"},
+		Changed: map[string]lxenv.Change{"TEST_WATCH_A": {Old: "1", New: "10"}},
+		Removed: map[string]string{"TEST_WATCH_B": "2"},
+	}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("diff = %+v, want %+v", d, want)
+	}
+	if got := d.Keys(); !reflect.DeepEqual(got, []string{"TEST_WATCH_A", "TEST_WATCH_B", "TEST_WATCH_C"}) {
+		t.Errorf("diff.Keys() = %v", got)
+	}
+
+	if got := os.Getenv("TEST_WATCH_A"); got != "10" {
+		t.Errorf("env[%q] = %q, want %q", "TEST_WATCH_A", got, "10")
+	}
+	if got := os.Getenv("TEST_WATCH_C"); got != "3" {
+		t.Errorf("env[%q] = %q, want %q", "TEST_WATCH_C", got, "3")
+	}
+	if lxenv.Has("TEST_WATCH_B") {
+		t.Error("removed key TEST_WATCH_B should be unset")
+	}
+}
+
+func TestWatch_WithoutApply(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_WATCH_D"})
+
+	p := writeTestFile(t, "watch.yml", "test_watch:\n  d: 1\n")
+	diffs, _ := startWatch(t, []string{p})
+
+	rewriteFile(t, p, "TEST_WATCH_D: 2\n", time.Now().Add(time.Hour))
+	d := receive(t, diffs, "diff")
+
+	if d.Added["TEST_WATCH_D"] != "2" || d.Removed["test_watch.d"] != "1" {
+		t.Errorf("diff = %+v, want TEST_WATCH_D added and test_watch.d removed", d)
+	}
+	if lxenv.Has("TEST_WATCH_D") {
+		t.Error("Watch() without WithApply must not modify the process environment")
+	}
+}
+
+func TestWatch_ParseErrorKeepsPreviousState(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_WATCH_E", "TEST_WATCH_F"})
+
+	p := writeTestFile(t, "watch.env", "TEST_WATCH_E=1\n")
+	diffs, errs := startWatch(t, []string{p}, lxenv.WithApply(true))
+
+	rewriteFile(t, p, "TEST_WATCH_E=2\nBROKEN\n", time.Now().Add(time.Hour))
+	if err := receive(t, errs, "parse error"); !strings.Contains(err.Error(), "missing '='") {
+		t.Errorf("error = %v, want parse error", err)
+	}
+	if got := os.Getenv("TEST_WATCH_E"); got != "1" {
+		t.Errorf("env[%q] after parse error = %q, want %q", "TEST_WATCH_E", got, "1")
+	}
+
+	rewriteFile(t, p, "TEST_WATCH_E=1\nTEST_WATCH_F=2\n", time.Now().Add(2*time.Hour))
+	d := receive(t, diffs, "diff")
+	want := lxenv.Diff{
+		Added:   map[string]string{"TEST_WATCH_F": "2"},
+		Changed: map[string]lxenv.Change{},
+		Removed: map[string]string{},
+	}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("diff after recovery = %+v, want %+v", d, want)
+	}
+}
+
+func TestWatch_ContentHash(t *testing.T) {
+	p := writeTestFile(t, "watch.env", "TEST_WATCH_G=1\n")
+	info, err := os.Stat(p)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	diffs, _ := startWatch(t, []string{p}, lxenv.WithContentHash(true))
+
+	// same size and mtime: only the content hash can tell the difference
+	rewriteFile(t, p, "TEST_WATCH_G=2\n", info.ModTime())
+	d := receive(t, diffs, "diff")
+	if got := d.Changed["TEST_WATCH_G"]; got != (lxenv.Change{Old: "1", New: "2"}) {
+		t.Errorf("diff.Changed = %+v, want TEST_WATCH_G 1 -> 2", d.Changed)
+	}
+}
+
+func TestWatch_OverrideDisabledFollowsEdits(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_WATCH_H"})
+	t.Setenv("TEST_WATCH_ENV", "from-env")
+
+	p := writeTestFile(t, "watch.env", "TEST_WATCH_H=1\nTEST_WATCH_ENV=from-file\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	diffs := make(chan lxenv.Diff, 10)
+	w, err := lxenv.NewLoader(lxenv.WithOverride(false)).Watch(ctx, []string{p}, func(d lxenv.Diff) {
+		diffs <- d
+	}, lxenv.WithApply(true), lxenv.WithPollInterval(watchInterval))
+	if err != nil {
+		t.Fatalf("Watch() unexpected error: %v", err)
+	}
+	defer w.Wait()
+	defer cancel()
+
+	// the watcher set TEST_WATCH_H itself, so later edits still apply
+	rewriteFile(t, p, "TEST_WATCH_H=2\nTEST_WATCH_ENV=edited\n", time.Now().Add(time.Hour))
+	d := receive(t, diffs, "diff")
+	if got := d.Changed["TEST_WATCH_H"]; got != (lxenv.Change{Old: "1", New: "2"}) {
+		t.Errorf("diff.Changed = %+v, want TEST_WATCH_H 1 -> 2", d.Changed)
+	}
+	if got := os.Getenv("TEST_WATCH_H"); got != "2" {
+		t.Errorf("env[%q] = %q, want %q", "TEST_WATCH_H", got, "2")
+	}
+	if got := os.Getenv("TEST_WATCH_ENV"); got != "from-env" {
+		t.Errorf("env[%q] = %q, want the variable set before watching", "TEST_WATCH_ENV", got)
+	}
+}
+
+func TestWatch_SelfReferenceKeepsOriginalValue(t *testing.T) {
+	cleanupKeys(t, []string{"TEST_WATCH_K"})
+	t.Setenv("TEST_WATCH_PATH", "/usr/bin")
+
+	p := writeTestFile(t, "watch.env", "TEST_WATCH_PATH=${TEST_WATCH_PATH}:/opt/bin\nTEST_WATCH_K=1\n")
+	diffs, _ := startWatch(t, []string{p}, lxenv.WithApply(true))
+	if got := os.Getenv("TEST_WATCH_PATH"); got != "/usr/bin:/opt/bin" {
+		t.Fatalf("env[%q] = %q, want %q", "TEST_WATCH_PATH", got, "/usr/bin:/opt/bin")
+	}
+
+	rewriteFile(t, p, "TEST_WATCH_PATH=${TEST_WATCH_PATH}:/opt/bin\nTEST_WATCH_K=2\n", time.Now().Add(time.Hour))
+	d := receive(t, diffs, "diff")
+	if _, ok := d.Changed["TEST_WATCH_PATH"]; ok {
+		t.Errorf("diff.Changed = %+v, want TEST_WATCH_PATH unchanged", d.Changed)
+	}
+	if got := os.Getenv("TEST_WATCH_PATH"); got != "/usr/bin:/opt/bin" {
+		t.Errorf("env[%q] = %q, want %q", "TEST_WATCH_PATH", got, "/usr/bin:/opt/bin")
+	}
+}
+
+func TestWatch_Wait(t *testing.T) {
+	p := writeTestFile(t, "watch.env", "TEST_WATCH_I=1\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	w, err := lxenv.Watch(ctx, []string{p}, nil, lxenv.WithPollInterval(watchInterval))
+	if err != nil {
+		t.Fatalf("Watch() unexpected error: %v", err)
+	}
+
+	select {
+	case <-w.Done():
+		t.Fatal("Done() closed while watching")
+	default:
+	}
+	cancel()
+	select {
+	case <-w.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Done() not closed after the context was cancelled")
+	}
+	w.Wait()
+}
+
+func TestWatch_InitialError(t *testing.T) {
+	w, err := lxenv.Watch(context.Background(), []string{"testdata/nonexistent.env"}, func(lxenv.Diff) {})
+	if err == nil || w != nil {
+		t.Error("Watch() expected error for missing file, got nil")
+	}
+}
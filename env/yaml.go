@@ -0,0 +1,1267 @@
+package lxenv
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// yamlKind identifies the shape of a parsed YAML node.
+type yamlKind int
+
+const (
+	yamlScalar yamlKind = iota
+	yamlMapping
+	yamlSequence
+)
+
+// yamlNode is a parsed YAML value. Scalars keep their decoded text; a null
+// scalar (empty, ~ or null) has an empty value.
+type yamlNode struct {
+	kind    yamlKind
+	value   string
+	keys    []string // mapping keys in document order
+	values  map[string]*yamlNode
+	items   []*yamlNode
+	line    int
+	literal bool // single-quoted scalar, never expanded
+}
+
+func newYAMLScalar(value string, line int) *yamlNode {
+	return &yamlNode{kind: yamlScalar, value: value, line: line}
+}
+
+func newYAMLMapping(line int) *yamlNode {
+	return &yamlNode{kind: yamlMapping, values: make(map[string]*yamlNode), line: line}
+}
+
+// set adds or replaces a mapping entry, keeping the first-seen key order.
+func (n *yamlNode) set(key string, value *yamlNode) {
+	if _, ok := n.values[key]; !ok {
+		n.keys = append(n.keys, key)
+	}
+	n.values[key] = value
+}
+
+// yamlParser is a block-and-flow YAML parser that works line by line.
+// It supports block mappings and sequences, block scalars (| and >),
+// flow collections, quoted scalars, anchors, aliases, merge keys (<<)
+// and multiple documents. Tags are accepted and ignored.
+type yamlParser struct {
+	lines   []string
+	n       int // index of the current line
+	anchors map[string]*yamlNode
+}
+
+// parseYAML parses every document in r.
+func parseYAML(r io.Reader) ([]*yamlNode, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	lines := strings.Split(text, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSuffix(lines[i], "\r")
+	}
+
+	p := &yamlParser{lines: lines}
+	var docs []*yamlNode
+	for {
+		doc, more, err := p.parseDocument()
+		if err != nil {
+			return nil, err
+		}
+		if doc != nil {
+			docs = append(docs, doc)
+		}
+		if !more {
+			return docs, nil
+		}
+	}
+}
+
+func (p *yamlParser) errorf(line, col int, format string, args ...any) error {
+	return fmt.Errorf("line %d, column %d: %s", line, col, fmt.Sprintf(format, args...))
+}
+
+// parseDocument parses one document and reports whether more may follow.
+func (p *yamlParser) parseDocument() (*yamlNode, bool, error) {
+	p.anchors = make(map[string]*yamlNode)
+
+	// skip directives, blank lines and comments before the document
+	for p.n < len(p.lines) {
+		trimmed := strings.TrimSpace(p.lines[p.n])
+		if trimmed == "" || trimmed[0] == '#' || strings.HasPrefix(p.lines[p.n], "%") {
+			p.n++
+			continue
+		}
+		break
+	}
+	if p.n >= len(p.lines) {
+		return nil, false, nil
+	}
+
+	explicit := false
+	if isDocMarker(p.lines[p.n], "---") {
+		explicit = true
+		// content may follow the marker on the same line, e.g. "--- |"
+		p.lines[p.n] = "    " + strings.TrimLeft(p.lines[p.n][3:], " \t")
+	}
+
+	doc, err := p.parseNode(-1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, content, ok, err := p.next()
+	if err != nil {
+		return nil, false, err
+	}
+	if ok {
+		return nil, false, p.errorf(p.n+1, p.indentOf(p.n)+1, "unexpected content %q", content)
+	}
+	if p.n < len(p.lines) && isDocMarker(p.lines[p.n], "...") {
+		p.n++
+	}
+	more := p.n < len(p.lines)
+
+	if doc == nil && !explicit && !more {
+		return nil, false, nil
+	}
+	return doc, more, nil
+}
+
+// next skips blank and comment lines and returns the indentation and content
+// of the next significant line without consuming it. ok is false at the end of
+// input or at a document marker.
+func (p *yamlParser) next() (indent int, content string, ok bool, err error) {
+	for ; p.n < len(p.lines); p.n++ {
+		line := p.lines[p.n]
+		if isDocMarker(line, "---") || isDocMarker(line, "...") {
+			return 0, "", false, nil
+		}
+
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" || trimmed[0] == '#' {
+			continue
+		}
+
+		indent = len(line) - len(strings.TrimLeft(line, " "))
+		if line[indent] == '\t' {
+			return 0, "", false, p.errorf(p.n+1, indent+1, "found tab character in indentation")
+		}
+		return indent, trimmed, true, nil
+	}
+	return 0, "", false, nil
+}
+
+func (p *yamlParser) indentOf(i int) int {
+	line := p.lines[i]
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// parseNode parses the block node on the next significant line. The node must
+// be indented deeper than parent; nil is returned when there is none.
+func (p *yamlParser) parseNode(parent int) (*yamlNode, error) {
+	indent, content, ok, err := p.next()
+	if err != nil || !ok || indent <= parent {
+		return nil, err
+	}
+
+	line, col := p.n+1, indent+1
+
+	anchor := ""
+	if content[0] == '&' || content[0] == '!' {
+		var rest string
+		anchor, rest, _, err = p.parseProperties(content, line, col)
+		if err != nil {
+			return nil, err
+		}
+		if isBlankOrComment(rest) {
+			// properties on their own line apply to the node below
+			p.n++
+			node, err := p.parseNode(parent)
+			if err != nil {
+				return nil, err
+			}
+			if node == nil {
+				node = newYAMLScalar("", line)
+			}
+			p.setAnchor(anchor, node)
+			return node, nil
+		}
+		p.lines[p.n] = strings.Repeat(" ", indent) + rest
+		content = rest
+	}
+
+	var node *yamlNode
+	if isSeqEntry(content) {
+		node, err = p.parseSequence(indent)
+	} else if _, _, _, isKey, keyErr := splitYAMLKey(content); keyErr != nil {
+		return nil, p.errorf(line, col, "%v", keyErr)
+	} else if isKey {
+		node, err = p.parseMapping(indent)
+	} else {
+		p.n++
+		node, err = p.parseInline(content, line, col, parent)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.setAnchor(anchor, node)
+	return node, nil
+}
+
+func (p *yamlParser) setAnchor(anchor string, node *yamlNode) {
+	if anchor != "" {
+		p.anchors[anchor] = node
+	}
+}
+
+// parseMapping parses a block mapping whose keys sit at indent.
+func (p *yamlParser) parseMapping(indent int) (*yamlNode, error) {
+	node := newYAMLMapping(p.n + 1)
+	var merges []*yamlNode
+
+	for {
+		ind, content, ok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok || ind < indent {
+			break
+		}
+
+		line := p.n + 1
+		if ind > indent {
+			return nil, p.errorf(line, ind+1, "unexpected indentation")
+		}
+		if isSeqEntry(content) {
+			return nil, p.errorf(line, ind+1, "unexpected sequence entry in mapping")
+		}
+
+		key, rest, offset, isKey, err := splitYAMLKey(content)
+		if err != nil {
+			return nil, p.errorf(line, ind+1, "%v", err)
+		}
+		if !isKey {
+			return nil, p.errorf(line, ind+1, "expected a mapping key, found %q", content)
+		}
+
+		value, err := p.parseValue(rest, line, ind+1+offset, indent)
+		if err != nil {
+			return nil, err
+		}
+
+		if key == "<<" {
+			merges = append(merges, value)
+			continue
+		}
+		node.set(key, value)
+	}
+
+	for _, merge := range merges {
+		if err := mergeYAML(node, merge); err != nil {
+			return nil, p.errorf(merge.line, 1, "%v", err)
+		}
+	}
+	return node, nil
+}
+
+// mergeYAML applies a merge key (<<). Explicit keys win over merged ones and
+// earlier merge sources win over later ones.
+func mergeYAML(dst, src *yamlNode) error {
+	switch src.kind {
+	case yamlMapping:
+		for _, k := range src.keys {
+			if _, exists := dst.values[k]; !exists {
+				dst.set(k, src.values[k])
+			}
+		}
+		return nil
+	case yamlSequence:
+		for _, item := range src.items {
+			if item.kind != yamlMapping {
+				return fmt.Errorf("merge key sequence must contain only mappings")
+			}
+			if err := mergeYAML(dst, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("merge key value must be a mapping or a sequence of mappings")
+}
+
+// parseValue parses the value that follows "key:" in a block mapping at indent.
+func (p *yamlParser) parseValue(text string, line, col, indent int) (*yamlNode, error) {
+	anchor, text, col, err := p.parseProperties(text, line, col)
+	if err != nil {
+		return nil, err
+	}
+
+	var node *yamlNode
+	if isBlankOrComment(text) {
+		p.n++
+		node, err = p.parseNode(indent)
+		if err == nil && node == nil {
+			// compact sequence: "key:\n- a" at the key's own indentation
+			if ind, content, ok, _ := p.next(); ok && ind == indent && isSeqEntry(content) {
+				node, err = p.parseSequence(indent)
+			}
+		}
+		if err == nil && node == nil {
+			node = newYAMLScalar("", line)
+		}
+	} else {
+		p.n++
+		node, err = p.parseInline(text, line, col, indent)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.setAnchor(anchor, node)
+	return node, nil
+}
+
+// parseSequence parses a block sequence whose dashes sit at indent.
+func (p *yamlParser) parseSequence(indent int) (*yamlNode, error) {
+	node := &yamlNode{kind: yamlSequence, line: p.n + 1}
+
+	for {
+		ind, content, ok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok || ind < indent {
+			break
+		}
+
+		line := p.n + 1
+		if ind > indent {
+			return nil, p.errorf(line, ind+1, "unexpected indentation")
+		}
+		if !isSeqEntry(content) {
+			// a compact sequence ends at the next key of its parent mapping
+			break
+		}
+
+		// Blank out the dash so the item's content keeps its own column and
+		// nested mappings ("- name: x") line up with their following keys.
+		raw := p.lines[p.n]
+		after := raw[ind+1:]
+		sep := len(after) - len(strings.TrimLeft(after, " \t"))
+		p.lines[p.n] = raw[:ind] + strings.Repeat(" ", 1+sep) + after[sep:]
+
+		item, err := p.parseNode(indent)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			item = newYAMLScalar("", line)
+		}
+		node.items = append(node.items, item)
+	}
+	return node, nil
+}
+
+// parseInline parses a value that starts on an already consumed line: an
+// alias, a block scalar, a flow collection, a quoted scalar or a plain scalar.
+// Continuation lines must be indented deeper than parent.
+func (p *yamlParser) parseInline(text string, line, col, parent int) (*yamlNode, error) {
+	anchor, text, col, err := p.parseProperties(text, line, col)
+	if err != nil {
+		return nil, err
+	}
+	if text == "" {
+		node := newYAMLScalar("", line)
+		p.setAnchor(anchor, node)
+		return node, nil
+	}
+
+	var node *yamlNode
+	switch text[0] {
+	case '*':
+		name, rest := splitYAMLToken(text[1:])
+		if !isBlankOrComment(rest) {
+			return nil, p.errorf(line, col, "unexpected content after alias: %q", rest)
+		}
+		alias, ok := p.anchors[name]
+		if !ok {
+			return nil, p.errorf(line, col, "unknown anchor %q", name)
+		}
+		node = alias
+	case '|', '>':
+		node, err = p.parseBlockScalar(text, line, col, parent)
+	case '[', '{':
+		node, err = p.parseFlow(text, line, col)
+	case '"', '\'':
+		node, err = p.parseQuoted(text, line, col)
+	case '@', '`':
+		return nil, p.errorf(line, col, "reserved character %q cannot start a plain scalar", text[0])
+	default:
+		node, err = p.parsePlain(text, line, col, parent)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.setAnchor(anchor, node)
+	return node, nil
+}
+
+// parseProperties strips leading anchors (&name) and tags (!tag) from text.
+// Tags are ignored; the anchor name is returned.
+func (p *yamlParser) parseProperties(text string, line, col int) (string, string, int, error) {
+	anchor := ""
+	for {
+		trimmed := strings.TrimLeft(text, " \t")
+		col += len(text) - len(trimmed)
+		text = trimmed
+		if text == "" || (text[0] != '&' && text[0] != '!') {
+			return anchor, text, col, nil
+		}
+
+		name, rest := splitYAMLToken(text[1:])
+		if text[0] == '&' {
+			if name == "" {
+				return "", "", 0, p.errorf(line, col, "anchor name is empty")
+			}
+			anchor = name
+		}
+		col += len(text) - len(rest)
+		text = rest
+	}
+}
+
+// parseBlockScalar parses a literal (|) or folded (>) block scalar.
+func (p *yamlParser) parseBlockScalar(header string, line, col, parent int) (*yamlNode, error) {
+	folded := header[0] == '>'
+	chomp := byte(0)
+	explicitIndent := 0
+
+	i := 1
+indicators:
+	for ; i < len(header) && i < 3; i++ {
+		switch c := header[i]; {
+		case (c == '+' || c == '-') && chomp == 0:
+			chomp = c
+		case c >= '1' && c <= '9' && explicitIndent == 0:
+			explicitIndent = int(c - '0')
+		default:
+			break indicators
+		}
+	}
+	if !isBlankOrComment(header[i:]) {
+		return nil, p.errorf(line, col+i, "invalid block scalar header %q", header)
+	}
+
+	contentIndent := -1
+	if explicitIndent > 0 {
+		base := parent
+		if base < 0 {
+			base = 0
+		}
+		contentIndent = base + explicitIndent
+	}
+
+	var lines []string
+	for ; p.n < len(p.lines); p.n++ {
+		raw := p.lines[p.n]
+		if isDocMarker(raw, "---") || isDocMarker(raw, "...") {
+			break
+		}
+		if strings.TrimLeft(raw, " ") == "" {
+			if contentIndent >= 0 && len(raw) > contentIndent {
+				lines = append(lines, raw[contentIndent:])
+			} else {
+				lines = append(lines, "")
+			}
+			continue
+		}
+
+		indent := len(raw) - len(strings.TrimLeft(raw, " "))
+		if contentIndent < 0 {
+			if indent <= parent {
+				break
+			}
+			contentIndent = indent
+		}
+		if indent < contentIndent {
+			break
+		}
+		lines = append(lines, raw[contentIndent:])
+	}
+
+	// trailing blank lines only matter for keep chomping
+	trailing := 0
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+
+	var body string
+	if folded {
+		body = foldBlockLines(lines)
+	} else {
+		body = strings.Join(lines, "\n")
+	}
+
+	switch chomp {
+	case '-':
+	case '+':
+		if len(lines) > 0 {
+			body += "\n"
+		}
+		body += strings.Repeat("\n", trailing)
+	default:
+		if len(lines) > 0 {
+			body += "\n"
+		}
+	}
+	return newYAMLScalar(body, line), nil
+}
+
+// foldBlockLines joins the content lines of a folded block scalar. Line breaks
+// between regular lines become spaces; breaks around blank or more-indented
+// lines are kept.
+func foldBlockLines(lines []string) string {
+	var b strings.Builder
+	for i, l := range lines {
+		if i > 0 {
+			prev := lines[i-1]
+			switch {
+			case prev == "" || isMoreIndented(prev) || isMoreIndented(l):
+				b.WriteByte('\n')
+			case l == "":
+				// the break before the first blank line is folded away
+			default:
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(l)
+	}
+	return b.String()
+}
+
+func isMoreIndented(s string) bool {
+	return s != "" && (s[0] == ' ' || s[0] == '\t')
+}
+
+// parsePlain parses a plain scalar, folding continuation lines indented deeper than parent.
+func (p *yamlParser) parsePlain(text string, line, col, parent int) (*yamlNode, error) {
+	first := stripYAMLComment(text)
+	if i := plainMappingIndicator(first); i >= 0 {
+		return nil, p.errorf(line, col+i, "mapping values are not allowed in this context")
+	}
+	parts := []string{first}
+
+	// a comment ends the scalar
+	commented := first != strings.TrimRight(text, " \t")
+	for !commented && p.n < len(p.lines) {
+		raw := p.lines[p.n]
+		if isDocMarker(raw, "---") || isDocMarker(raw, "...") {
+			break
+		}
+		trimmed := strings.TrimSpace(raw)
+		if trimmed == "" {
+			// blank lines only belong to the scalar if it continues afterwards
+			j := p.n
+			for j < len(p.lines) && strings.TrimSpace(p.lines[j]) == "" {
+				j++
+			}
+			if j == len(p.lines) || p.indentOf(j) <= parent || strings.HasPrefix(strings.TrimSpace(p.lines[j]), "#") {
+				break
+			}
+			for ; p.n < j; p.n++ {
+				parts = append(parts, "")
+			}
+			continue
+		}
+		if p.indentOf(p.n) <= parent || trimmed[0] == '#' {
+			break
+		}
+
+		cont := stripYAMLComment(trimmed)
+		if i := plainMappingIndicator(cont); i >= 0 {
+			return nil, p.errorf(p.n+1, p.indentOf(p.n)+i+1, "mapping values are not allowed in this context")
+		}
+		parts = append(parts, cont)
+		p.n++
+		commented = cont != trimmed
+	}
+
+	value := foldLines(parts)
+	if isYAMLNull(value) {
+		value = ""
+	}
+	return newYAMLScalar(value, line), nil
+}
+
+// parseQuoted parses a single- or double-quoted scalar that may span lines.
+func (p *yamlParser) parseQuoted(text string, line, col int) (*yamlNode, error) {
+	quote := text[0]
+	src := text
+	for {
+		end := findClosingQuote(src, quote)
+		if end >= 0 {
+			if rest := src[end+1:]; !isBlankOrComment(rest) {
+				return nil, p.errorf(line, col, "unexpected content after quoted scalar: %q", strings.TrimSpace(rest))
+			}
+			value, err := decodeQuoted(src[1:end], quote)
+			if err != nil {
+				return nil, p.errorf(line, col, "%v", err)
+			}
+			node := newYAMLScalar(value, line)
+			node.literal = quote == '\''
+			return node, nil
+		}
+		if p.n >= len(p.lines) || isDocMarker(p.lines[p.n], "---") || isDocMarker(p.lines[p.n], "...") {
+			return nil, p.errorf(line, col, "unterminated quoted scalar")
+		}
+		src += "\n" + p.lines[p.n]
+		p.n++
+	}
+}
+
+// parseFlow parses a flow collection ([...] or {...}) that may span lines.
+func (p *yamlParser) parseFlow(text string, line, col int) (*yamlNode, error) {
+	src := text
+	for {
+		end, err := findFlowEnd(src)
+		if err != nil {
+			return nil, p.errorf(line, col, "%v", err)
+		}
+		if end >= 0 {
+			if rest := src[end:]; !isBlankOrComment(rest) {
+				return nil, p.errorf(line, col, "unexpected content after flow collection: %q", strings.TrimSpace(rest))
+			}
+			fp := &yamlFlowParser{p: p, src: src[:end], line: line, col: col}
+			return fp.parse()
+		}
+		if p.n >= len(p.lines) || isDocMarker(p.lines[p.n], "---") || isDocMarker(p.lines[p.n], "...") {
+			return nil, p.errorf(line, col, "unterminated flow collection")
+		}
+		src += "\n" + p.lines[p.n]
+		p.n++
+	}
+}
+
+// findFlowEnd returns the offset just past the bracket that closes the flow
+// collection at the start of src, or -1 if it is not closed yet.
+func findFlowEnd(src string) (int, error) {
+	depth := 0
+	prev := byte(' ')
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case (c == '"' || c == '\'') && strings.IndexByte(" \t\n[{,:", prev) >= 0:
+			end := findClosingQuote(src[i:], c)
+			if end < 0 {
+				return -1, nil
+			}
+			i += end
+		case c == '#' && (prev == ' ' || prev == '\t' || prev == '\n'):
+			nl := strings.IndexByte(src[i:], '\n')
+			if nl < 0 {
+				return -1, nil
+			}
+			i += nl
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+			if depth == 0 {
+				return i + 1, nil
+			}
+			if depth < 0 {
+				return -1, fmt.Errorf("unbalanced %q in flow collection", c)
+			}
+		}
+		prev = src[i]
+	}
+	return -1, nil
+}
+
+// findClosingQuote returns the index of the quote that closes the quoted
+// scalar at the start of s, or -1.
+func findClosingQuote(s string, quote byte) int {
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case s[i] == quote:
+			if quote == '\'' && i+1 < len(s) && s[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+// decodeQuoted folds and unescapes the body of a quoted scalar.
+func decodeQuoted(body string, quote byte) (string, error) {
+	lines := strings.Split(body, "\n")
+	if quote == '"' {
+		// an escaped line break joins lines without a space
+		for i := 0; i < len(lines)-1; i++ {
+			l := strings.TrimRight(lines[i], " \t")
+			if n := len(l) - len(strings.TrimRight(l, "\\")); n%2 == 1 {
+				lines[i] = l[:len(l)-1] + strings.TrimLeft(lines[i+1], " \t")
+				lines = append(lines[:i+1], lines[i+2:]...)
+				i--
+			}
+		}
+	}
+	for i := range lines {
+		if i > 0 {
+			lines[i] = strings.TrimLeft(lines[i], " \t")
+		}
+		if i < len(lines)-1 {
+			lines[i] = strings.TrimRight(lines[i], " \t")
+		}
+	}
+	folded := foldLines(lines)
+
+	if quote == '\'' {
+		return strings.ReplaceAll(folded, "''", "'"), nil
+	}
+	return unescapeDoubleQuoted(folded)
+}
+
+// foldLines joins the lines of a multi-line flow scalar: a single line break
+// becomes a space and each empty line becomes a newline.
+func foldLines(lines []string) string {
+	var b strings.Builder
+	b.WriteString(lines[0])
+	breaks := 0
+	for _, l := range lines[1:] {
+		if l == "" {
+			breaks++
+			continue
+		}
+		if breaks > 0 {
+			b.WriteString(strings.Repeat("\n", breaks))
+		} else {
+			b.WriteByte(' ')
+		}
+		breaks = 0
+		b.WriteString(l)
+	}
+	if breaks > 0 {
+		b.WriteString(strings.Repeat("\n", breaks-1))
+		if breaks == 1 {
+			b.WriteByte(' ')
+		}
+	}
+	return b.String()
+}
+
+// unescapeDoubleQuoted decodes YAML double-quoted escape sequences.
+func unescapeDoubleQuoted(s string) (string, error) {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i >= len(s) {
+			return "", fmt.Errorf("trailing backslash in double-quoted scalar")
+		}
+
+		switch c := s[i]; c {
+		case '0':
+			b.WriteByte(0)
+		case 'a':
+			b.WriteByte('\a')
+		case 'b':
+			b.WriteByte('\b')
+		case 't', '\t':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'v':
+			b.WriteByte('\v')
+		case 'f':
+			b.WriteByte('\f')
+		case 'r':
+			b.WriteByte('\r')
+		case 'e':
+			b.WriteByte(0x1b)
+		case ' ', '"', '/', '\\':
+			b.WriteByte(c)
+		case 'N':
+			b.WriteRune('\u0085')
+		case '_':
+			b.WriteRune('\u00a0')
+		case 'L':
+			b.WriteRune('\u2028')
+		case 'P':
+			b.WriteRune('\u2029')
+		case 'x', 'u', 'U':
+			width := 2
+			if c == 'u' {
+				width = 4
+			} else if c == 'U' {
+				width = 8
+			}
+			if i+width >= len(s) {
+				return "", fmt.Errorf("short escape sequence \\%c", c)
+			}
+			code, err := strconv.ParseUint(s[i+1:i+1+width], 16, 32)
+			if err != nil || !utf8.ValidRune(rune(code)) {
+				return "", fmt.Errorf("invalid escape sequence \\%c%s", c, s[i+1:i+1+width])
+			}
+			b.WriteRune(rune(code))
+			i += width
+		default:
+			return "", fmt.Errorf("invalid escape sequence \\%c", c)
+		}
+	}
+	return b.String(), nil
+}
+
+// yamlFlowParser parses a complete flow collection held in src, which starts
+// at the given line and column of the input.
+type yamlFlowParser struct {
+	p         *yamlParser
+	src       string
+	i         int
+	line, col int
+}
+
+func (f *yamlFlowParser) errorf(format string, args ...any) error {
+	line, col := f.line, f.col
+	for j := 0; j < f.i && j < len(f.src); j++ {
+		if f.src[j] == '\n' {
+			line++
+			col = 0
+		}
+		col++
+	}
+	return f.p.errorf(line, col, format, args...)
+}
+
+func (f *yamlFlowParser) curLine() int {
+	return f.line + strings.Count(f.src[:f.i], "\n")
+}
+
+func (f *yamlFlowParser) parse() (*yamlNode, error) {
+	node, err := f.parseNode()
+	if err != nil {
+		return nil, err
+	}
+	f.skipSpace()
+	if f.i < len(f.src) {
+		return nil, f.errorf("unexpected character %q", f.src[f.i])
+	}
+	return node, nil
+}
+
+// skipSpace skips whitespace, line breaks and comments.
+func (f *yamlFlowParser) skipSpace() {
+	for f.i < len(f.src) {
+		switch c := f.src[f.i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			f.i++
+		case c == '#' && (f.i == 0 || strings.IndexByte(" \t\n", f.src[f.i-1]) >= 0):
+			for f.i < len(f.src) && f.src[f.i] != '\n' {
+				f.i++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (f *yamlFlowParser) peek() byte {
+	if f.i < len(f.src) {
+		return f.src[f.i]
+	}
+	return 0
+}
+
+func (f *yamlFlowParser) parseNode() (*yamlNode, error) {
+	f.skipSpace()
+
+	anchor := ""
+	for c := f.peek(); c == '&' || c == '!'; c = f.peek() {
+		name, rest := splitYAMLToken(f.src[f.i+1:])
+		if c == '&' {
+			if name == "" {
+				return nil, f.errorf("anchor name is empty")
+			}
+			anchor = name
+		}
+		f.i = len(f.src) - len(rest)
+		f.skipSpace()
+	}
+
+	line := f.curLine()
+	var node *yamlNode
+	var err error
+	switch c := f.peek(); c {
+	case '[':
+		node, err = f.parseSequence()
+	case '{':
+		node, err = f.parseMapping()
+	case '"', '\'':
+		end := findClosingQuote(f.src[f.i:], c)
+		if end < 0 {
+			return nil, f.errorf("unterminated quoted scalar")
+		}
+		var value string
+		value, err = decodeQuoted(f.src[f.i+1:f.i+end], c)
+		if err != nil {
+			return nil, f.errorf("%v", err)
+		}
+		f.i += end + 1
+		node = newYAMLScalar(value, line)
+		node.literal = c == '\''
+	case '*':
+		name, rest := splitYAMLToken(f.src[f.i+1:])
+		alias, ok := f.p.anchors[name]
+		if !ok {
+			return nil, f.errorf("unknown anchor %q", name)
+		}
+		f.i = len(f.src) - len(rest)
+		node = alias
+	default:
+		node = newYAMLScalar(f.parsePlain(), line)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if anchor != "" {
+		f.p.anchors[anchor] = node
+	}
+	return node, nil
+}
+
+// parsePlain reads a plain scalar up to the next flow indicator.
+func (f *yamlFlowParser) parsePlain() string {
+	start := f.i
+	for f.i < len(f.src) {
+		c := f.src[f.i]
+		if strings.IndexByte(",[]{}", c) >= 0 {
+			break
+		}
+		if c == ':' && (f.i+1 == len(f.src) || strings.IndexByte(" \t\n,[]{}", f.src[f.i+1]) >= 0) {
+			break
+		}
+		if c == '#' && f.i > start && strings.IndexByte(" \t\n", f.src[f.i-1]) >= 0 {
+			break
+		}
+		f.i++
+	}
+
+	lines := strings.Split(f.src[start:f.i], "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	value := strings.TrimSpace(foldLines(lines))
+	if isYAMLNull(value) {
+		return ""
+	}
+	return value
+}
+
+func (f *yamlFlowParser) parseSequence() (*yamlNode, error) {
+	node := &yamlNode{kind: yamlSequence, line: f.curLine()}
+	f.i++ // [
+
+	for {
+		f.skipSpace()
+		if f.peek() == ']' {
+			f.i++
+			return node, nil
+		}
+
+		item, err := f.parseNode()
+		if err != nil {
+			return nil, err
+		}
+
+		// single-pair mapping inside a sequence: [a: 1, b: 2]
+		f.skipSpace()
+		if f.peek() == ':' {
+			f.i++
+			value, err := f.parseEntryValue(']')
+			if err != nil {
+				return nil, err
+			}
+			if item.kind != yamlScalar {
+				return nil, f.errorf("flow mapping keys must be scalars")
+			}
+			pair := newYAMLMapping(item.line)
+			pair.set(item.value, value)
+			item = pair
+		}
+		node.items = append(node.items, item)
+
+		if err := f.expectSeparator(']'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (f *yamlFlowParser) parseMapping() (*yamlNode, error) {
+	node := newYAMLMapping(f.curLine())
+	f.i++ // {
+
+	for {
+		f.skipSpace()
+		if f.peek() == '}' {
+			f.i++
+			return node, nil
+		}
+
+		key, err := f.parseNode()
+		if err != nil {
+			return nil, err
+		}
+		if key.kind != yamlScalar {
+			return nil, f.errorf("flow mapping keys must be scalars")
+		}
+
+		value := newYAMLScalar("", key.line)
+		f.skipSpace()
+		if f.peek() == ':' {
+			f.i++
+			if value, err = f.parseEntryValue('}'); err != nil {
+				return nil, err
+			}
+		}
+
+		if key.value == "<<" {
+			if err := mergeYAML(node, value); err != nil {
+				return nil, f.errorf("%v", err)
+			}
+		} else {
+			node.set(key.value, value)
+		}
+
+		if err := f.expectSeparator('}'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseEntryValue parses the value after ':' in a flow entry, which may be empty.
+func (f *yamlFlowParser) parseEntryValue(closer byte) (*yamlNode, error) {
+	f.skipSpace()
+	if c := f.peek(); c == ',' || c == closer {
+		return newYAMLScalar("", f.curLine()), nil
+	}
+	return f.parseNode()
+}
+
+// expectSeparator consumes a ',' or stops before closer.
+func (f *yamlFlowParser) expectSeparator(closer byte) error {
+	f.skipSpace()
+	switch f.peek() {
+	case ',':
+		f.i++
+		return nil
+	case closer:
+		return nil
+	case 0:
+		return f.errorf("unterminated flow collection")
+	}
+	return f.errorf("expected ',' or %q, found %q", closer, f.peek())
+}
+
+// splitYAMLKey splits a block mapping entry "key: value" and returns the key,
+// the text after the colon and its offset within content. isKey is false when
+// content is not a mapping entry.
+func splitYAMLKey(content string) (key, rest string, offset int, isKey bool, err error) {
+	switch content[0] {
+	case '"', '\'':
+		end := findClosingQuote(content, content[0])
+		if end < 0 {
+			return "", "", 0, false, nil
+		}
+		after := strings.TrimLeft(content[end+1:], " \t")
+		if !isMappingIndicator(after, 0) {
+			return "", "", 0, false, nil
+		}
+		key, err = decodeQuoted(content[1:end], content[0])
+		if err != nil {
+			return "", "", 0, false, err
+		}
+		offset = len(content) - len(after) + 1
+		return key, content[offset:], offset, true, nil
+	case '[', '{', '|', '>', '*', '#':
+		return "", "", 0, false, nil
+	case '?':
+		if len(content) == 1 || content[1] == ' ' || content[1] == '\t' {
+			return "", "", 0, false, fmt.Errorf("complex mapping keys are not supported")
+		}
+	}
+
+	i := plainMappingIndicator(stripYAMLComment(content))
+	if i < 0 {
+		return "", "", 0, false, nil
+	}
+	key = strings.TrimRight(content[:i], " \t")
+	if key == "" {
+		return "", "", 0, false, fmt.Errorf("empty mapping key")
+	}
+	return key, content[i+1:], i + 1, true, nil
+}
+
+// plainMappingIndicator returns the index of the first ':' in s that is
+// followed by a space or the end of s, or -1.
+func plainMappingIndicator(s string) int {
+	for i := 0; i < len(s); i++ {
+		if isMappingIndicator(s, i) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isMappingIndicator(s string, i int) bool {
+	return i < len(s) && s[i] == ':' && (i+1 == len(s) || s[i+1] == ' ' || s[i+1] == '\t')
+}
+
+// stripYAMLComment removes a trailing " # comment" from a plain scalar.
+func stripYAMLComment(s string) string {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '#' && (s[i-1] == ' ' || s[i-1] == '\t') {
+			return strings.TrimRight(s[:i], " \t")
+		}
+	}
+	return strings.TrimRight(s, " \t")
+}
+
+// splitYAMLToken splits an anchor, alias or tag name from the text after it.
+func splitYAMLToken(s string) (string, string) {
+	end := strings.IndexAny(s, " \t\n,[]{}")
+	if end < 0 {
+		return s, ""
+	}
+	return s[:end], s[end:]
+}
+
+func isSeqEntry(content string) bool {
+	return content == "-" || strings.HasPrefix(content, "- ") || strings.HasPrefix(content, "-\t")
+}
+
+func isDocMarker(line, marker string) bool {
+	return strings.HasPrefix(line, marker) &&
+		(len(line) == len(marker) || line[len(marker)] == ' ' || line[len(marker)] == '\t')
+}
+
+func isBlankOrComment(s string) bool {
+	s = strings.TrimLeft(s, " \t")
+	return s == "" || s[0] == '#'
+}
+
+func isYAMLNull(s string) bool {
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return true
+	}
+	return false
+}
+
+// Limits on the expansion of aliases, so that a small document of nested
+// aliases ("billion laughs") cannot flatten to millions of keys. Up to
+// yamlMaxNodes nodes are always flattened; beyond that, the flattened tree may
+// be at most yamlMaxAliasRatio times as large as the document itself.
+const (
+	yamlMaxNodes      = 100000
+	yamlMaxAliasRatio = 10
+)
+
+// yamlExpansion counts the nodes flattened from the documents of one file.
+type yamlExpansion struct {
+	nodes    map[*yamlNode]bool // distinct nodes, an alias shares its anchor's node
+	expanded int                // nodes flattened, counting each alias again
+}
+
+// visit counts n and fails once aliases expand beyond the limits.
+func (e *yamlExpansion) visit(n *yamlNode) error {
+	if e.nodes == nil {
+		e.nodes = make(map[*yamlNode]bool)
+	}
+	e.nodes[n] = true
+	e.expanded++
+	if e.expanded > yamlMaxNodes && e.expanded > yamlMaxAliasRatio*len(e.nodes) {
+		return fmt.Errorf("line %d: aliases expand to too many nodes (more than %d)", n.line, yamlMaxNodes)
+	}
+	return nil
+}
+
+// flattenYAML writes the leaves of n into pairs using dot-notation keys, and
+// the line of every key and prefix into lines. exp counts the flattened nodes
+// across the documents of a file.
+// Sequence items are addressed by index (servers.0.host); a sequence of
+// scalars is also stored under its own key as a comma-joined list.
+func flattenYAML(prefix string, n *yamlNode, pairs map[string]string, lines map[string]int, literals map[string]bool, exp *yamlExpansion) error {
+	if err := exp.visit(n); err != nil {
+		return err
+	}
+	if prefix != "" {
+		lines[prefix] = n.line
+	}
+	switch n.kind {
+	case yamlMapping:
+		for _, k := range n.keys {
+			if err := flattenYAML(joinKey(prefix, k), n.values[k], pairs, lines, literals, exp); err != nil {
+				return err
+			}
+		}
+	case yamlSequence:
+		scalars := make([]string, 0, len(n.items))
+		literal := len(n.items) > 0
+		for i, item := range n.items {
+			if err := flattenYAML(joinKey(prefix, strconv.Itoa(i)), item, pairs, lines, literals, exp); err != nil {
+				return err
+			}
+			if item.kind == yamlScalar {
+				scalars = append(scalars, item.value)
+			}
+			literal = literal && item.literal
+		}
+		if prefix != "" && len(scalars) == len(n.items) {
+			pairs[prefix] = strings.Join(scalars, ",")
+			setLiteral(literals, prefix, literal)
+		}
+	default:
+		if prefix == "" {
+			if n.value == "" {
+				return nil
+			}
+			return fmt.Errorf("line %d: document root must be a mapping or a sequence", n.line)
+		}
+		pairs[prefix] = n.value
+		setLiteral(literals, prefix, n.literal)
+	}
+	return nil
+}
+
+// setLiteral records whether the value stored under key is literal, so that a
+// later document overriding the key is expanded again.
+func setLiteral(literals map[string]bool, key string, literal bool) {
+	if literal {
+		literals[key] = true
+	} else {
+		delete(literals, key)
+	}
+}
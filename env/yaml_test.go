@@ -0,0 +1,230 @@
+package lxenv
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestYAMLFileParser_Parse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]string
+	}{
+		{
+			name:  "nested mapping",
+			input: "a:\n  b:\n    c: 1\n  d: two\n",
+			want:  map[string]string{"a.b.c": "1", "a.d": "two"},
+		},
+		{
+			name:  "scalar sequence",
+			input: "tags:\n  - x\n  - y\n",
+			want:  map[string]string{"tags.0": "x", "tags.1": "y", "tags": "x,y"},
+		},
+		{
+			name:  "compact sequence at key indent",
+			input: "tags:\n- x\n- y\nnext: 1\n",
+			want:  map[string]string{"tags.0": "x", "tags.1": "y", "tags": "x,y", "next": "1"},
+		},
+		{
+			name:  "sequence of mappings",
+			input: "servers:\n  - host: a\n    port: 1\n  - host: b\n    port: 2\n",
+			want: map[string]string{
+				"servers.0.host": "a", "servers.0.port": "1",
+				"servers.1.host": "b", "servers.1.port": "2",
+			},
+		},
+		{
+			name:  "nested sequences",
+			input: "m:\n  - - a\n    - b\n  - - c\n",
+			want:  map[string]string{"m.0.0": "a", "m.0.1": "b", "m.0": "a,b", "m.1.0": "c", "m.1": "c"},
+		},
+		{
+			name:  "literal block scalar",
+			input: "script: |\n  line one\n  line two\n\nnext: x\n",
+			want:  map[string]string{"script": "line one\nline two\n", "next": "x"},
+		},
+		{
+			name:  "literal block scalar strip",
+			input: "script: |-\n  a\n   b\n",
+			want:  map[string]string{"script": "a\n b"},
+		},
+		{
+			name:  "literal block scalar keep",
+			input: "script: |+\n  a\n\n\nnext: x\n",
+			want:  map[string]string{"script": "a\n\n\n", "next": "x"},
+		},
+		{
+			name:  "folded block scalar",
+			input: "text: >\n  folded\n  line\n\n  new para\n    indented\n  end\n",
+			want:  map[string]string{"text": "folded line\nnew para\n  indented\nend\n"},
+		},
+		{
+			name:  "block scalar with indentation indicator",
+			input: "text: |2\n    indented\n  base\n",
+			want:  map[string]string{"text": "  indented\nbase\n"},
+		},
+		{
+			name:  "block scalar keeps comment-like lines",
+			input: "text: |\n  # not a comment\n  x\n",
+			want:  map[string]string{"text": "# not a comment\nx\n"},
+		},
+		{
+			name:  "flow sequence",
+			input: "ports: [80, 443, \"8080\"]\n",
+			want:  map[string]string{"ports.0": "80", "ports.1": "443", "ports.2": "8080", "ports": "80,443,8080"},
+		},
+		{
+			name:  "flow mapping",
+			input: "db: {host: localhost, port: 5432, opts: {ssl: true}}\n",
+			want:  map[string]string{"db.host": "localhost", "db.port": "5432", "db.opts.ssl": "true"},
+		},
+		{
+			name:  "multi-line flow collection with comments",
+			input: "list: [\n  a, # first\n  b,\n]\n",
+			want:  map[string]string{"list.0": "a", "list.1": "b", "list": "a,b"},
+		},
+		{
+			name:  "flow sequence of mappings",
+			input: "s: [{a: 1}, {a: 2}]\n",
+			want:  map[string]string{"s.0.a": "1", "s.1.a": "2"},
+		},
+		{
+			name:  "empty flow collections",
+			input: "a: []\nb: {}\n",
+			want:  map[string]string{"a": ""},
+		},
+		{
+			name:  "quoted scalars",
+			input: "a: \"tab\\there \\u00e9\"\nb: 'it''s'\nc: \"x # y\" # comment\n\"quoted key\": v\n",
+			want:  map[string]string{"a": "tab\there é", "b": "it's", "c": "x # y", "quoted key": "v"},
+		},
+		{
+			name:  "multi-line quoted scalar",
+			input: "a: \"one\n  two\n\n  three\"\nb: 'x\n  y'\n",
+			want:  map[string]string{"a": "one two\nthree", "b": "x y"},
+		},
+		{
+			name:  "multi-line plain scalar",
+			input: "a: one\n  two\n  three\nb: x\n",
+			want:  map[string]string{"a": "one two three", "b": "x"},
+		},
+		{
+			name:  "anchors and aliases",
+			input: "base: &base\n  host: h\n  port: 1\ncopy: *base\nname: &n lx\nalias: *n\n",
+			want: map[string]string{
+				"base.host": "h", "base.port": "1",
+				"copy.host": "h", "copy.port": "1",
+				"name": "lx", "alias": "lx",
+			},
+		},
+		{
+			name:  "merge keys",
+			input: "defaults: &d\n  a: 1\n  b: 2\nprod:\n  <<: *d\n  b: 3\n",
+			want:  map[string]string{"defaults.a": "1", "defaults.b": "2", "prod.a": "1", "prod.b": "3"},
+		},
+		{
+			name:  "merge key sequence",
+			input: "x: &x {a: 1}\ny: &y {a: 2, b: 2}\nz:\n  <<: [*x, *y]\n",
+			want:  map[string]string{"x.a": "1", "y.a": "2", "y.b": "2", "z.a": "1", "z.b": "2"},
+		},
+		{
+			name:  "tags are ignored",
+			input: "a: !!str 123\nb: !custom value\n",
+			want:  map[string]string{"a": "123", "b": "value"},
+		},
+		{
+			name:  "nulls",
+			input: "a:\nb: ~\nc: null\nd: 'null'\n",
+			want:  map[string]string{"a": "", "b": "", "c": "", "d": "null"},
+		},
+		{
+			name:  "multiple documents",
+			input: "%YAML 1.2\n---\na: 1\nb: 1\n---\nb: 2\n...\n---\nc: 3\n",
+			want:  map[string]string{"a": "1", "b": "2", "c": "3"},
+		},
+		{
+			name:  "comments and colons in values",
+			input: "# header\nurl: http://example.com:8080/x # trailing\ntime: 12:30\nhash: a#b\n",
+			want:  map[string]string{"url": "http://example.com:8080/x", "time": "12:30", "hash": "a#b"},
+		},
+		{
+			name:  "crlf line endings",
+			input: "a:\r\n  b: 1\r\n",
+			want:  map[string]string{"a.b": "1"},
+		},
+		{
+			name:  "empty input",
+			input: "# only a comment\n",
+			want:  map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := defaultYAMLParser.Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// billionLaughs is a document of nested aliases that expands to 10^9 nodes.
+var billionLaughs = func() string {
+	var b strings.Builder
+	b.WriteString("l0: &l0 [x, x, x, x, x, x, x, x, x, x]\n")
+	for i := 1; i <= 9; i++ {
+		fmt.Fprintf(&b, "l%d: &l%d [", i, i)
+		for j := 0; j < 10; j++ {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "*l%d", i-1)
+		}
+		b.WriteString("]\n")
+	}
+	return b.String()
+}()
+
+func TestYAMLFileParser_ParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"bad indentation", "a:\n  b: 1\n c: 2\n", "line 3, column 2: unexpected indentation"},
+		{"over-indented key", "a: 1\n   b: 2\n", "line 2, column 5: mapping values are not allowed"},
+		{"tab indentation", "a:\n\tb: 1\n", "line 2, column 1: found tab character in indentation"},
+		{"mapping value in scalar", "a: b: c\n", "line 1, column 5: mapping values are not allowed"},
+		{"unknown alias", "a: *missing\n", "line 1, column 4: unknown anchor \"missing\""},
+		{"unterminated flow", "a: [1, 2\nb: 3\n", "line 1, column 4: unterminated flow collection"},
+		{"unterminated quote", "a: \"open\n", "line 1, column 4: unterminated quoted scalar"},
+		{"content after quote", "a: \"x\" y\n", "line 1, column 4: unexpected content after quoted scalar"},
+		{"invalid escape", "a: \"\\q\"\n", "line 1, column 4: invalid escape sequence"},
+		{"missing flow separator", "a: {x: 1 y: 2}\n", "expected ',' or '}'"},
+		{"sequence in mapping", "a: 1\n- b\n", "line 2, column 1: unexpected sequence entry in mapping"},
+		{"scalar in mapping", "a: 1\nplain\n", "line 2, column 1: expected a mapping key"},
+		{"bad merge", "a:\n  <<: 1\n", "merge key value must be a mapping"},
+		{"scalar root", "just text\n", "document root must be a mapping or a sequence"},
+		{"complex key", "? a\n: b\n", "complex mapping keys are not supported"},
+		{"billion laughs", billionLaughs, "aliases expand to too many nodes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := defaultYAMLParser.Parse(strings.NewReader(tt.input))
+			if err == nil {
+				t.Fatalf("Parse(%q) expected error, got nil", tt.input)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse(%q) error = %q, want it to contain %q", tt.input, err, tt.want)
+			}
+		})
+	}
+}
@@ -30,7 +30,11 @@
 // 5. Async Operations:
 //
 //   - Future[T] - Asynchronous computation with type-safe composition and context support
+//   - Future.Done, IsDone, TryGet, OnComplete - Non-blocking inspection and completion callbacks
 //   - FutureAny[T] - Return the first successful result from many futures (first err==nil)
+//   - FutureAllFailFast, FutureAllAggregate, FutureAllSettled - Failure modes of FutureAll (also for FutureJoin2..8)
+//   - FutureRetry, FutureTimeout, FutureRecover, FutureFallback - Resilience combinators
+//   - Executor, Pool - Run futures on bounded worker pools (FutureDoOn, FutureAllOn, FutureJoin2On...)
 //
 // 6. Mutable State:
 //
@@ -0,0 +1,287 @@
+package lxtypes
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrExecutorShutdown is returned by Execute, and by the futures submitted
+// with it, once Shutdown has been called on a Pool.
+var ErrExecutorShutdown = errors.New("lxtypes: executor is shut down")
+
+// Executor runs the computations of futures created with FutureDoOn and the
+// other *On functions.
+//
+// Execute runs task, possibly asynchronously, and returns an error if the
+// task is not accepted, in which case task is never run. Execute may block
+// to apply backpressure to the caller.
+//
+// Example:
+//
+//	pool := NewBoundedPool(8, 100)
+//	defer pool.Shutdown(context.Background())
+//
+//	futures := make([]Future[Item], len(ids))
+//	for i, id := range ids {
+//	    id := id
+//	    futures[i] = FutureDoOn(pool, func() (Item, error) { return fetchItem(id) })
+//	}
+//	items, err := FutureAll(futures...).Get(ctx)
+type Executor interface {
+	Execute(task func()) error
+}
+
+// goExecutor runs every task in a new goroutine.
+type goExecutor struct{}
+
+func (goExecutor) Execute(task func()) error {
+	go task()
+	return nil
+}
+
+// inlineExecutor runs every task in the goroutine that submits it.
+type inlineExecutor struct{}
+
+func (inlineExecutor) Execute(task func()) error {
+	task()
+	return nil
+}
+
+// GoExecutor returns an Executor that runs every task in a new goroutine,
+// without any bound. It is the executor used by FutureDo.
+func GoExecutor() Executor {
+	return goExecutor{}
+}
+
+// InlineExecutor returns an Executor that runs every task synchronously in
+// the goroutine calling Execute. A future created on it is completed when
+// FutureDoOn returns, which makes it convenient in tests.
+//
+// Example:
+//
+//	future := FutureDoOn(InlineExecutor(), func() (int, error) { return 42, nil })
+//	value, _ := future.Get(ctx) // Already completed
+func InlineExecutor() Executor {
+	return inlineExecutor{}
+}
+
+// PoolStats is a snapshot of the activity of a Pool.
+type PoolStats struct {
+	Workers   int    // Number of worker goroutines
+	Queued    int    // Tasks waiting for a worker
+	Active    int    // Tasks running, including tasks run by callers of a caller-runs pool
+	Completed uint64 // Tasks that have finished running
+}
+
+// Pool is an Executor that runs tasks on a fixed number of worker goroutines.
+// Tasks wait in a FIFO queue until a worker is free; what happens when the
+// queue is full depends on the constructor:
+//
+//   - NewFixedPool: the queue is unbounded
+//   - NewBoundedPool: Execute blocks until there is room (backpressure)
+//   - NewCallerRunsPool: Execute runs the task in the calling goroutine
+//
+// A Pool is safe for concurrent use. Call Shutdown to stop its workers.
+type Pool struct {
+	mu         sync.Mutex
+	hasTask    *sync.Cond // Signalled when a task is queued or the pool shuts down
+	hasRoom    *sync.Cond // Signalled when room may have been made in the queue
+	queue      []func()
+	workers    int
+	capacity   int  // Queue capacity, -1 if unbounded
+	callerRuns bool // Run tasks in the caller when the queue is full
+	active     int  // Tasks run by workers
+	inline     int  // Tasks run by callers
+	completed  uint64
+	closed     bool
+	running    sync.WaitGroup // Workers and tasks run by callers
+	stopped    chan struct{}  // Closed when running is done
+}
+
+// NewFixedPool creates a Pool of workers goroutines with an unbounded queue.
+// Execute never blocks.
+//
+// Panics if workers is not positive.
+//
+// Example:
+//
+//	pool := NewFixedPool(4)
+//	defer pool.Shutdown(context.Background())
+//	future := FutureDoOn(pool, func() (int, error) { return compute() })
+func NewFixedPool(workers int) *Pool {
+	return newPool(workers, -1, false)
+}
+
+// NewBoundedPool creates a Pool of workers goroutines whose queue holds at
+// most queueSize tasks. When the queue is full, Execute blocks until a worker
+// takes a task, so producers cannot outrun the workers. With a queueSize of
+// 0, Execute blocks until a worker is free.
+//
+// Panics if workers is not positive or queueSize is negative.
+//
+// Example:
+//
+//	pool := NewBoundedPool(16, 64)
+//	for _, id := range ids {
+//	    id := id
+//	    FutureDoOn(pool, func() (Item, error) { return fetchItem(id) }) // Blocks while 16 run and 64 wait
+//	}
+func NewBoundedPool(workers, queueSize int) *Pool {
+	if queueSize < 0 {
+		panic("lxtypes: negative pool queue size")
+	}
+	return newPool(workers, queueSize, false)
+}
+
+// NewCallerRunsPool creates a Pool of workers goroutines whose queue holds at
+// most queueSize tasks. When the queue is full, Execute runs the task in the
+// calling goroutine, which slows producers down without rejecting work.
+//
+// Panics if workers is not positive or queueSize is negative.
+//
+// Example:
+//
+//	pool := NewCallerRunsPool(8, 32)
+//	future := FutureDoOn(pool, func() (int, error) { return compute() })
+func NewCallerRunsPool(workers, queueSize int) *Pool {
+	if queueSize < 0 {
+		panic("lxtypes: negative pool queue size")
+	}
+	return newPool(workers, queueSize, true)
+}
+
+func newPool(workers, capacity int, callerRuns bool) *Pool {
+	if workers <= 0 {
+		panic("lxtypes: pool needs at least one worker")
+	}
+	p := &Pool{
+		workers:    workers,
+		capacity:   capacity,
+		callerRuns: callerRuns,
+		stopped:    make(chan struct{}),
+	}
+	p.hasTask = sync.NewCond(&p.mu)
+	p.hasRoom = sync.NewCond(&p.mu)
+
+	// Workers exit only after Shutdown, and callers are only added to running
+	// before it, so running never drops to zero while callers are added
+	p.running.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer p.running.Done()
+			p.work()
+		}()
+	}
+	go func() {
+		p.running.Wait()
+		close(p.stopped)
+	}()
+	return p
+}
+
+// Execute queues task for a worker. Returns ErrExecutorShutdown if the pool
+// is shut down, including while Execute is blocked on a full queue.
+func (p *Pool) Execute(task func()) error {
+	p.mu.Lock()
+	for !p.closed && !p.hasRoomLocked() {
+		if p.callerRuns {
+			p.inline++
+			p.running.Add(1)
+			p.mu.Unlock()
+			defer p.running.Done()
+			p.run(task, &p.inline)
+			return nil
+		}
+		p.hasRoom.Wait()
+	}
+	if p.closed {
+		p.mu.Unlock()
+		return ErrExecutorShutdown
+	}
+	p.queue = append(p.queue, task)
+	p.hasTask.Signal()
+	p.mu.Unlock()
+	return nil
+}
+
+// hasRoomLocked reports whether a task can be queued. Idle workers take
+// queued tasks right away, so they count as room too.
+func (p *Pool) hasRoomLocked() bool {
+	return p.capacity < 0 || len(p.queue) < p.capacity+p.workers-p.active
+}
+
+// Stats returns the current activity of the pool.
+func (p *Pool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return PoolStats{
+		Workers:   p.workers,
+		Queued:    len(p.queue),
+		Active:    p.active + p.inline,
+		Completed: p.completed,
+	}
+}
+
+// Shutdown stops the pool from accepting tasks and waits for the queued and
+// running tasks to finish, including tasks that a caller-runs pool is running
+// in the goroutines of its callers. If ctx is done first, Shutdown returns its error;
+// the workers still finish the remaining tasks in the background.
+// Shutdown may be called several times.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	if err := pool.Shutdown(ctx); err != nil {
+//	    log.Printf("pool did not drain: %v (%+v)", err, pool.Stats())
+//	}
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		p.hasTask.Broadcast()
+		p.hasRoom.Broadcast()
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// work runs queued tasks until the pool is shut down and the queue is empty.
+func (p *Pool) work() {
+	for {
+		p.mu.Lock()
+		for len(p.queue) == 0 && !p.closed {
+			p.hasTask.Wait()
+		}
+		if len(p.queue) == 0 {
+			p.mu.Unlock()
+			return
+		}
+		task := p.queue[0]
+		p.queue[0] = nil
+		p.queue = p.queue[1:]
+		p.active++
+		p.mu.Unlock()
+
+		p.run(task, &p.active)
+	}
+}
+
+// run runs task and then records its completion in counter.
+func (p *Pool) run(task func(), counter *int) {
+	defer func() {
+		p.mu.Lock()
+		*counter--
+		p.completed++
+		p.hasRoom.Signal()
+		p.mu.Unlock()
+	}()
+	task()
+}
@@ -0,0 +1,64 @@
+package lxtypes_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hgapdvn/lx/types"
+)
+
+// Example of bounding a fan-out with a worker pool
+func ExampleFutureDoOn() {
+	// At most 4 calls run at once and 16 wait; FutureDoOn blocks beyond that
+	pool := lxtypes.NewBoundedPool(4, 16)
+	defer pool.Shutdown(context.Background())
+
+	futures := make([]lxtypes.Future[int], 100)
+	for i := range futures {
+		i := i
+		futures[i] = lxtypes.FutureDoOn(pool, func() (int, error) {
+			return i * i, nil
+		})
+	}
+
+	squares, err := lxtypes.FutureAll(futures...).Get(context.Background())
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Println(len(squares), squares[9])
+	// Output: 100 81
+}
+
+// Example of draining a pool and reading its metrics
+func ExamplePool_Shutdown() {
+	pool := lxtypes.NewFixedPool(2)
+	for i := 0; i < 10; i++ {
+		_ = pool.Execute(func() {})
+	}
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Printf("%+v\n", pool.Stats())
+
+	_, err := lxtypes.FutureDoOn(pool, func() (int, error) { return 1, nil }).Get(context.Background())
+	fmt.Println(err)
+	// Output:
+	// {Workers:2 Queued:0 Active:0 Completed:10}
+	// lxtypes: executor is shut down
+}
+
+// Example of running futures synchronously in tests
+func ExampleInlineExecutor() {
+	future := lxtypes.FutureDoOn(lxtypes.InlineExecutor(), func() (string, error) {
+		return "done", nil
+	})
+
+	// The future completed before FutureDoOn returned
+	value, _ := future.Get(context.Background())
+	fmt.Println(value)
+	// Output: done
+}
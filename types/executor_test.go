@@ -0,0 +1,405 @@
+package lxtypes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ========================================
+// Test helpers
+// ========================================
+
+// blockWorkers submits n tasks to pool that block until release is closed,
+// and returns once they are all running.
+func blockWorkers(t *testing.T, pool *Pool, n int) chan struct{} {
+	t.Helper()
+	release := make(chan struct{})
+	var running sync.WaitGroup
+	running.Add(n)
+	for i := 0; i < n; i++ {
+		if err := pool.Execute(func() {
+			running.Done()
+			<-release
+		}); err != nil {
+			t.Fatalf("Execute() unexpected error: %v", err)
+		}
+	}
+	running.Wait()
+	return release
+}
+
+// returnsWithin reports whether fn returns within d.
+func returnsWithin(d time.Duration, fn func()) (chan struct{}, bool) {
+	returned := make(chan struct{})
+	go func() {
+		defer close(returned)
+		fn()
+	}()
+	select {
+	case <-returned:
+		return returned, true
+	case <-time.After(d):
+		return returned, false
+	}
+}
+
+func shutdown(t *testing.T, pool *Pool) {
+	t.Helper()
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() unexpected error: %v", err)
+	}
+}
+
+// ========================================
+// Executor Tests
+// ========================================
+
+func TestInlineExecutor(t *testing.T) {
+	ran := false
+	future := FutureDoOn(InlineExecutor(), func() (int, error) {
+		ran = true
+		return 42, nil
+	})
+	if !ran {
+		t.Fatal("FutureDoOn(InlineExecutor()) did not run fn before returning")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Get must not need to wait
+	if result, err := future.Get(ctx); err != nil || result != 42 {
+		t.Errorf("Get() = (%v, %v), want (42, nil)", result, err)
+	}
+}
+
+func TestFixedPool_LimitsConcurrency(t *testing.T) {
+	pool := NewFixedPool(2)
+	defer shutdown(t, pool)
+
+	var running, maxRunning int32
+	futures := make([]Future[int], 20)
+	for i := range futures {
+		i := i
+		futures[i] = FutureDoOn(pool, func() (int, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return i, nil
+		})
+	}
+
+	results, err := FutureAll(futures...).Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() returned unexpected error: %v", err)
+	}
+	for i, v := range results {
+		if v != i {
+			t.Errorf("results[%d] = %v, want %v", i, v, i)
+		}
+	}
+	if maxRunning > 2 {
+		t.Errorf("%d tasks ran concurrently, want at most 2", maxRunning)
+	}
+	if stats := pool.Stats(); stats != (PoolStats{Workers: 2, Completed: 20}) {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestBoundedPool_Backpressure(t *testing.T) {
+	pool := NewBoundedPool(1, 1)
+	defer shutdown(t, pool)
+
+	release := blockWorkers(t, pool, 1)
+	if err := pool.Execute(func() {}); err != nil { // Fills the queue
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if stats := pool.Stats(); stats.Queued != 1 || stats.Active != 1 {
+		t.Errorf("Stats() = %+v, want 1 queued and 1 active", stats)
+	}
+
+	returned, ok := returnsWithin(20*time.Millisecond, func() {
+		FutureDoOn(pool, func() (int, error) { return 0, nil })
+	})
+	if ok {
+		t.Fatal("FutureDoOn() did not block on a full queue")
+	}
+	close(release)
+	<-returned
+}
+
+func TestBoundedPool_ZeroQueue(t *testing.T) {
+	pool := NewBoundedPool(1, 0)
+	defer shutdown(t, pool)
+
+	// The idle worker takes the task right away
+	future := FutureDoOn(pool, func() (int, error) { return 1, nil })
+	if _, err := future.Get(context.Background()); err != nil {
+		t.Fatalf("Get() returned unexpected error: %v", err)
+	}
+
+	release := blockWorkers(t, pool, 1)
+	returned, ok := returnsWithin(20*time.Millisecond, func() {
+		_ = pool.Execute(func() {})
+	})
+	if ok {
+		t.Fatal("Execute() did not block while the worker was busy")
+	}
+	close(release)
+	<-returned
+}
+
+func TestCallerRunsPool(t *testing.T) {
+	pool := NewCallerRunsPool(1, 1)
+	defer shutdown(t, pool)
+
+	release := blockWorkers(t, pool, 1)
+	defer close(release)
+	if err := pool.Execute(func() {}); err != nil { // Fills the queue
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	ran := false
+	future := FutureDoOn(pool, func() (int, error) {
+		ran = true
+		return 42, nil
+	})
+	if !ran {
+		t.Fatal("FutureDoOn() did not run fn in the caller when the queue was full")
+	}
+	if result, err := future.Get(context.Background()); err != nil || result != 42 {
+		t.Errorf("Get() = (%v, %v), want (42, nil)", result, err)
+	}
+	if stats := pool.Stats(); stats.Completed != 1 {
+		t.Errorf("Stats().Completed = %d, want 1", stats.Completed)
+	}
+}
+
+func TestPool_Shutdown(t *testing.T) {
+	pool := NewFixedPool(1)
+	release := blockWorkers(t, pool, 1)
+
+	var ran int32
+	for i := 0; i < 3; i++ {
+		if err := pool.Execute(func() { atomic.AddInt32(&ran, 1) }); err != nil {
+			t.Fatalf("Execute() unexpected error: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := pool.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Shutdown() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if err := pool.Execute(func() {}); err != ErrExecutorShutdown {
+		t.Errorf("Execute() after Shutdown error = %v, want %v", err, ErrExecutorShutdown)
+	}
+	_, err := FutureDoOn(pool, func() (int, error) { return 1, nil }).Get(context.Background())
+	if !errors.Is(err, ErrExecutorShutdown) {
+		t.Errorf("FutureDoOn() after Shutdown error = %v, want %v", err, ErrExecutorShutdown)
+	}
+
+	close(release)
+	shutdown(t, pool)
+	if ran != 3 {
+		t.Errorf("%d queued tasks ran, want 3", ran)
+	}
+	if stats := pool.Stats(); stats != (PoolStats{Workers: 1, Completed: 4}) {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestPool_ShutdownUnblocksExecute(t *testing.T) {
+	pool := NewBoundedPool(1, 0)
+	release := blockWorkers(t, pool, 1)
+	defer close(release)
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- pool.Execute(func() {})
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_ = pool.Shutdown(ctx)
+
+	select {
+	case err := <-errs:
+		if err != ErrExecutorShutdown {
+			t.Errorf("Execute() error = %v, want %v", err, ErrExecutorShutdown)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Shutdown() did not unblock Execute()")
+	}
+}
+
+func TestPool_ShutdownWaitsForCallerRuns(t *testing.T) {
+	pool := NewCallerRunsPool(1, 0)
+	release := blockWorkers(t, pool, 1)
+
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	go func() {
+		_ = pool.Execute(func() { // Runs in this goroutine, the worker is busy
+			close(started)
+			<-finish
+		})
+	}()
+	<-started
+	close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := pool.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Shutdown() error = %v, want %v while a caller runs a task", err, context.DeadlineExceeded)
+	}
+
+	close(finish)
+	shutdown(t, pool)
+	if stats := pool.Stats(); stats != (PoolStats{Workers: 1, Completed: 2}) {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestNewPool_Panics(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"no workers", func() { NewFixedPool(0) }},
+		{"negative queue", func() { NewBoundedPool(1, -1) }},
+		{"caller runs negative queue", func() { NewCallerRunsPool(1, -1) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
+
+// ========================================
+// Futures on Executors Tests
+// ========================================
+
+func TestFutureDoOn_CancelWhileQueued(t *testing.T) {
+	pool := NewFixedPool(1)
+	defer shutdown(t, pool)
+	release := blockWorkers(t, pool, 1)
+
+	ran := int32(0)
+	future := FutureDoOn(pool, func() (int, error) {
+		atomic.StoreInt32(&ran, 1)
+		return 1, nil
+	})
+	future.Cancel()
+	close(release)
+	shutdown(t, pool)
+
+	if atomic.LoadInt32(&ran) != 0 {
+		t.Error("fn ran although the future was cancelled while queued")
+	}
+	if _, err := future.Get(context.Background()); err != context.Canceled {
+		t.Errorf("Get() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestFutureDoOn_Panic(t *testing.T) {
+	pool := NewFixedPool(1)
+	defer shutdown(t, pool)
+
+	_, err := FutureDoOn(pool, func() (int, error) { panic("boom") }).Get(context.Background())
+	if !errors.Is(err, ErrPanicked) || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Get() error = %v, want ErrPanicked with the panic value", err)
+	}
+
+	// The worker survived the panic
+	if result, err := FutureDoOn(pool, func() (int, error) { return 42, nil }).Get(context.Background()); err != nil || result != 42 {
+		t.Errorf("Get() after panic = (%v, %v), want (42, nil)", result, err)
+	}
+}
+
+func TestFutureDoOn_PanickingCallback(t *testing.T) {
+	pool := NewFixedPool(1)
+	defer shutdown(t, pool)
+
+	release := make(chan struct{})
+	future := FutureDoOn(pool, func() (int, error) {
+		<-release
+		return 1, nil
+	})
+	future.OnComplete(func(int, error) { panic("cb") }) // Runs on the worker
+	close(release)
+
+	if result, err := FutureDoOn(pool, func() (int, error) { return 42, nil }).Get(context.Background()); err != nil || result != 42 {
+		t.Errorf("Get() after panicking callback = (%v, %v), want (42, nil)", result, err)
+	}
+}
+
+func TestFutureThenOn(t *testing.T) {
+	pool := NewFixedPool(1)
+	defer shutdown(t, pool)
+
+	parent := FutureDo(func() (int, error) { return 21, nil })
+	child := FutureThenOn(pool, parent, func(v int) (int, error) { return v * 2, nil })
+
+	if result, err := child.Get(context.Background()); err != nil || result != 42 {
+		t.Errorf("Get() = (%v, %v), want (42, nil)", result, err)
+	}
+	if stats := pool.Stats(); stats.Completed != 1 {
+		t.Errorf("Stats().Completed = %d, want 1", stats.Completed)
+	}
+}
+
+func TestFutureAllOn_DoesNotDeadlock(t *testing.T) {
+	// A single worker runs every input and the aggregation: waiting for the
+	// inputs must not occupy it.
+	pool := NewBoundedPool(1, 0)
+	defer shutdown(t, pool)
+
+	all := FutureAllOn(pool, FutureDo(func() (int, error) {
+		time.Sleep(10 * time.Millisecond)
+		return 1, nil
+	}), FutureOf(2))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	results, err := all.Get(ctx)
+	if err != nil || len(results) != 2 || results[0] != 1 || results[1] != 2 {
+		t.Errorf("Get() = (%v, %v), want ([1 2], nil)", results, err)
+	}
+}
+
+func TestFutureJoinOn(t *testing.T) {
+	pool := NewFixedPool(2)
+	defer shutdown(t, pool)
+
+	f1 := FutureDoOn(pool, func() (int, error) { return 1, nil })
+	f2 := FutureDoOn(pool, func() (string, error) { return "two", nil })
+	f3 := FutureDoOn(pool, func() (bool, error) { return true, nil })
+
+	pair, err := FutureJoin2On(pool, f1, f2).Get(context.Background())
+	if err != nil || pair != NewPair(1, "two") {
+		t.Errorf("FutureJoin2On() = (%v, %v)", pair, err)
+	}
+	triple, err := FutureJoin3On(pool, f1, f2, f3).Get(context.Background())
+	if err != nil || triple != NewTriple(1, "two", true) {
+		t.Errorf("FutureJoin3On() = (%v, %v)", triple, err)
+	}
+}
@@ -3,9 +3,15 @@ package lxtypes
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 )
 
+// ErrPanicked is wrapped by the error of a future whose computation panicked.
+// The panic value follows it in the message, e.g.
+// "lxtypes: future computation panicked: index out of range".
+var ErrPanicked = errors.New("lxtypes: future computation panicked")
+
 // Future represents a value that will be available in the future.
 // It supports type transformation through the Then function for sequential
 // operations, and can be combined with other futures for parallel execution.
@@ -36,20 +42,272 @@ type Future[T any] interface {
 	// If the provided context is canceled or reaches its deadline before
 	// the computation completes, Get returns the context error.
 	Get(ctx context.Context) (T, error)
+
+	// Cancel completes the future with context.Canceled if it has not
+	// completed yet and cancels the context passed to its computation.
+	//
+	// It also releases the futures it was derived from (the parent of
+	// FutureThen, the inputs of FutureAll, FutureAny and FutureJoin2..8).
+	// An input is cancelled once every future derived from it has been
+	// cancelled, so cancelling one consumer of a shared input does not
+	// fail the others. Code that only reads the input with Get does not
+	// count as a consumer; cancel such an input explicitly if needed.
+	//
+	// Cancel has no effect on a completed future.
+	Cancel()
+
+	// Done returns a channel that is closed when the future completes, for
+	// use in select statements.
+	Done() <-chan struct{}
+
+	// IsDone reports whether the future has completed, without blocking.
+	IsDone() bool
+
+	// TryGet returns the result and true if the future has completed, or
+	// the zero value, nil and false otherwise. It never blocks.
+	TryGet() (T, error, bool)
+
+	// OnComplete registers fn to be called with the result once the future
+	// completes. Callbacks run in registration order in the goroutine that
+	// completes the future: the goroutine or pool worker running the
+	// computation, the goroutine calling Cancel, or the goroutine watching
+	// the context or timeout of the future. If the future has already
+	// completed, fn runs right away in the calling goroutine.
+	//
+	// Callbacks run after the future is done and, when it is cancelled,
+	// after the cancellation has reached the futures it was derived from.
+	// They still hold up the goroutine running them, e.g. a pool worker, so
+	// they should return quickly; start a goroutine for anything slow.
+	// A panic in a callback run on completion is recovered and dropped, so
+	// it neither skips the other callbacks nor crashes the goroutine that
+	// completed the future; recover in fn to report it. A callback run
+	// right away on a completed future panics in the calling goroutine.
+	OnComplete(fn func(T, error))
+
+	// OnSuccess is like OnComplete but calls fn only if the future succeeds.
+	OnSuccess(fn func(T))
+
+	// OnFailure is like OnComplete but calls fn only if the future fails,
+	// including when it is cancelled.
+	OnFailure(fn func(error))
 }
 
 // Note: Then is provided as a standalone function (not a method) because
 // Go interfaces cannot have methods with type parameters.
 // Use: Then(future, transformFn) instead of future.Then(transformFn)
 
+// canceler is implemented by every Future.
+type canceler interface {
+	Cancel()
+}
+
+// consumable is implemented by the futures of this package. A derived future
+// acquires each of its inputs when it is created and releases them once it
+// no longer needs them; an input is cancelled when every future derived from
+// it has released it. Other implementations of Future are never cancelled by
+// the futures derived from them.
+type consumable interface {
+	acquire()
+	release()
+}
+
 // future is the internal implementation of Future[T].
-// Lock-free design using only channel synchronization.
+// Results are published through channel synchronization; the mutex only
+// guards the callbacks registered before completion.
 type future[T any] struct {
-	fn    func() (T, error) // Function to execute
-	value T                 // Result value
-	err   error             // Result error
-	done  chan struct{}     // Closed when computation completes
-	once  sync.Once         // Ensures fn runs only once
+	value     T                  // Result value
+	err       error              // Result error
+	done      chan struct{}      // Closed when computation completes
+	once      sync.Once          // Ensures the future completes only once
+	base      context.Context    // Context the computation was started with, nil if never started
+	cancel    context.CancelFunc // Cancels the context of the computation
+	deps      []canceler         // Futures this one was derived from
+	releaser  sync.Once          // Ensures deps are released only once
+	mu        sync.Mutex         // Guards callbacks, consumers and releases
+	callbacks []func(T, error)   // Called on completion, nil once completed
+	consumers int                // Futures derived from this one
+	releases  int                // Derived futures that no longer need this one
+}
+
+// newFuture creates a pending future whose computation runs with a context
+// derived from base, and returns that context. If base can be cancelled, the
+// future completes with its error as soon as it is done, without waiting for
+// the computation to return, or right away if base is already done.
+func newFuture[T any](base context.Context, deps []canceler) (*future[T], context.Context) {
+	ctx, cancel := context.WithCancel(base)
+	f := &future[T]{done: make(chan struct{}), base: base, cancel: cancel, deps: deps}
+	for _, d := range deps {
+		if c, ok := d.(consumable); ok {
+			c.acquire()
+		}
+	}
+	if err := base.Err(); err != nil {
+		f.abort(err)
+	} else if baseDone := base.Done(); baseDone != nil {
+		go func() {
+			select {
+			case <-baseDone:
+				f.abort(base.Err())
+			case <-f.done:
+			}
+		}()
+	}
+	return f, ctx
+}
+
+// completedFuture creates a future that is already completed with value and err.
+func completedFuture[T any](value T, err error) *future[T] {
+	f := &future[T]{done: make(chan struct{})}
+	f.complete(value, err)
+	return f
+}
+
+// derive starts a future that waits for deps to complete, computes its
+// result with fn on exec, and releases deps when it is cancelled.
+//
+// Waiting happens in a goroutine of its own rather than on exec, so a
+// bounded executor never blocks on the inputs of its own tasks.
+func derive[T any](base context.Context, deps []canceler, exec Executor, fn func(ctx context.Context) (T, error)) Future[T] {
+	f, ctx := newFuture[T](base, deps)
+	go func() {
+		await(deps)
+		f.run(ctx, exec, fn)
+	}()
+	return f
+}
+
+// await blocks until every future in deps has completed.
+func await(deps []canceler) {
+	for _, d := range deps {
+		if w, ok := d.(interface{ wait() }); ok {
+			w.wait()
+		}
+	}
+}
+
+// wait blocks until the future has completed.
+func (f *future[T]) wait() {
+	<-f.done
+}
+
+// run submits fn to exec. The future completes with the result of fn, with an
+// error wrapping ErrPanicked if fn panics, or with the error of exec if the
+// task is rejected. fn is skipped if the future has already completed, e.g.
+// because it was cancelled while the task was queued.
+func (f *future[T]) run(ctx context.Context, exec Executor, fn func(ctx context.Context) (T, error)) {
+	err := exec.Execute(func() {
+		select {
+		case <-f.done:
+			return
+		default:
+		}
+		value, err := call(ctx, fn)
+		f.complete(value, err)
+	})
+	if err != nil {
+		var zero T
+		f.complete(zero, err)
+	}
+}
+
+// call runs fn and turns a panic into an error, so that a panicking
+// computation fails its future instead of killing the executor's goroutine.
+func call[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) (value T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			value, err = zero, fmt.Errorf("%w: %v", ErrPanicked, r)
+		}
+	}()
+	return fn(ctx)
+}
+
+// complete stores the result, runs the callbacks and reports whether it
+// completed the future.
+func (f *future[T]) complete(value T, err error) bool {
+	callbacks, completed := f.settle(value, err)
+	notify(callbacks, value, err)
+	return completed
+}
+
+// abort completes the future with err and releases the futures it depends on
+// before running the callbacks, so a slow callback does not hold up the
+// cancellation.
+func (f *future[T]) abort(err error) {
+	var zero T
+	callbacks, completed := f.settle(zero, err)
+	if completed {
+		f.releaseDeps()
+	}
+	notify(callbacks, zero, err)
+}
+
+// releaseDeps releases the futures f depends on, once.
+func (f *future[T]) releaseDeps() {
+	f.releaser.Do(func() {
+		for _, d := range f.deps {
+			if c, ok := d.(consumable); ok {
+				c.release()
+			}
+		}
+	})
+}
+
+// acquire records a future derived from f.
+func (f *future[T]) acquire() {
+	f.mu.Lock()
+	f.consumers++
+	f.mu.Unlock()
+}
+
+// release records that a future derived from f no longer needs it, and
+// cancels f once none of them does.
+func (f *future[T]) release() {
+	f.mu.Lock()
+	f.releases++
+	unused := f.releases == f.consumers
+	f.mu.Unlock()
+	if unused {
+		f.Cancel()
+	}
+}
+
+// settle stores the result and returns the callbacks to run, and whether it
+// completed the future. Lock-free: writes happen-before channel close.
+func (f *future[T]) settle(value T, err error) ([]func(T, error), bool) {
+	completed := false
+	var callbacks []func(T, error)
+	f.once.Do(func() {
+		f.value, f.err = value, err
+		f.mu.Lock()
+		close(f.done) // Signals completion (happens-after guarantee)
+		callbacks, f.callbacks = f.callbacks, nil
+		f.mu.Unlock()
+		if f.cancel != nil {
+			f.cancel() // Releases the context of the computation
+		}
+		completed = true
+	})
+	return callbacks, completed
+}
+
+// notify calls every callback with the result. The panic of a callback is
+// recovered and dropped: it must not skip the other callbacks, nor crash a
+// pool worker or reach a goroutine that merely called Cancel.
+func notify[T any](callbacks []func(T, error), value T, err error) {
+	for _, fn := range callbacks {
+		func() {
+			defer func() {
+				_ = recover()
+			}()
+			fn(value, err)
+		}()
+	}
+}
+
+// baseContext returns the context the computation was started with.
+func (f *future[T]) baseContext() context.Context {
+	return f.base
 }
 
 // Get blocks until the computation completes and returns the result.
@@ -58,9 +316,17 @@ type future[T any] struct {
 // If the context is cancelled or times out before the future completes,
 // returns the zero value of T and the context error.
 //
-// Note: The future's computation continues running in the background even
-// if the context is cancelled. The cancellation only affects this Get call.
+// Note: Get does not cancel the future when ctx is done; the computation
+// keeps running in the background until it completes or Cancel is called.
+// Use FutureDoCtx to tie the computation itself to a context.
 func (f *future[T]) Get(ctx context.Context) (T, error) {
+	// A completed future returns its result even if ctx is done
+	select {
+	case <-f.done:
+		return f.value, f.err
+	default:
+	}
+
 	select {
 	case <-f.done:
 		// Future completed (successfully or with error)
@@ -78,15 +344,80 @@ func (f *future[T]) Get(ctx context.Context) (T, error) {
 	}
 }
 
-// exec executes the function and stores the result.
-// Lock-free: writes happen-before channel close.
-func (f *future[T]) exec() {
-	f.once.Do(func() {
-		f.value, f.err = f.fn()
-		close(f.done) // Signals completion (happens-after guarantee)
+// Done returns a channel that is closed when the future completes.
+func (f *future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// IsDone reports whether the future has completed.
+func (f *future[T]) IsDone() bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// TryGet returns the result and true if the future has completed, without blocking.
+func (f *future[T]) TryGet() (T, error, bool) {
+	if !f.IsDone() {
+		var zero T
+		return zero, nil, false
+	}
+	return f.value, f.err, true
+}
+
+// OnComplete calls fn with the result once the future completes. No goroutine
+// waits for the future: fn is stored and called by complete, or by abort after
+// it has cancelled the dependencies.
+func (f *future[T]) OnComplete(fn func(T, error)) {
+	f.mu.Lock()
+	if !f.IsDone() {
+		f.callbacks = append(f.callbacks, fn)
+		f.mu.Unlock()
+		return
+	}
+	f.mu.Unlock()
+	fn(f.value, f.err)
+}
+
+// OnSuccess calls fn with the value once the future succeeds.
+func (f *future[T]) OnSuccess(fn func(T)) {
+	f.OnComplete(func(value T, err error) {
+		if err == nil {
+			fn(value)
+		}
+	})
+}
+
+// OnFailure calls fn with the error once the future fails.
+func (f *future[T]) OnFailure(fn func(error)) {
+	f.OnComplete(func(_ T, err error) {
+		if err != nil {
+			fn(err)
+		}
 	})
 }
 
+// Cancel completes the future with context.Canceled, cancels the context of
+// its computation and releases the futures it was derived from. It has no
+// effect if the future has already completed.
+func (f *future[T]) Cancel() {
+	f.abort(context.Canceled)
+}
+
+// baseOf returns the context the computation of f was started with, so that
+// derived futures are cancelled along with it, or context.Background().
+func baseOf(f any) context.Context {
+	if b, ok := f.(interface{ baseContext() context.Context }); ok {
+		if ctx := b.baseContext(); ctx != nil {
+			return ctx
+		}
+	}
+	return context.Background()
+}
+
 // FutureThen Then creates a new Future that runs after the current Future successfully
 // completes, transforming the result from type T to type U.
 //
@@ -100,6 +431,12 @@ func (f *future[T]) exec() {
 // chained future with a cancelled context, it will return immediately with
 // the context error, even if the parent is still running.
 //
+// Cancelling the chained future also cancels the parent unless another
+// future derived from the parent still needs it, so cancelling the last
+// future of a chain stops every step still in flight. If the parent was
+// created with FutureDoCtx, the chained future is also cancelled with the
+// parent's context.
+//
 // Example:
 //
 //	// Transform int -> User -> Card
@@ -114,33 +451,68 @@ func (f *future[T]) exec() {
 //	})
 //	card, err := cardFuture.Get(ctx)
 func FutureThen[T, U any](parent Future[T], fn func(T) (U, error)) Future[U] {
-	next := &future[U]{
-		fn: func() (U, error) {
-			// Wait for parent to complete via the interface method.
-			// context.Background() is used here because context cancellation
-			// is handled at the outer Get() level, not here.
-			parentValue, parentErr := parent.Get(context.Background())
-
-			// If parent failed, propagate error
-			if parentErr != nil {
-				var zero U
-				return zero, parentErr
-			}
+	return FutureThenCtx(parent, func(_ context.Context, value T) (U, error) {
+		return fn(value)
+	})
+}
 
-			// Transform T -> U
-			return fn(parentValue)
-		},
-		done: make(chan struct{}),
-	}
+// FutureThenCtx is like FutureThen but passes fn a context that is cancelled
+// when the chained future is cancelled, or when the context of the parent's
+// FutureDoCtx is done.
+//
+// Example:
+//
+//	user := FutureDoCtx(ctx, func(ctx context.Context) (User, error) {
+//	    return fetchUser(ctx, id)
+//	})
+//	card := FutureThenCtx(user, func(ctx context.Context, u User) (Card, error) {
+//	    return fetchCard(ctx, u.CardId)
+//	})
+//	defer card.Cancel() // stops both calls if the request ends early
+func FutureThenCtx[T, U any](parent Future[T], fn func(context.Context, T) (U, error)) Future[U] {
+	return thenOn(InlineExecutor(), parent, fn)
+}
 
-	// Start the chained future immediately
-	go next.exec()
-	return next
+// FutureThenOn is like FutureThen but runs fn on exec once the parent has
+// completed. Waiting for the parent does not occupy exec.
+//
+// Example:
+//
+//	pool := NewFixedPool(4)
+//	thumbnail := FutureThenOn(pool, download, func(img Image) (Image, error) {
+//	    return resize(img, 128)
+//	})
+func FutureThenOn[T, U any](exec Executor, parent Future[T], fn func(T) (U, error)) Future[U] {
+	return thenOn(exec, parent, func(_ context.Context, value T) (U, error) {
+		return fn(value)
+	})
+}
+
+// thenOn implements FutureThenCtx and FutureThenOn.
+func thenOn[T, U any](exec Executor, parent Future[T], fn func(context.Context, T) (U, error)) Future[U] {
+	return derive(baseOf(parent), []canceler{parent}, exec, func(ctx context.Context) (U, error) {
+		// The parent has completed unless it is a foreign implementation of
+		// Future. context.Background() is used here because cancelling this
+		// future completes it directly and cancels the parent.
+		parentValue, parentErr := parent.Get(context.Background())
+
+		// If parent failed, propagate error
+		if parentErr != nil {
+			var zero U
+			return zero, parentErr
+		}
+
+		// Transform T -> U
+		return fn(ctx, parentValue)
+	})
 }
 
 // FutureDo creates a Future that executes the given function asynchronously.
 // The computation starts immediately in a background goroutine (hot start).
 //
+// Cancel completes the future with context.Canceled, but fn itself keeps
+// running until it returns; use FutureDoCtx for computations that can stop early.
+//
 // Example:
 //
 //	future := FutureDo(func() (string, error) {
@@ -154,11 +526,63 @@ func FutureThen[T, U any](parent Future[T], fn func(T) (U, error)) Future[U] {
 //	})
 //	result, err := future.Get(ctx)
 func FutureDo[T any](fn func() (T, error)) Future[T] {
-	f := &future[T]{
-		fn:   fn,
-		done: make(chan struct{}),
-	}
-	go f.exec()
+	return FutureDoCtx(context.Background(), func(context.Context) (T, error) {
+		return fn()
+	})
+}
+
+// FutureDoCtx creates a Future that executes fn asynchronously with a context
+// derived from ctx. The context passed to fn is cancelled when the future is
+// cancelled or ctx is done, and the future then completes immediately with
+// the context error, without waiting for fn to return.
+//
+// Futures derived with FutureThen inherit ctx, so a whole chain stops when
+// the request it serves ends.
+//
+// Example:
+//
+//	future := FutureDoCtx(r.Context(), func(ctx context.Context) (*http.Response, error) {
+//	    req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return http.DefaultClient.Do(req)
+//	})
+//	defer future.Cancel()
+//	resp, err := future.Get(ctx)
+func FutureDoCtx[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) Future[T] {
+	return FutureDoCtxOn(ctx, GoExecutor(), fn)
+}
+
+// FutureDoOn is like FutureDo but runs fn on exec instead of a new goroutine.
+// FutureDoOn blocks if exec applies backpressure, and the future fails with
+// the error of exec, such as ErrExecutorShutdown, if exec rejects fn.
+//
+// Example:
+//
+//	pool := NewBoundedPool(8, 100)
+//	defer pool.Shutdown(context.Background())
+//	future := FutureDoOn(pool, func() (Data, error) {
+//	    return fetchData()
+//	})
+func FutureDoOn[T any](exec Executor, fn func() (T, error)) Future[T] {
+	return FutureDoCtxOn(context.Background(), exec, func(context.Context) (T, error) {
+		return fn()
+	})
+}
+
+// FutureDoCtxOn combines FutureDoCtx and FutureDoOn: fn runs on exec with a
+// context derived from ctx. If the future is cancelled while fn is still
+// queued, fn is not run at all.
+//
+// Example:
+//
+//	future := FutureDoCtxOn(r.Context(), pool, func(ctx context.Context) (Data, error) {
+//	    return fetchData(ctx)
+//	})
+func FutureDoCtxOn[T any](ctx context.Context, exec Executor, fn func(ctx context.Context) (T, error)) Future[T] {
+	f, fctx := newFuture[T](ctx, nil)
+	f.run(fctx, exec, fn)
 	return f
 }
 
@@ -170,12 +594,7 @@ func FutureDo[T any](fn func() (T, error)) Future[T] {
 //	future := FutureOf(42)
 //	value, _ := future.Get(ctx) // Returns 42 immediately
 func FutureOf[T any](value T) Future[T] {
-	f := &future[T]{
-		value: value,
-		done:  make(chan struct{}),
-	}
-	close(f.done)
-	return f
+	return completedFuture(value, nil)
 }
 
 // FutureError creates a Future that is already completed with an error.
@@ -186,12 +605,8 @@ func FutureOf[T any](value T) Future[T] {
 //	future := FutureError[int](errors.New("failed"))
 //	_, err := future.Get(ctx) // Returns error immediately
 func FutureError[T any](err error) Future[T] {
-	f := &future[T]{
-		err:  err,
-		done: make(chan struct{}),
-	}
-	close(f.done)
-	return f
+	var zero T
+	return completedFuture(zero, err)
 }
 
 // FutureAll executes multiple futures of the same type concurrently and
@@ -203,7 +618,8 @@ func FutureError[T any](err error) Future[T] {
 //
 // The returned future respects context cancellation - if you call Get(ctx)
 // with a cancelled or timed-out context, it returns immediately without
-// waiting for all futures to complete.
+// waiting for all futures to complete. Cancelling the returned future also
+// cancels each input future that no other derived future still needs.
 //
 // Example:
 //
@@ -219,32 +635,49 @@ func FutureError[T any](err error) Future[T] {
 //	//    return combineData(data), nil
 //	//})
 func FutureAll[T any](futures ...Future[T]) Future[[]T] {
-	return FutureDo(func() ([]T, error) {
-		results := make([]T, len(futures))
-		errs := make([]error, len(futures))
-		var wg sync.WaitGroup
+	return FutureAllOn(InlineExecutor(), futures...)
+}
 
+// FutureAllOn is like FutureAll but collects the results on exec once every
+// input future has completed. Together with FutureDoOn it bounds a large
+// fan-out: the inputs run on exec and FutureAllOn waits for them with a
+// single goroutine.
+//
+// Example:
+//
+//	pool := NewBoundedPool(16, 256)
+//	futures := make([]Future[Item], len(ids))
+//	for i, id := range ids {
+//	    id := id
+//	    futures[i] = FutureDoOn(pool, func() (Item, error) { return fetchItem(id) })
+//	}
+//	items, err := FutureAllOn(pool, futures...).Get(ctx)
+func FutureAllOn[T any](exec Executor, futures ...Future[T]) Future[[]T] {
+	return derive(context.Background(), cancelers(futures), exec, func(context.Context) ([]T, error) {
+		// Every future has completed, so results are collected in input order
+		// and the first error by input order is returned.
+		results := make([]T, len(futures))
 		for i, f := range futures {
-			wg.Add(1)
-			go func(index int, fut Future[T]) {
-				defer wg.Done()
-				results[index], errs[index] = fut.Get(context.Background())
-			}(i, f)
-		}
-
-		wg.Wait()
-
-		// Return first error encountered
-		for _, err := range errs {
+			value, err := f.Get(context.Background())
 			if err != nil {
 				return nil, err
 			}
+			results[i] = value
 		}
 
 		return results, nil
 	})
 }
 
+// cancelers returns futures as a slice of cancelers.
+func cancelers[T any](futures []Future[T]) []canceler {
+	deps := make([]canceler, len(futures))
+	for i, f := range futures {
+		deps[i] = f
+	}
+	return deps
+}
+
 type anyResult[T any] struct {
 	idx   int
 	value T
@@ -259,13 +692,16 @@ type anyResult[T any] struct {
 // If no futures are provided, FutureAny returns a failed future immediately.
 //
 // The returned future respects context cancellation when Get(ctx) is called.
-// Child futures are not cancelled; they continue running in the background.
+// Once a child succeeds, or the returned future is cancelled, the children
+// are released: each one is cancelled unless another derived future still
+// needs it.
 func FutureAny[T any](futures ...Future[T]) Future[T] {
 	if len(futures) == 0 {
 		return FutureError[T](errors.New("lxtypes: no futures provided"))
 	}
 
-	return FutureDo(func() (T, error) {
+	f, ctx := newFuture[T](context.Background(), cancelers(futures))
+	f.run(ctx, GoExecutor(), func(context.Context) (T, error) {
 		// Buffered channel to avoid blocking sends if we return early
 		ch := make(chan anyResult[T], len(futures))
 
@@ -284,6 +720,8 @@ func FutureAny[T any](futures ...Future[T]) Future[T] {
 		for i := 0; i < len(futures); i++ {
 			r := <-ch
 			if r.err == nil {
+				// Losing branches are no longer needed by this future
+				f.releaseDeps()
 				return r.value, nil
 			}
 			errs[r.idx] = r.err
@@ -301,12 +739,15 @@ func FutureAny[T any](futures ...Future[T]) Future[T] {
 		var zero T
 		return zero, errors.New("lxtypes: unknown error in FutureAny")
 	})
+	return f
 }
 
 // FutureJoin2 executes two futures concurrently and combines their results
 // into a Pair. Returns an error if either future fails.
 //
 // The returned future respects context cancellation when Get(ctx) is called.
+// Cancelling it also cancels f1 and f2 unless another derived future still
+// needs them.
 //
 // Example:
 //
@@ -322,7 +763,13 @@ func FutureAny[T any](futures ...Future[T]) Future[T] {
 //	    return buildResponse(pair.First, pair.Second), nil
 //	})
 func FutureJoin2[T, U any](f1 Future[T], f2 Future[U]) Future[Pair[T, U]] {
-	return FutureDo(func() (Pair[T, U], error) {
+	return FutureJoin2On(InlineExecutor(), f1, f2)
+}
+
+// FutureJoin2On is like FutureJoin2 but combines the results on exec once
+// every input future has completed.
+func FutureJoin2On[T, U any](exec Executor, f1 Future[T], f2 Future[U]) Future[Pair[T, U]] {
+	return derive(context.Background(), []canceler{f1, f2}, exec, func(context.Context) (Pair[T, U], error) {
 		var (
 			v1 T
 			e1 error
@@ -357,6 +804,8 @@ func FutureJoin2[T, U any](f1 Future[T], f2 Future[U]) Future[Pair[T, U]] {
 // into a Triple. Returns an error if any future fails.
 //
 // The returned future respects context cancellation when Get(ctx) is called.
+// Cancelling it also cancels each input future that no other derived future
+// still needs.
 //
 // Example:
 //
@@ -368,7 +817,13 @@ func FutureJoin2[T, U any](f1 Future[T], f2 Future[U]) Future[Pair[T, U]] {
 //	result, err := combined.Get(ctx)
 //	// result.First = User, result.Second = Config, result.Third = Stats
 func FutureJoin3[T, U, V any](f1 Future[T], f2 Future[U], f3 Future[V]) Future[Triple[T, U, V]] {
-	return FutureDo(func() (Triple[T, U, V], error) {
+	return FutureJoin3On(InlineExecutor(), f1, f2, f3)
+}
+
+// FutureJoin3On is like FutureJoin3 but combines the results on exec once
+// every input future has completed.
+func FutureJoin3On[T, U, V any](exec Executor, f1 Future[T], f2 Future[U], f3 Future[V]) Future[Triple[T, U, V]] {
+	return derive(context.Background(), []canceler{f1, f2, f3}, exec, func(context.Context) (Triple[T, U, V], error) {
 		var (
 			v1 T
 			e1 error
@@ -412,8 +867,16 @@ func FutureJoin3[T, U, V any](f1 Future[T], f2 Future[U], f3 Future[V]) Future[T
 // into a Quad. Returns an error if any future fails.
 //
 // The returned future respects context cancellation when Get(ctx) is called.
+// Cancelling it also cancels each input future that no other derived future
+// still needs.
 func FutureJoin4[T, U, V, W any](f1 Future[T], f2 Future[U], f3 Future[V], f4 Future[W]) Future[Quad[T, U, V, W]] {
-	return FutureDo(func() (Quad[T, U, V, W], error) {
+	return FutureJoin4On(InlineExecutor(), f1, f2, f3, f4)
+}
+
+// FutureJoin4On is like FutureJoin4 but combines the results on exec once
+// every input future has completed.
+func FutureJoin4On[T, U, V, W any](exec Executor, f1 Future[T], f2 Future[U], f3 Future[V], f4 Future[W]) Future[Quad[T, U, V, W]] {
+	return derive(context.Background(), []canceler{f1, f2, f3, f4}, exec, func(context.Context) (Quad[T, U, V, W], error) {
 		var (
 			v1 T
 			e1 error
@@ -466,6 +929,8 @@ func FutureJoin4[T, U, V, W any](f1 Future[T], f2 Future[U], f3 Future[V], f4 Fu
 // into a Tuple5. Returns an error if any future fails.
 //
 // The returned future respects context cancellation when Get(ctx) is called.
+// Cancelling it also cancels each input future that no other derived future
+// still needs.
 //
 // Example:
 //
@@ -479,7 +944,13 @@ func FutureJoin4[T, U, V, W any](f1 Future[T], f2 Future[U], f3 Future[V], f4 Fu
 //	result, err := combined.Get(ctx)
 //	// Access: result.V1, result.V2, result.V3, result.V4, result.V5
 func FutureJoin5[T1, T2, T3, T4, T5 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5]) Future[Tuple5[T1, T2, T3, T4, T5]] {
-	return FutureDo(func() (Tuple5[T1, T2, T3, T4, T5], error) {
+	return FutureJoin5On(InlineExecutor(), f1, f2, f3, f4, f5)
+}
+
+// FutureJoin5On is like FutureJoin5 but combines the results on exec once
+// every input future has completed.
+func FutureJoin5On[T1, T2, T3, T4, T5 any](exec Executor, f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5]) Future[Tuple5[T1, T2, T3, T4, T5]] {
+	return derive(context.Background(), []canceler{f1, f2, f3, f4, f5}, exec, func(context.Context) (Tuple5[T1, T2, T3, T4, T5], error) {
 		var (
 			v1 T1
 			e1 error
@@ -527,6 +998,8 @@ func FutureJoin5[T1, T2, T3, T4, T5 any](f1 Future[T1], f2 Future[T2], f3 Future
 // into a Tuple6. Returns an error if any future fails.
 //
 // The returned future respects context cancellation when Get(ctx) is called.
+// Cancelling it also cancels each input future that no other derived future
+// still needs.
 //
 // Example:
 //
@@ -540,7 +1013,13 @@ func FutureJoin5[T1, T2, T3, T4, T5 any](f1 Future[T1], f2 Future[T2], f3 Future
 //	combined := FutureJoin6(f1, f2, f3, f4, f5, f6)
 //	result, err := combined.Get(ctx)
 func FutureJoin6[T1, T2, T3, T4, T5, T6 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5], f6 Future[T6]) Future[Tuple6[T1, T2, T3, T4, T5, T6]] {
-	return FutureDo(func() (Tuple6[T1, T2, T3, T4, T5, T6], error) {
+	return FutureJoin6On(InlineExecutor(), f1, f2, f3, f4, f5, f6)
+}
+
+// FutureJoin6On is like FutureJoin6 but combines the results on exec once
+// every input future has completed.
+func FutureJoin6On[T1, T2, T3, T4, T5, T6 any](exec Executor, f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5], f6 Future[T6]) Future[Tuple6[T1, T2, T3, T4, T5, T6]] {
+	return derive(context.Background(), []canceler{f1, f2, f3, f4, f5, f6}, exec, func(context.Context) (Tuple6[T1, T2, T3, T4, T5, T6], error) {
 		var (
 			v1 T1
 			e1 error
@@ -594,8 +1073,16 @@ func FutureJoin6[T1, T2, T3, T4, T5, T6 any](f1 Future[T1], f2 Future[T2], f3 Fu
 // into a Tuple7. Returns an error if any future fails.
 //
 // The returned future respects context cancellation when Get(ctx) is called.
+// Cancelling it also cancels each input future that no other derived future
+// still needs.
 func FutureJoin7[T1, T2, T3, T4, T5, T6, T7 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5], f6 Future[T6], f7 Future[T7]) Future[Tuple7[T1, T2, T3, T4, T5, T6, T7]] {
-	return FutureDo(func() (Tuple7[T1, T2, T3, T4, T5, T6, T7], error) {
+	return FutureJoin7On(InlineExecutor(), f1, f2, f3, f4, f5, f6, f7)
+}
+
+// FutureJoin7On is like FutureJoin7 but combines the results on exec once
+// every input future has completed.
+func FutureJoin7On[T1, T2, T3, T4, T5, T6, T7 any](exec Executor, f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5], f6 Future[T6], f7 Future[T7]) Future[Tuple7[T1, T2, T3, T4, T5, T6, T7]] {
+	return derive(context.Background(), []canceler{f1, f2, f3, f4, f5, f6, f7}, exec, func(context.Context) (Tuple7[T1, T2, T3, T4, T5, T6, T7], error) {
 		var (
 			v1 T1
 			e1 error
@@ -655,8 +1142,16 @@ func FutureJoin7[T1, T2, T3, T4, T5, T6, T7 any](f1 Future[T1], f2 Future[T2], f
 // into a Tuple8. Returns an error if any future fails.
 //
 // The returned future respects context cancellation when Get(ctx) is called.
+// Cancelling it also cancels each input future that no other derived future
+// still needs.
 func FutureJoin8[T1, T2, T3, T4, T5, T6, T7, T8 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5], f6 Future[T6], f7 Future[T7], f8 Future[T8]) Future[Tuple8[T1, T2, T3, T4, T5, T6, T7, T8]] {
-	return FutureDo(func() (Tuple8[T1, T2, T3, T4, T5, T6, T7, T8], error) {
+	return FutureJoin8On(InlineExecutor(), f1, f2, f3, f4, f5, f6, f7, f8)
+}
+
+// FutureJoin8On is like FutureJoin8 but combines the results on exec once
+// every input future has completed.
+func FutureJoin8On[T1, T2, T3, T4, T5, T6, T7, T8 any](exec Executor, f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5], f6 Future[T6], f7 Future[T7], f8 Future[T8]) Future[Tuple8[T1, T2, T3, T4, T5, T6, T7, T8]] {
+	return derive(context.Background(), []canceler{f1, f2, f3, f4, f5, f6, f7, f8}, exec, func(context.Context) (Tuple8[T1, T2, T3, T4, T5, T6, T7, T8], error) {
 		var (
 			v1 T1
 			e1 error
@@ -0,0 +1,593 @@
+package lxtypes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// IndexedError is the failure of one input future of an aggregating future,
+// identified by its position: the index in the arguments of FutureAll*, or
+// the position minus one for FutureJoin* (f1 has index 0).
+type IndexedError struct {
+	Index int   // Position of the failed future, starting at 0
+	Err   error // Error of the failed future
+}
+
+// Error returns the index followed by the error, e.g. "future 2: timeout".
+func (e *IndexedError) Error() string {
+	return fmt.Sprintf("future %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error of the failed future.
+func (e *IndexedError) Unwrap() error {
+	return e.Err
+}
+
+// AggregateError lists every failed input of FutureAllAggregate and
+// FutureJoin*Aggregate, in input order. Like the result of errors.Join, it
+// matches any of its errors with errors.Is.
+type AggregateError struct {
+	Errors []*IndexedError
+}
+
+// Error joins the failures with "; ".
+func (e *AggregateError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("lxtypes: %d futures failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the failures.
+func (e *AggregateError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Is reports whether any failure matches target.
+func (e *AggregateError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewAggregateError returns an *AggregateError listing the non-nil errors of
+// errs with their index, or nil if every error is nil.
+//
+// Example:
+//
+//	err := NewAggregateError([]error{nil, errTimeout, nil, errNotFound})
+//	fmt.Println(err) // lxtypes: 2 futures failed: future 1: timeout; future 3: not found
+func NewAggregateError(errs []error) error {
+	var failed []*IndexedError
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, &IndexedError{Index: i, Err: err})
+		}
+	}
+	if failed == nil {
+		return nil
+	}
+	return &AggregateError{Errors: failed}
+}
+
+// gatherMode selects how an aggregating future handles failed inputs.
+type gatherMode int
+
+const (
+	gatherFailFast  gatherMode = iota // Fail on the first failure and cancel the other inputs
+	gatherAggregate                   // Wait for every input and fail with an *AggregateError
+	gatherSettled                     // Wait for every input and never fail
+)
+
+// gather starts an aggregating future over deps. Each getter waits for one
+// input, stores its value and returns its error; build assembles the result
+// from the stored values once every getter has returned.
+func gather[R any](mode gatherMode, deps []canceler, getters []func() error, build func(errs []error) R) Future[R] {
+	errs := make([]error, len(getters))
+	if mode != gatherFailFast {
+		return derive(context.Background(), deps, InlineExecutor(), func(context.Context) (R, error) {
+			// Every input has completed, so the getters return right away
+			for i, get := range getters {
+				errs[i] = get()
+			}
+			if mode == gatherAggregate {
+				if err := NewAggregateError(errs); err != nil {
+					var zero R
+					return zero, err
+				}
+			}
+			return build(errs), nil
+		})
+	}
+
+	f, _ := newFuture[R](context.Background(), deps)
+	var wg sync.WaitGroup
+	wg.Add(len(getters))
+	for i, get := range getters {
+		go func(index int, get func() error) {
+			defer wg.Done()
+			if err := get(); err != nil {
+				errs[index] = err
+				// Completes the future and releases the other inputs
+				f.abort(&IndexedError{Index: index, Err: err})
+			}
+		}(i, get)
+	}
+	go func() {
+		wg.Wait()
+		select {
+		case <-f.done:
+			// Failed or cancelled
+		default:
+			f.complete(build(errs), nil)
+		}
+	}()
+	return f
+}
+
+// getter returns a function that waits for f, stores its value in dst and
+// returns its error.
+func getter[T any](f Future[T], dst *T) func() error {
+	return func() error {
+		value, err := f.Get(context.Background())
+		*dst = value
+		return err
+	}
+}
+
+// resultOf returns value and err as a Result.
+func resultOf[T any](value T, err error) Result[T] {
+	if err != nil {
+		return ResultFailure[T](err)
+	}
+	return ResultSuccess(value)
+}
+
+// allGetters returns a getter for every future, storing values in a new slice.
+func allGetters[T any](futures []Future[T]) ([]T, []func() error) {
+	values := make([]T, len(futures))
+	getters := make([]func() error, len(futures))
+	for i, f := range futures {
+		getters[i] = getter(f, &values[i])
+	}
+	return values, getters
+}
+
+// FutureAllFailFast is like FutureAll but fails as soon as any future fails,
+// without waiting for the others, which are released as by Cancel. The error is an
+// *IndexedError wrapping the first failure to complete.
+//
+// Cancelling the returned future also cancels each input future that no
+// other derived future still needs.
+//
+// Example:
+//
+//	all := FutureAllFailFast(fetchShard(0), fetchShard(1), fetchShard(2))
+//	records, err := all.Get(ctx)
+//	var failed *IndexedError
+//	if errors.As(err, &failed) {
+//	    log.Printf("shard %d failed: %v", failed.Index, failed.Err)
+//	}
+func FutureAllFailFast[T any](futures ...Future[T]) Future[[]T] {
+	values, getters := allGetters(futures)
+	return gather(gatherFailFast, cancelers(futures), getters, func([]error) []T {
+		return values
+	})
+}
+
+// FutureAllAggregate is like FutureAll but, if any future fails, fails with
+// an *AggregateError listing every failure by index. Succeeds with all the
+// values, in input order, if no future fails.
+//
+// Cancelling the returned future also cancels each input future that no
+// other derived future still needs.
+//
+// Example:
+//
+//	_, err := FutureAllAggregate(f1, f2, f3).Get(ctx)
+//	fmt.Println(err) // lxtypes: 2 futures failed: future 0: timeout; future 2: not found
+//	if errors.Is(err, ErrNotFound) { // Matches any of the failures
+//	    // ...
+//	}
+func FutureAllAggregate[T any](futures ...Future[T]) Future[[]T] {
+	values, getters := allGetters(futures)
+	return gather(gatherAggregate, cancelers(futures), getters, func([]error) []T {
+		return values
+	})
+}
+
+// FutureAllSettled waits for every future and returns the outcome of each
+// one as a Result, in input order. The returned future does not fail, unless
+// it is cancelled.
+//
+// Cancelling the returned future also cancels each input future that no
+// other derived future still needs.
+//
+// Example:
+//
+//	results, _ := FutureAllSettled(f1, f2, f3).Get(ctx)
+//	for i, r := range results {
+//	    if value, err := r.Value(); err != nil {
+//	        log.Printf("future %d failed: %v", i, err)
+//	    } else {
+//	        use(value)
+//	    }
+//	}
+func FutureAllSettled[T any](futures ...Future[T]) Future[[]Result[T]] {
+	values, getters := allGetters(futures)
+	return gather(gatherSettled, cancelers(futures), getters, func(errs []error) []Result[T] {
+		results := make([]Result[T], len(values))
+		for i, value := range values {
+			results[i] = resultOf(value, errs[i])
+		}
+		return results
+	})
+}
+
+// FutureJoin2FailFast is like FutureJoin2 but fails as soon as either future
+// fails, with an *IndexedError, and releases the other one as by Cancel.
+//
+// Example:
+//
+//	combined := FutureJoin2FailFast(user, config)
+//	pair, err := combined.Get(ctx) // Returns early if config fails before user completes
+func FutureJoin2FailFast[T, U any](f1 Future[T], f2 Future[U]) Future[Pair[T, U]] {
+	var (
+		v1 T
+		v2 U
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2)}
+	return gather(gatherFailFast, []canceler{f1, f2}, getters, func([]error) Pair[T, U] {
+		return NewPair(v1, v2)
+	})
+}
+
+// FutureJoin2Aggregate is like FutureJoin2 but, if any future fails, fails
+// with an *AggregateError listing every failure (index 0 for f1, 1 for f2).
+//
+// Example:
+//
+//	_, err := FutureJoin2Aggregate(user, config).Get(ctx)
+//	var agg *AggregateError
+//	if errors.As(err, &agg) {
+//	    for _, failed := range agg.Errors {
+//	        log.Printf("input %d: %v", failed.Index, failed.Err)
+//	    }
+//	}
+func FutureJoin2Aggregate[T, U any](f1 Future[T], f2 Future[U]) Future[Pair[T, U]] {
+	var (
+		v1 T
+		v2 U
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2)}
+	return gather(gatherAggregate, []canceler{f1, f2}, getters, func([]error) Pair[T, U] {
+		return NewPair(v1, v2)
+	})
+}
+
+// FutureJoin2Settled waits for both futures and returns the outcome of each
+// one as a Result. The returned future does not fail, unless it is cancelled.
+//
+// Example:
+//
+//	pair, _ := FutureJoin2Settled(user, config).Get(ctx)
+//	u, err := pair.First.Value()
+//	cfg := pair.Second.ValueOr(defaultConfig)
+func FutureJoin2Settled[T, U any](f1 Future[T], f2 Future[U]) Future[Pair[Result[T], Result[U]]] {
+	var (
+		v1 T
+		v2 U
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2)}
+	return gather(gatherSettled, []canceler{f1, f2}, getters, func(errs []error) Pair[Result[T], Result[U]] {
+		return NewPair(resultOf(v1, errs[0]), resultOf(v2, errs[1]))
+	})
+}
+
+// FutureJoin3FailFast is like FutureJoin3 but fails as soon as any future
+// fails, with an *IndexedError, and releases the others as by Cancel.
+func FutureJoin3FailFast[T, U, V any](f1 Future[T], f2 Future[U], f3 Future[V]) Future[Triple[T, U, V]] {
+	var (
+		v1 T
+		v2 U
+		v3 V
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3)}
+	return gather(gatherFailFast, []canceler{f1, f2, f3}, getters, func([]error) Triple[T, U, V] {
+		return NewTriple(v1, v2, v3)
+	})
+}
+
+// FutureJoin3Aggregate is like FutureJoin3 but, if any future fails, fails
+// with an *AggregateError listing every failure.
+func FutureJoin3Aggregate[T, U, V any](f1 Future[T], f2 Future[U], f3 Future[V]) Future[Triple[T, U, V]] {
+	var (
+		v1 T
+		v2 U
+		v3 V
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3)}
+	return gather(gatherAggregate, []canceler{f1, f2, f3}, getters, func([]error) Triple[T, U, V] {
+		return NewTriple(v1, v2, v3)
+	})
+}
+
+// FutureJoin3Settled waits for all three futures and returns the outcome of
+// each one as a Result. The returned future does not fail, unless it is cancelled.
+func FutureJoin3Settled[T, U, V any](f1 Future[T], f2 Future[U], f3 Future[V]) Future[Triple[Result[T], Result[U], Result[V]]] {
+	var (
+		v1 T
+		v2 U
+		v3 V
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3)}
+	return gather(gatherSettled, []canceler{f1, f2, f3}, getters, func(errs []error) Triple[Result[T], Result[U], Result[V]] {
+		return NewTriple(resultOf(v1, errs[0]), resultOf(v2, errs[1]), resultOf(v3, errs[2]))
+	})
+}
+
+// FutureJoin4FailFast is like FutureJoin4 but fails as soon as any future
+// fails, with an *IndexedError, and releases the others as by Cancel.
+func FutureJoin4FailFast[T, U, V, W any](f1 Future[T], f2 Future[U], f3 Future[V], f4 Future[W]) Future[Quad[T, U, V, W]] {
+	var (
+		v1 T
+		v2 U
+		v3 V
+		v4 W
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3), getter(f4, &v4)}
+	return gather(gatherFailFast, []canceler{f1, f2, f3, f4}, getters, func([]error) Quad[T, U, V, W] {
+		return NewQuad(v1, v2, v3, v4)
+	})
+}
+
+// FutureJoin4Aggregate is like FutureJoin4 but, if any future fails, fails
+// with an *AggregateError listing every failure.
+func FutureJoin4Aggregate[T, U, V, W any](f1 Future[T], f2 Future[U], f3 Future[V], f4 Future[W]) Future[Quad[T, U, V, W]] {
+	var (
+		v1 T
+		v2 U
+		v3 V
+		v4 W
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3), getter(f4, &v4)}
+	return gather(gatherAggregate, []canceler{f1, f2, f3, f4}, getters, func([]error) Quad[T, U, V, W] {
+		return NewQuad(v1, v2, v3, v4)
+	})
+}
+
+// FutureJoin4Settled waits for all four futures and returns the outcome of
+// each one as a Result. The returned future does not fail, unless it is cancelled.
+func FutureJoin4Settled[T, U, V, W any](f1 Future[T], f2 Future[U], f3 Future[V], f4 Future[W]) Future[Quad[Result[T], Result[U], Result[V], Result[W]]] {
+	var (
+		v1 T
+		v2 U
+		v3 V
+		v4 W
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3), getter(f4, &v4)}
+	return gather(gatherSettled, []canceler{f1, f2, f3, f4}, getters, func(errs []error) Quad[Result[T], Result[U], Result[V], Result[W]] {
+		return NewQuad(resultOf(v1, errs[0]), resultOf(v2, errs[1]), resultOf(v3, errs[2]), resultOf(v4, errs[3]))
+	})
+}
+
+// FutureJoin5FailFast is like FutureJoin5 but fails as soon as any future
+// fails, with an *IndexedError, and releases the others as by Cancel.
+func FutureJoin5FailFast[T1, T2, T3, T4, T5 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5]) Future[Tuple5[T1, T2, T3, T4, T5]] {
+	var (
+		v1 T1
+		v2 T2
+		v3 T3
+		v4 T4
+		v5 T5
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3), getter(f4, &v4), getter(f5, &v5)}
+	return gather(gatherFailFast, []canceler{f1, f2, f3, f4, f5}, getters, func([]error) Tuple5[T1, T2, T3, T4, T5] {
+		return NewTuple5(v1, v2, v3, v4, v5)
+	})
+}
+
+// FutureJoin5Aggregate is like FutureJoin5 but, if any future fails, fails
+// with an *AggregateError listing every failure.
+func FutureJoin5Aggregate[T1, T2, T3, T4, T5 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5]) Future[Tuple5[T1, T2, T3, T4, T5]] {
+	var (
+		v1 T1
+		v2 T2
+		v3 T3
+		v4 T4
+		v5 T5
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3), getter(f4, &v4), getter(f5, &v5)}
+	return gather(gatherAggregate, []canceler{f1, f2, f3, f4, f5}, getters, func([]error) Tuple5[T1, T2, T3, T4, T5] {
+		return NewTuple5(v1, v2, v3, v4, v5)
+	})
+}
+
+// FutureJoin5Settled waits for all five futures and returns the outcome of
+// each one as a Result. The returned future does not fail, unless it is cancelled.
+func FutureJoin5Settled[T1, T2, T3, T4, T5 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5]) Future[Tuple5[Result[T1], Result[T2], Result[T3], Result[T4], Result[T5]]] {
+	var (
+		v1 T1
+		v2 T2
+		v3 T3
+		v4 T4
+		v5 T5
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3), getter(f4, &v4), getter(f5, &v5)}
+	return gather(gatherSettled, []canceler{f1, f2, f3, f4, f5}, getters, func(errs []error) Tuple5[Result[T1], Result[T2], Result[T3], Result[T4], Result[T5]] {
+		return NewTuple5(resultOf(v1, errs[0]), resultOf(v2, errs[1]), resultOf(v3, errs[2]), resultOf(v4, errs[3]), resultOf(v5, errs[4]))
+	})
+}
+
+// FutureJoin6FailFast is like FutureJoin6 but fails as soon as any future
+// fails, with an *IndexedError, and releases the others as by Cancel.
+func FutureJoin6FailFast[T1, T2, T3, T4, T5, T6 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5], f6 Future[T6]) Future[Tuple6[T1, T2, T3, T4, T5, T6]] {
+	var (
+		v1 T1
+		v2 T2
+		v3 T3
+		v4 T4
+		v5 T5
+		v6 T6
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3), getter(f4, &v4), getter(f5, &v5), getter(f6, &v6)}
+	return gather(gatherFailFast, []canceler{f1, f2, f3, f4, f5, f6}, getters, func([]error) Tuple6[T1, T2, T3, T4, T5, T6] {
+		return NewTuple6(v1, v2, v3, v4, v5, v6)
+	})
+}
+
+// FutureJoin6Aggregate is like FutureJoin6 but, if any future fails, fails
+// with an *AggregateError listing every failure.
+func FutureJoin6Aggregate[T1, T2, T3, T4, T5, T6 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5], f6 Future[T6]) Future[Tuple6[T1, T2, T3, T4, T5, T6]] {
+	var (
+		v1 T1
+		v2 T2
+		v3 T3
+		v4 T4
+		v5 T5
+		v6 T6
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3), getter(f4, &v4), getter(f5, &v5), getter(f6, &v6)}
+	return gather(gatherAggregate, []canceler{f1, f2, f3, f4, f5, f6}, getters, func([]error) Tuple6[T1, T2, T3, T4, T5, T6] {
+		return NewTuple6(v1, v2, v3, v4, v5, v6)
+	})
+}
+
+// FutureJoin6Settled waits for all six futures and returns the outcome of
+// each one as a Result. The returned future does not fail, unless it is cancelled.
+func FutureJoin6Settled[T1, T2, T3, T4, T5, T6 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5], f6 Future[T6]) Future[Tuple6[Result[T1], Result[T2], Result[T3], Result[T4], Result[T5], Result[T6]]] {
+	var (
+		v1 T1
+		v2 T2
+		v3 T3
+		v4 T4
+		v5 T5
+		v6 T6
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3), getter(f4, &v4), getter(f5, &v5), getter(f6, &v6)}
+	return gather(gatherSettled, []canceler{f1, f2, f3, f4, f5, f6}, getters, func(errs []error) Tuple6[Result[T1], Result[T2], Result[T3], Result[T4], Result[T5], Result[T6]] {
+		return NewTuple6(resultOf(v1, errs[0]), resultOf(v2, errs[1]), resultOf(v3, errs[2]), resultOf(v4, errs[3]), resultOf(v5, errs[4]), resultOf(v6, errs[5]))
+	})
+}
+
+// FutureJoin7FailFast is like FutureJoin7 but fails as soon as any future
+// fails, with an *IndexedError, and releases the others as by Cancel.
+func FutureJoin7FailFast[T1, T2, T3, T4, T5, T6, T7 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5], f6 Future[T6], f7 Future[T7]) Future[Tuple7[T1, T2, T3, T4, T5, T6, T7]] {
+	var (
+		v1 T1
+		v2 T2
+		v3 T3
+		v4 T4
+		v5 T5
+		v6 T6
+		v7 T7
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3), getter(f4, &v4), getter(f5, &v5), getter(f6, &v6), getter(f7, &v7)}
+	return gather(gatherFailFast, []canceler{f1, f2, f3, f4, f5, f6, f7}, getters, func([]error) Tuple7[T1, T2, T3, T4, T5, T6, T7] {
+		return NewTuple7(v1, v2, v3, v4, v5, v6, v7)
+	})
+}
+
+// FutureJoin7Aggregate is like FutureJoin7 but, if any future fails, fails
+// with an *AggregateError listing every failure.
+func FutureJoin7Aggregate[T1, T2, T3, T4, T5, T6, T7 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5], f6 Future[T6], f7 Future[T7]) Future[Tuple7[T1, T2, T3, T4, T5, T6, T7]] {
+	var (
+		v1 T1
+		v2 T2
+		v3 T3
+		v4 T4
+		v5 T5
+		v6 T6
+		v7 T7
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3), getter(f4, &v4), getter(f5, &v5), getter(f6, &v6), getter(f7, &v7)}
+	return gather(gatherAggregate, []canceler{f1, f2, f3, f4, f5, f6, f7}, getters, func([]error) Tuple7[T1, T2, T3, T4, T5, T6, T7] {
+		return NewTuple7(v1, v2, v3, v4, v5, v6, v7)
+	})
+}
+
+// FutureJoin7Settled waits for all seven futures and returns the outcome of
+// each one as a Result. The returned future does not fail, unless it is cancelled.
+func FutureJoin7Settled[T1, T2, T3, T4, T5, T6, T7 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5], f6 Future[T6], f7 Future[T7]) Future[Tuple7[Result[T1], Result[T2], Result[T3], Result[T4], Result[T5], Result[T6], Result[T7]]] {
+	var (
+		v1 T1
+		v2 T2
+		v3 T3
+		v4 T4
+		v5 T5
+		v6 T6
+		v7 T7
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3), getter(f4, &v4), getter(f5, &v5), getter(f6, &v6), getter(f7, &v7)}
+	return gather(gatherSettled, []canceler{f1, f2, f3, f4, f5, f6, f7}, getters, func(errs []error) Tuple7[Result[T1], Result[T2], Result[T3], Result[T4], Result[T5], Result[T6], Result[T7]] {
+		return NewTuple7(resultOf(v1, errs[0]), resultOf(v2, errs[1]), resultOf(v3, errs[2]), resultOf(v4, errs[3]), resultOf(v5, errs[4]), resultOf(v6, errs[5]), resultOf(v7, errs[6]))
+	})
+}
+
+// FutureJoin8FailFast is like FutureJoin8 but fails as soon as any future
+// fails, with an *IndexedError, and releases the others as by Cancel.
+func FutureJoin8FailFast[T1, T2, T3, T4, T5, T6, T7, T8 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5], f6 Future[T6], f7 Future[T7], f8 Future[T8]) Future[Tuple8[T1, T2, T3, T4, T5, T6, T7, T8]] {
+	var (
+		v1 T1
+		v2 T2
+		v3 T3
+		v4 T4
+		v5 T5
+		v6 T6
+		v7 T7
+		v8 T8
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3), getter(f4, &v4), getter(f5, &v5), getter(f6, &v6), getter(f7, &v7), getter(f8, &v8)}
+	return gather(gatherFailFast, []canceler{f1, f2, f3, f4, f5, f6, f7, f8}, getters, func([]error) Tuple8[T1, T2, T3, T4, T5, T6, T7, T8] {
+		return NewTuple8(v1, v2, v3, v4, v5, v6, v7, v8)
+	})
+}
+
+// FutureJoin8Aggregate is like FutureJoin8 but, if any future fails, fails
+// with an *AggregateError listing every failure.
+func FutureJoin8Aggregate[T1, T2, T3, T4, T5, T6, T7, T8 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5], f6 Future[T6], f7 Future[T7], f8 Future[T8]) Future[Tuple8[T1, T2, T3, T4, T5, T6, T7, T8]] {
+	var (
+		v1 T1
+		v2 T2
+		v3 T3
+		v4 T4
+		v5 T5
+		v6 T6
+		v7 T7
+		v8 T8
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3), getter(f4, &v4), getter(f5, &v5), getter(f6, &v6), getter(f7, &v7), getter(f8, &v8)}
+	return gather(gatherAggregate, []canceler{f1, f2, f3, f4, f5, f6, f7, f8}, getters, func([]error) Tuple8[T1, T2, T3, T4, T5, T6, T7, T8] {
+		return NewTuple8(v1, v2, v3, v4, v5, v6, v7, v8)
+	})
+}
+
+// FutureJoin8Settled waits for all eight futures and returns the outcome of
+// each one as a Result. The returned future does not fail, unless it is cancelled.
+func FutureJoin8Settled[T1, T2, T3, T4, T5, T6, T7, T8 any](f1 Future[T1], f2 Future[T2], f3 Future[T3], f4 Future[T4], f5 Future[T5], f6 Future[T6], f7 Future[T7], f8 Future[T8]) Future[Tuple8[Result[T1], Result[T2], Result[T3], Result[T4], Result[T5], Result[T6], Result[T7], Result[T8]]] {
+	var (
+		v1 T1
+		v2 T2
+		v3 T3
+		v4 T4
+		v5 T5
+		v6 T6
+		v7 T7
+		v8 T8
+	)
+	getters := []func() error{getter(f1, &v1), getter(f2, &v2), getter(f3, &v3), getter(f4, &v4), getter(f5, &v5), getter(f6, &v6), getter(f7, &v7), getter(f8, &v8)}
+	return gather(gatherSettled, []canceler{f1, f2, f3, f4, f5, f6, f7, f8}, getters, func(errs []error) Tuple8[Result[T1], Result[T2], Result[T3], Result[T4], Result[T5], Result[T6], Result[T7], Result[T8]] {
+		return NewTuple8(resultOf(v1, errs[0]), resultOf(v2, errs[1]), resultOf(v3, errs[2]), resultOf(v4, errs[3]), resultOf(v5, errs[4]), resultOf(v6, errs[5]), resultOf(v7, errs[6]), resultOf(v8, errs[7]))
+	})
+}
@@ -0,0 +1,277 @@
+package lxtypes
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// ========================================
+// AggregateError Tests
+// ========================================
+
+func TestNewAggregateError(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+
+	if err := NewAggregateError([]error{nil, nil}); err != nil {
+		t.Errorf("NewAggregateError(nil errors) = %v, want nil", err)
+	}
+
+	err := NewAggregateError([]error{nil, errA, nil, errB})
+	if got, want := err.Error(), "lxtypes: 2 futures failed: future 1: a; future 3: b"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Error("errors.Is() should match every failure")
+	}
+	if errors.Is(err, context.Canceled) {
+		t.Error("errors.Is() matched an unrelated error")
+	}
+
+	var agg *AggregateError
+	if !errors.As(err, &agg) {
+		t.Fatal("errors.As() did not find the *AggregateError")
+	}
+	if len(agg.Errors) != 2 || agg.Errors[0].Index != 1 || agg.Errors[1].Index != 3 {
+		t.Errorf("Errors = %v, want indexes 1 and 3", agg.Errors)
+	}
+	if unwrapped := agg.Unwrap(); len(unwrapped) != 2 || !errors.Is(unwrapped[1], errB) {
+		t.Errorf("Unwrap() = %v", unwrapped)
+	}
+}
+
+// ========================================
+// FutureAll Modes Tests
+// ========================================
+
+func TestFutureAllFailFast(t *testing.T) {
+	errFailed := errors.New("failed")
+	slow, stopped := blockingFuture(context.Background())
+	failing := FutureDo(func() (int, error) {
+		time.Sleep(5 * time.Millisecond)
+		return 0, errFailed
+	})
+
+	_, err := FutureAllFailFast(slow, failing, FutureOf(3)).Get(context.Background())
+
+	var failed *IndexedError
+	if !errors.As(err, &failed) || failed.Index != 1 || !errors.Is(err, errFailed) {
+		t.Fatalf("Get() error = %v, want future 1: failed", err)
+	}
+	waitStopped(t, "slow future", stopped)
+}
+
+func TestFutureAllFailFast_Success(t *testing.T) {
+	tests := []struct {
+		name    string
+		futures []Future[int]
+		want    []int
+	}{
+		{"empty", nil, []int{}},
+		{"values", []Future[int]{FutureOf(1), FutureDo(func() (int, error) { return 2, nil })}, []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FutureAllFailFast(tt.futures...).Get(context.Background())
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() = (%v, %v), want (%v, nil)", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFutureAllAggregate(t *testing.T) {
+	err1 := errors.New("e1")
+	err3 := errors.New("e3")
+
+	_, err := FutureAllAggregate(
+		FutureOf(0),
+		FutureError[int](err1),
+		FutureOf(2),
+		FutureDo(func() (int, error) {
+			time.Sleep(5 * time.Millisecond)
+			return 0, err3
+		}),
+	).Get(context.Background())
+
+	var agg *AggregateError
+	if !errors.As(err, &agg) {
+		t.Fatalf("Get() error = %v, want *AggregateError", err)
+	}
+	want := []*IndexedError{{Index: 1, Err: err1}, {Index: 3, Err: err3}}
+	if !reflect.DeepEqual(agg.Errors, want) {
+		t.Errorf("Errors = %v, want %v", agg.Errors, want)
+	}
+
+	values, err := FutureAllAggregate(FutureOf(1), FutureOf(2)).Get(context.Background())
+	if err != nil || !reflect.DeepEqual(values, []int{1, 2}) {
+		t.Errorf("Get() = (%v, %v), want ([1 2], nil)", values, err)
+	}
+}
+
+func TestFutureAllSettled(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	results, err := FutureAllSettled(FutureOf(1), FutureError[int](errFailed), FutureOf(3)).Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() returned unexpected error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Get() returned %d results, want 3", len(results))
+	}
+	for i, want := range []struct {
+		value int
+		err   error
+	}{{1, nil}, {0, errFailed}, {3, nil}} {
+		if value, err := results[i].Value(); value != want.value || err != want.err {
+			t.Errorf("results[%d] = (%v, %v), want (%v, %v)", i, value, err, want.value, want.err)
+		}
+	}
+}
+
+func TestFutureAllModes_CancelPropagates(t *testing.T) {
+	modes := map[string]func(...Future[int]) canceler{
+		"FailFast":  func(fs ...Future[int]) canceler { return FutureAllFailFast(fs...) },
+		"Aggregate": func(fs ...Future[int]) canceler { return FutureAllAggregate(fs...) },
+		"Settled":   func(fs ...Future[int]) canceler { return FutureAllSettled(fs...) },
+	}
+
+	for name, mode := range modes {
+		t.Run(name, func(t *testing.T) {
+			f1, stopped1 := blockingFuture(context.Background())
+			f2, stopped2 := blockingFuture(context.Background())
+			mode(f1, f2).Cancel()
+			waitStopped(t, "f1", stopped1)
+			waitStopped(t, "f2", stopped2)
+		})
+	}
+}
+
+// ========================================
+// FutureJoin Modes Tests
+// ========================================
+
+func TestFutureJoin2Modes(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	pair, err := FutureJoin2FailFast(FutureOf(1), FutureOf("two")).Get(context.Background())
+	if err != nil || pair != NewPair(1, "two") {
+		t.Errorf("FutureJoin2FailFast() = (%v, %v)", pair, err)
+	}
+
+	slow, stopped := blockingFuture(context.Background())
+	_, err = FutureJoin2FailFast(slow, FutureError[string](errFailed)).Get(context.Background())
+	var failed *IndexedError
+	if !errors.As(err, &failed) || failed.Index != 1 || failed.Err != errFailed {
+		t.Errorf("FutureJoin2FailFast() error = %v, want future 1: failed", err)
+	}
+	waitStopped(t, "slow future", stopped)
+
+	_, err = FutureJoin2Aggregate(FutureError[int](errFailed), FutureError[string](errFailed)).Get(context.Background())
+	var agg *AggregateError
+	if !errors.As(err, &agg) || len(agg.Errors) != 2 {
+		t.Errorf("FutureJoin2Aggregate() error = %v, want 2 failures", err)
+	}
+
+	settled, err := FutureJoin2Settled(FutureOf(1), FutureError[string](errFailed)).Get(context.Background())
+	if err != nil {
+		t.Fatalf("FutureJoin2Settled() returned unexpected error: %v", err)
+	}
+	if v, err := settled.First.Value(); v != 1 || err != nil {
+		t.Errorf("First = (%v, %v), want (1, nil)", v, err)
+	}
+	if _, err := settled.Second.Value(); err != errFailed {
+		t.Errorf("Second error = %v, want %v", err, errFailed)
+	}
+}
+
+func TestFutureJoinModes_AllArities(t *testing.T) {
+	errFailed := errors.New("failed")
+	ok := FutureOf(1)
+	bad := FutureError[int](errFailed)
+	ctx := context.Background()
+
+	// The last input fails: FailFast and Aggregate report its index, Settled keeps it
+	checks := []struct {
+		name      string
+		lastIndex int
+		failFast  func() error
+		agg       func() error
+		settled   func() (error, error) // Error of the future, error of the last result
+	}{
+		{"3", 2,
+			func() error { _, err := FutureJoin3FailFast(ok, ok, bad).Get(ctx); return err },
+			func() error { _, err := FutureJoin3Aggregate(ok, ok, bad).Get(ctx); return err },
+			func() (error, error) {
+				r, err := FutureJoin3Settled(ok, ok, bad).Get(ctx)
+				_, last := r.Third.Value()
+				return err, last
+			},
+		},
+		{"4", 3,
+			func() error { _, err := FutureJoin4FailFast(ok, ok, ok, bad).Get(ctx); return err },
+			func() error { _, err := FutureJoin4Aggregate(ok, ok, ok, bad).Get(ctx); return err },
+			func() (error, error) {
+				r, err := FutureJoin4Settled(ok, ok, ok, bad).Get(ctx)
+				_, last := r.Fourth.Value()
+				return err, last
+			},
+		},
+		{"5", 4,
+			func() error { _, err := FutureJoin5FailFast(ok, ok, ok, ok, bad).Get(ctx); return err },
+			func() error { _, err := FutureJoin5Aggregate(ok, ok, ok, ok, bad).Get(ctx); return err },
+			func() (error, error) {
+				r, err := FutureJoin5Settled(ok, ok, ok, ok, bad).Get(ctx)
+				_, last := r.V5.Value()
+				return err, last
+			},
+		},
+		{"6", 5,
+			func() error { _, err := FutureJoin6FailFast(ok, ok, ok, ok, ok, bad).Get(ctx); return err },
+			func() error { _, err := FutureJoin6Aggregate(ok, ok, ok, ok, ok, bad).Get(ctx); return err },
+			func() (error, error) {
+				r, err := FutureJoin6Settled(ok, ok, ok, ok, ok, bad).Get(ctx)
+				_, last := r.V6.Value()
+				return err, last
+			},
+		},
+		{"7", 6,
+			func() error { _, err := FutureJoin7FailFast(ok, ok, ok, ok, ok, ok, bad).Get(ctx); return err },
+			func() error { _, err := FutureJoin7Aggregate(ok, ok, ok, ok, ok, ok, bad).Get(ctx); return err },
+			func() (error, error) {
+				r, err := FutureJoin7Settled(ok, ok, ok, ok, ok, ok, bad).Get(ctx)
+				_, last := r.V7.Value()
+				return err, last
+			},
+		},
+		{"8", 7,
+			func() error { _, err := FutureJoin8FailFast(ok, ok, ok, ok, ok, ok, ok, bad).Get(ctx); return err },
+			func() error { _, err := FutureJoin8Aggregate(ok, ok, ok, ok, ok, ok, ok, bad).Get(ctx); return err },
+			func() (error, error) {
+				r, err := FutureJoin8Settled(ok, ok, ok, ok, ok, ok, ok, bad).Get(ctx)
+				_, last := r.V8.Value()
+				return err, last
+			},
+		},
+	}
+
+	for _, c := range checks {
+		t.Run(c.name, func(t *testing.T) {
+			var failed *IndexedError
+			if err := c.failFast(); !errors.As(err, &failed) || failed.Index != c.lastIndex {
+				t.Errorf("FailFast error = %v, want index %d", err, c.lastIndex)
+			}
+			var agg *AggregateError
+			if err := c.agg(); !errors.As(err, &agg) || len(agg.Errors) != 1 || agg.Errors[0].Index != c.lastIndex {
+				t.Errorf("Aggregate error = %v, want index %d", err, c.lastIndex)
+			}
+			if err, last := c.settled(); err != nil || last != errFailed {
+				t.Errorf("Settled = (%v, %v), want (nil, %v)", err, last, errFailed)
+			}
+		})
+	}
+}
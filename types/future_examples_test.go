@@ -355,3 +355,124 @@ func ExampleFutureJoin5_parallel() {
 	// Services: service1, service2, service3, service4, service5
 	// Completed in: ~100ms (parallel)
 }
+
+// Example of tying a computation to a request context
+func ExampleFutureDoCtx() {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	future := lxtypes.FutureDoCtx(ctx, func(ctx context.Context) (string, error) {
+		select {
+		case <-time.After(5 * time.Second):
+			return "completed", nil
+		case <-ctx.Done():
+			return "", ctx.Err() // Stops the outbound call
+		}
+	})
+	user := lxtypes.FutureThen(future, func(body string) (string, error) {
+		return "user from " + body, nil
+	})
+
+	_, err := user.Get(context.Background())
+	fmt.Println(err)
+	// Output: context deadline exceeded
+}
+
+// Example of collecting every failure with its index
+func ExampleFutureAllAggregate() {
+	f1 := lxtypes.FutureOf(1)
+	f2 := lxtypes.FutureError[int](errors.New("timeout"))
+	f3 := lxtypes.FutureError[int](errors.New("not found"))
+
+	_, err := lxtypes.FutureAllAggregate(f1, f2, f3).Get(context.Background())
+	fmt.Println(err)
+	// Output: lxtypes: 2 futures failed: future 1: timeout; future 2: not found
+}
+
+// Example of inspecting the outcome of every future
+func ExampleFutureAllSettled() {
+	f1 := lxtypes.FutureOf(1)
+	f2 := lxtypes.FutureError[int](errors.New("timeout"))
+
+	results, _ := lxtypes.FutureAllSettled(f1, f2).Get(context.Background())
+	for i, r := range results {
+		value, err := r.Value()
+		fmt.Println(i, value, err)
+	}
+	// Output:
+	// 0 1 <nil>
+	// 1 0 timeout
+}
+
+// Example of retrying a flaky call with exponential backoff
+func ExampleFutureRetry() {
+	attempts := 0
+	policy := lxtypes.RetryPolicy{MaxAttempts: 5, InitialDelay: time.Millisecond}
+
+	future := lxtypes.FutureRetry(context.Background(), policy, func(ctx context.Context) (string, error) {
+		attempts++
+		if attempts < 3 {
+			return "", errors.New("unavailable")
+		}
+		return "ok", nil
+	})
+
+	result, err := future.Get(context.Background())
+	fmt.Println(result, err, attempts)
+	// Output: ok <nil> 3
+}
+
+// Example of replacing a failure with a default value
+func ExampleFutureRecover() {
+	remote := lxtypes.FutureError[string](errors.New("unreachable"))
+	config := lxtypes.FutureRecover(remote, func(err error) (string, error) {
+		return "defaults", nil
+	})
+
+	value, _ := config.Get(context.Background())
+	fmt.Println(value)
+	// Output: defaults
+}
+
+// Example of bounding how long a future may take
+func ExampleFutureTimeout() {
+	slow := lxtypes.FutureDoCtx(context.Background(), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	defer slow.Cancel() // The timeout leaves slow running
+
+	_, err := lxtypes.FutureTimeout(slow, 10*time.Millisecond).Get(context.Background())
+	fmt.Println(errors.Is(err, lxtypes.ErrTimeout))
+	// Output: true
+}
+
+// Example of reacting to completion without blocking
+func ExampleFuture_OnComplete() {
+	future := lxtypes.FutureOf(42)
+	future.OnSuccess(func(v int) { fmt.Println("success:", v) })
+	future.OnFailure(func(err error) { fmt.Println("failure:", err) })
+
+	value, err, ok := future.TryGet()
+	fmt.Println(value, err, ok)
+	// Output:
+	// success: 42
+	// 42 <nil> true
+}
+
+// Example of waiting for a future in a select loop
+func ExampleFuture_Done() {
+	future := lxtypes.FutureDo(func() (string, error) {
+		time.Sleep(10 * time.Millisecond)
+		return "ready", nil
+	})
+
+	select {
+	case <-future.Done():
+		value, _, _ := future.TryGet()
+		fmt.Println(value)
+	case <-time.After(time.Second):
+		fmt.Println("timed out")
+	}
+	// Output: ready
+}
@@ -0,0 +1,238 @@
+package lxtypes
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// ErrTimeout is the error of a future returned by FutureTimeout when its
+// input does not complete in time.
+var ErrTimeout = errors.New("lxtypes: future timed out")
+
+// Clock provides timers to FutureRetry, so that tests can run backoff delays
+// without waiting for them.
+type Clock interface {
+	// After returns a channel that receives the current time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock implements Clock with the time package.
+type systemClock struct{}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock returns the Clock backed by the time package, used when a
+// RetryPolicy does not set one.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// RetryPolicy configures FutureRetry. The zero value makes up to 3 attempts
+// with no delay between them and retries every error.
+//
+// The delay before attempt n+1 is InitialDelay * Multiplier^(n-1), capped at
+// MaxDelay, then reduced by a random fraction of up to Jitter so that
+// clients retrying together spread out.
+//
+// Example:
+//
+//	policy := RetryPolicy{
+//	    MaxAttempts:  5,
+//	    InitialDelay: 100 * time.Millisecond,
+//	    MaxDelay:     2 * time.Second,
+//	    Jitter:       0.2,
+//	    RetryIf: func(err error) bool {
+//	        return !errors.Is(err, ErrNotFound)
+//	    },
+//	}
+type RetryPolicy struct {
+	MaxAttempts  int              // Attempts including the first one; 3 if not positive
+	InitialDelay time.Duration    // Delay after the first failed attempt
+	MaxDelay     time.Duration    // Upper bound of the delay; no bound if not positive
+	Multiplier   float64          // Growth factor of the delay; 2 if not positive
+	Jitter       float64          // Fraction in [0, 1] of the delay that is randomised
+	RetryIf      func(error) bool // Reports whether an error is worth retrying; nil retries every error
+	Clock        Clock            // Source of timers; SystemClock() if nil
+}
+
+// Delay returns the delay before the attempt following the failed attempt
+// number attempt, starting at 1, including jitter.
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	if p.InitialDelay <= 0 {
+		return 0
+	}
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	d := float64(p.InitialDelay) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxDelay > 0 && d > float64(p.MaxDelay) {
+		d = float64(p.MaxDelay)
+	}
+	if p.Jitter > 0 {
+		d -= d * math.Min(p.Jitter, 1) * rand.Float64()
+	}
+	if d >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(d)
+}
+
+// maxAttempts returns MaxAttempts or its default.
+func (p RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts <= 0 {
+		return 3
+	}
+	return p.MaxAttempts
+}
+
+// shouldRetry reports whether err is worth another attempt.
+func (p RetryPolicy) shouldRetry(err error) bool {
+	return p.RetryIf == nil || p.RetryIf(err)
+}
+
+// clock returns Clock or the system clock.
+func (p RetryPolicy) clock() Clock {
+	if p.Clock == nil {
+		return SystemClock()
+	}
+	return p.Clock
+}
+
+// FutureRetry creates a Future that calls fn until it succeeds, following
+// policy. The future fails with the error of the last attempt once the
+// attempts are exhausted or policy.RetryIf rejects an error.
+//
+// As with FutureDoCtx, fn receives a context derived from ctx: when ctx is
+// done or the future is cancelled, the current attempt is cancelled, no
+// further attempt is made and the future completes with the context error.
+//
+// Example:
+//
+//	user := FutureRetry(ctx, RetryPolicy{MaxAttempts: 4, InitialDelay: 50 * time.Millisecond},
+//	    func(ctx context.Context) (User, error) {
+//	        return client.FetchUser(ctx, id)
+//	    })
+//	card := FutureThen(user, func(u User) (Card, error) { return fetchCard(u.CardId) })
+func FutureRetry[T any](ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) (T, error)) Future[T] {
+	return FutureDoCtx(ctx, func(ctx context.Context) (T, error) {
+		return retry(ctx, policy, fn)
+	})
+}
+
+// retry calls fn until it succeeds or policy gives up.
+func retry[T any](ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) (T, error)) (T, error) {
+	clock := policy.clock()
+	for attempt := 1; ; attempt++ {
+		value, err := fn(ctx)
+		if err != nil && ctx.Err() != nil {
+			var zero T
+			return zero, ctx.Err()
+		}
+		if err == nil || attempt >= policy.maxAttempts() || !policy.shouldRetry(err) {
+			return value, err
+		}
+
+		select {
+		case <-clock.After(policy.Delay(attempt)):
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// FutureTimeout returns a Future that completes like f if f completes within
+// d, or fails with ErrTimeout otherwise.
+//
+// A timeout only ends this view of f: f may be shared, so it keeps running
+// and its other readers still get its result. To stop the computation too,
+// give it a deadline, e.g. with FutureDoCtx and context.WithTimeout.
+// Cancelling the returned future releases f like any derived future.
+//
+// Example:
+//
+//	profile := FutureTimeout(FutureDoCtx(ctx, fetchProfile), 200*time.Millisecond)
+//	p, err := profile.Get(ctx)
+//	if errors.Is(err, ErrTimeout) {
+//	    // Render without the profile
+//	}
+func FutureTimeout[T any](f Future[T], d time.Duration) Future[T] {
+	out, _ := newFuture[T](baseOf(f), []canceler{f})
+	timer := time.AfterFunc(d, func() {
+		var zero T
+		out.complete(zero, ErrTimeout) // Leaves f alone
+	})
+	go func() {
+		value, err := f.Get(context.Background())
+		timer.Stop()
+		out.complete(value, err)
+	}()
+	return out
+}
+
+// FutureRecover returns a Future that completes like f if f succeeds, or with
+// the result of fn applied to the error of f otherwise. fn may return a
+// replacement value, or an error to keep failing.
+//
+// Cancelling the returned future cancels f, and fn does not recover from
+// that cancellation.
+//
+// Example:
+//
+//	config := FutureRecover(fetchRemoteConfig(), func(err error) (Config, error) {
+//	    if errors.Is(err, ErrNotFound) {
+//	        return defaultConfig, nil
+//	    }
+//	    return Config{}, err
+//	})
+func FutureRecover[T any](f Future[T], fn func(error) (T, error)) Future[T] {
+	return derive(baseOf(f), []canceler{f}, InlineExecutor(), func(context.Context) (T, error) {
+		value, err := f.Get(context.Background())
+		if err != nil {
+			return fn(err)
+		}
+		return value, nil
+	})
+}
+
+// FutureFallback returns a Future that completes like primary if it
+// succeeds, and like secondary otherwise. Since futures start immediately,
+// secondary runs alongside primary; it is not cancelled when primary
+// succeeds, since other code may still read it. Use FutureRecover to start a
+// fallback only after primary has failed.
+//
+// If both fail, the future fails with an *AggregateError holding both errors.
+// Cancelling the returned future releases primary and secondary like any
+// derived future.
+//
+// Example:
+//
+//	price := FutureFallback(
+//	    FutureDoCtx(ctx, fetchLivePrice),
+//	    FutureDoCtx(ctx, fetchCachedPrice),
+//	)
+func FutureFallback[T any](primary, secondary Future[T]) Future[T] {
+	out, _ := newFuture[T](baseOf(primary), []canceler{primary, secondary})
+	go func() {
+		value, err := primary.Get(context.Background())
+		if err == nil {
+			out.complete(value, nil)
+			return
+		}
+
+		fallback, fallbackErr := secondary.Get(context.Background())
+		if fallbackErr != nil {
+			var zero T
+			out.complete(zero, NewAggregateError([]error{err, fallbackErr}))
+			return
+		}
+		out.complete(fallback, nil)
+	}()
+	return out
+}
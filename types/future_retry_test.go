@@ -0,0 +1,305 @@
+package lxtypes
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose timers fire immediately, unless it is blocked,
+// and which records every delay it is asked for.
+type fakeClock struct {
+	mu      sync.Mutex
+	delays  []time.Duration
+	blocked bool // Timers never fire
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.delays = append(c.delays, d)
+	if c.blocked {
+		return nil
+	}
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	return ch
+}
+
+func (c *fakeClock) recorded() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]time.Duration(nil), c.delays...)
+}
+
+// flaky returns a function that fails until it has been called n times, and
+// a pointer to its call count.
+func flaky(n int32, err error) (func(context.Context) (int, error), *int32) {
+	var calls int32
+	return func(context.Context) (int, error) {
+		if c := atomic.AddInt32(&calls, 1); c < n {
+			return 0, err
+		}
+		return 42, nil
+	}, &calls
+}
+
+// ========================================
+// FutureRetry Tests
+// ========================================
+
+func TestFutureRetry(t *testing.T) {
+	errTemporary := errors.New("temporary")
+	errPermanent := errors.New("permanent")
+
+	tests := []struct {
+		name       string
+		policy     RetryPolicy
+		succeedOn  int32
+		err        error
+		wantValue  int
+		wantErr    error
+		wantCalls  int32
+		wantDelays []time.Duration
+	}{
+		{
+			name:       "succeeds after retries",
+			policy:     RetryPolicy{MaxAttempts: 5, InitialDelay: 10 * time.Millisecond},
+			succeedOn:  3,
+			err:        errTemporary,
+			wantValue:  42,
+			wantCalls:  3,
+			wantDelays: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond},
+		},
+		{
+			name:       "attempts exhausted",
+			policy:     RetryPolicy{MaxAttempts: 3, InitialDelay: time.Second, Multiplier: 3},
+			succeedOn:  10,
+			err:        errTemporary,
+			wantErr:    errTemporary,
+			wantCalls:  3,
+			wantDelays: []time.Duration{time.Second, 3 * time.Second},
+		},
+		{
+			name:       "default attempts",
+			succeedOn:  10,
+			err:        errTemporary,
+			wantErr:    errTemporary,
+			wantCalls:  3,
+			wantDelays: []time.Duration{0, 0},
+		},
+		{
+			name: "not retryable",
+			policy: RetryPolicy{MaxAttempts: 5, RetryIf: func(err error) bool {
+				return !errors.Is(err, errPermanent)
+			}},
+			succeedOn: 10,
+			err:       errPermanent,
+			wantErr:   errPermanent,
+			wantCalls: 1,
+		},
+		{
+			name:      "first attempt succeeds",
+			policy:    RetryPolicy{MaxAttempts: 5},
+			succeedOn: 1,
+			wantValue: 42,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{}
+			tt.policy.Clock = clock
+			fn, calls := flaky(tt.succeedOn, tt.err)
+
+			value, err := FutureRetry(context.Background(), tt.policy, fn).Get(context.Background())
+			if value != tt.wantValue || err != tt.wantErr {
+				t.Errorf("Get() = (%v, %v), want (%v, %v)", value, err, tt.wantValue, tt.wantErr)
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("fn called %d times, want %d", *calls, tt.wantCalls)
+			}
+			if delays := clock.recorded(); !reflect.DeepEqual(delays, tt.wantDelays) {
+				t.Errorf("delays = %v, want %v", delays, tt.wantDelays)
+			}
+		})
+	}
+}
+
+func TestFutureRetry_CancelDuringBackoff(t *testing.T) {
+	clock := &fakeClock{blocked: true}
+	fn, calls := flaky(10, errors.New("temporary"))
+
+	future := FutureRetry(context.Background(), RetryPolicy{MaxAttempts: 5, Clock: clock}, fn)
+	for len(clock.recorded()) == 0 {
+		time.Sleep(time.Millisecond) // Wait for the first backoff
+	}
+	future.Cancel()
+
+	if _, err := future.Get(context.Background()); err != context.Canceled {
+		t.Errorf("Get() error = %v, want %v", err, context.Canceled)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if n := atomic.LoadInt32(calls); n != 1 {
+		t.Errorf("fn called %d times after Cancel, want 1", n)
+	}
+}
+
+func TestFutureRetry_ContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	fn, _ := flaky(1000, errors.New("temporary"))
+
+	policy := RetryPolicy{MaxAttempts: 1000, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	if _, err := FutureRetry(ctx, policy, fn).Get(context.Background()); err != context.DeadlineExceeded {
+		t.Errorf("Get() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		if got := policy.Delay(attempt + 1); got != want {
+			t.Errorf("Delay(%d) = %v, want %v", attempt+1, got, want)
+		}
+	}
+
+	unbounded := RetryPolicy{InitialDelay: time.Second}
+	if got := unbounded.Delay(1000); got != time.Duration(1<<63-1) {
+		t.Errorf("Delay(1000) = %v, want the maximum duration", got)
+	}
+
+	jittered := RetryPolicy{InitialDelay: time.Second, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		if got := jittered.Delay(1); got < 500*time.Millisecond || got > time.Second {
+			t.Fatalf("Delay(1) with jitter = %v, want within [500ms, 1s]", got)
+		}
+	}
+}
+
+// ========================================
+// FutureTimeout Tests
+// ========================================
+
+func TestFutureTimeout(t *testing.T) {
+	slow, stopped := blockingFuture(context.Background())
+	timed := FutureTimeout(slow, 10*time.Millisecond)
+
+	if _, err := timed.Get(context.Background()); err != ErrTimeout {
+		t.Errorf("Get() error = %v, want %v", err, ErrTimeout)
+	}
+	if slow.IsDone() {
+		t.Error("FutureTimeout() cancelled its input on timeout")
+	}
+	slow.Cancel()
+	waitStopped(t, "input future", stopped)
+
+	fast := FutureTimeout(FutureOf(42), time.Second)
+	if value, err := fast.Get(context.Background()); value != 42 || err != nil {
+		t.Errorf("Get() = (%v, %v), want (42, nil)", value, err)
+	}
+}
+
+func TestFutureTimeout_KeepsSharedInput(t *testing.T) {
+	release := make(chan struct{})
+	shared := FutureDo(func() (int, error) {
+		<-release
+		return 7, nil
+	})
+
+	if _, err := FutureTimeout(shared, 10*time.Millisecond).Get(context.Background()); err != ErrTimeout {
+		t.Errorf("Get() error = %v, want %v", err, ErrTimeout)
+	}
+	close(release)
+	if value, err := shared.Get(context.Background()); value != 7 || err != nil {
+		t.Errorf("shared Get() = (%v, %v), want (7, nil)", value, err)
+	}
+}
+
+func TestFutureTimeout_ComposesWithThen(t *testing.T) {
+	slow, stopped := blockingFuture(context.Background())
+	chained := FutureThen(FutureTimeout(slow, time.Hour), func(v int) (int, error) {
+		return v + 1, nil
+	})
+
+	chained.Cancel()
+	waitStopped(t, "input future", stopped)
+}
+
+// ========================================
+// FutureRecover and FutureFallback Tests
+// ========================================
+
+func TestFutureRecover(t *testing.T) {
+	errFailed := errors.New("failed")
+	errStill := errors.New("still failing")
+
+	tests := []struct {
+		name      string
+		input     Future[int]
+		recover   func(error) (int, error)
+		wantValue int
+		wantErr   error
+	}{
+		{"success passes through", FutureOf(1), func(error) (int, error) { return 2, nil }, 1, nil},
+		{"recovers", FutureError[int](errFailed), func(error) (int, error) { return 2, nil }, 2, nil},
+		{"keeps failing", FutureError[int](errFailed), func(err error) (int, error) { return 0, errStill }, 0, errStill},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := FutureRecover(tt.input, tt.recover).Get(context.Background())
+			if value != tt.wantValue || err != tt.wantErr {
+				t.Errorf("Get() = (%v, %v), want (%v, %v)", value, err, tt.wantValue, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFutureRecover_Cancel(t *testing.T) {
+	slow, stopped := blockingFuture(context.Background())
+	var recovered int32
+	future := FutureRecover(slow, func(error) (int, error) {
+		atomic.StoreInt32(&recovered, 1)
+		return 0, nil
+	})
+
+	future.Cancel()
+	waitStopped(t, "input future", stopped)
+	if _, err := future.Get(context.Background()); err != context.Canceled {
+		t.Errorf("Get() error = %v, want %v", err, context.Canceled)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if atomic.LoadInt32(&recovered) != 0 {
+		t.Error("FutureRecover() recovered from its own cancellation")
+	}
+}
+
+func TestFutureFallback(t *testing.T) {
+	errPrimary := errors.New("primary")
+	errSecondary := errors.New("secondary")
+
+	secondary, stopped := blockingFuture(context.Background())
+	if value, err := FutureFallback(FutureOf(1), secondary).Get(context.Background()); value != 1 || err != nil {
+		t.Errorf("Get() = (%v, %v), want (1, nil)", value, err)
+	}
+	if secondary.IsDone() {
+		t.Error("FutureFallback() cancelled secondary after primary succeeded")
+	}
+	secondary.Cancel()
+	waitStopped(t, "secondary", stopped)
+
+	if value, err := FutureFallback(FutureError[int](errPrimary), FutureOf(2)).Get(context.Background()); value != 2 || err != nil {
+		t.Errorf("Get() = (%v, %v), want (2, nil)", value, err)
+	}
+
+	_, err := FutureFallback(FutureError[int](errPrimary), FutureError[int](errSecondary)).Get(context.Background())
+	if !errors.Is(err, errPrimary) || !errors.Is(err, errSecondary) {
+		t.Errorf("Get() error = %v, want both errors", err)
+	}
+}
@@ -3,6 +3,7 @@ package lxtypes
 import (
 	"context"
 	"errors"
+	"reflect"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -1469,3 +1470,424 @@ func TestFutureAny_Empty(t *testing.T) {
 		t.Fatalf("Get() expected error for empty futures, got nil")
 	}
 }
+
+// ========================================
+// Cancellation Tests
+// ========================================
+
+// blockingFuture starts a FutureDoCtx that blocks until its context is
+// cancelled and closes stopped when it returns. It returns once the
+// computation is running.
+func blockingFuture(ctx context.Context) (Future[int], chan struct{}) {
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	f := FutureDoCtx(ctx, func(ctx context.Context) (int, error) {
+		defer close(stopped)
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	<-started
+	return f, stopped
+}
+
+// waitStopped fails the test if stopped is not closed in time.
+func waitStopped(t *testing.T, name string, stopped chan struct{}) {
+	t.Helper()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatalf("%s was not cancelled", name)
+	}
+}
+
+func TestFutureDoCtx_Success(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	future := FutureDoCtx(ctx, func(ctx context.Context) (string, error) {
+		return ctx.Value(key{}).(string), nil
+	})
+
+	result, err := future.Get(context.Background())
+	if err != nil || result != "value" {
+		t.Errorf("Get() = (%v, %v), want (value, nil)", result, err)
+	}
+}
+
+func TestFutureDoCtx_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	future, stopped := blockingFuture(ctx)
+	_, err := future.Get(context.Background())
+	if err != context.DeadlineExceeded {
+		t.Errorf("Get() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	waitStopped(t, "computation", stopped)
+}
+
+func TestFutureDoCtx_ContextAlreadyDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	future := FutureDoCtx(ctx, func(ctx context.Context) (int, error) {
+		return 42, nil
+	})
+	if _, err := future.Get(context.Background()); err != context.Canceled {
+		t.Errorf("Get() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestFutureDoCtx_CompletesWithoutWaitingForFn(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+
+	future := FutureDoCtx(ctx, func(context.Context) (int, error) {
+		<-release // ignores its context
+		return 42, nil
+	})
+	cancel()
+
+	_, err := future.Get(context.Background())
+	if err != context.Canceled {
+		t.Errorf("Get() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestFuture_Cancel(t *testing.T) {
+	future, stopped := blockingFuture(context.Background())
+	future.Cancel()
+	future.Cancel() // No effect
+
+	_, err := future.Get(context.Background())
+	if err != context.Canceled {
+		t.Errorf("Get() error = %v, want %v", err, context.Canceled)
+	}
+	waitStopped(t, "computation", stopped)
+}
+
+func TestFuture_CancelCompleted(t *testing.T) {
+	futures := []Future[int]{
+		FutureOf(42),
+		FutureError[int](errors.New("failed")),
+		FutureDo(func() (int, error) { return 42, nil }),
+	}
+
+	for _, future := range futures {
+		want, wantErr := future.Get(context.Background())
+		future.Cancel()
+		got, err := future.Get(context.Background())
+		if got != want || err != wantErr {
+			t.Errorf("Get() after Cancel() = (%v, %v), want (%v, %v)", got, err, want, wantErr)
+		}
+	}
+}
+
+func TestFutureThen_CancelPropagatesToParent(t *testing.T) {
+	parent, stopped := blockingFuture(context.Background())
+	child := FutureThen(parent, func(v int) (int, error) {
+		return v * 2, nil
+	})
+	grandchild := FutureThen(child, func(v int) (string, error) {
+		return "done", nil
+	})
+
+	grandchild.Cancel()
+
+	waitStopped(t, "parent", stopped)
+	if _, err := child.Get(context.Background()); err != context.Canceled {
+		t.Errorf("child Get() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestFutureThen_CancelKeepsSharedParent(t *testing.T) {
+	parent, stopped := blockingFuture(context.Background())
+	a := FutureThen(parent, func(v int) (int, error) { return v, nil })
+	b := FutureThen(parent, func(v int) (int, error) { return v, nil })
+
+	a.Cancel()
+
+	select {
+	case <-stopped:
+		t.Fatal("cancelling one consumer cancelled the shared parent")
+	case <-time.After(10 * time.Millisecond):
+	}
+	if b.IsDone() {
+		t.Error("cancelling one consumer completed the other")
+	}
+
+	b.Cancel() // The last consumer
+	waitStopped(t, "parent", stopped)
+}
+
+func TestFutureThenCtx_InheritsParentContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	parent := FutureDoCtx(ctx, func(context.Context) (int, error) {
+		return 1, nil
+	})
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	child := FutureThenCtx(parent, func(ctx context.Context, v int) (int, error) {
+		defer close(stopped)
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	<-started // The parent has completed
+
+	cancel()
+
+	if _, err := child.Get(context.Background()); err != context.Canceled {
+		t.Errorf("child Get() error = %v, want %v", err, context.Canceled)
+	}
+	waitStopped(t, "child", stopped)
+}
+
+func TestFutureAll_CancelPropagates(t *testing.T) {
+	f1, stopped1 := blockingFuture(context.Background())
+	f2, stopped2 := blockingFuture(context.Background())
+
+	all := FutureAll(f1, f2)
+	all.Cancel()
+
+	if _, err := all.Get(context.Background()); err != context.Canceled {
+		t.Errorf("Get() error = %v, want %v", err, context.Canceled)
+	}
+	waitStopped(t, "f1", stopped1)
+	waitStopped(t, "f2", stopped2)
+}
+
+func TestFutureJoin_CancelPropagates(t *testing.T) {
+	f1, stopped1 := blockingFuture(context.Background())
+	f2, stopped2 := blockingFuture(context.Background())
+	f3 := FutureOf("done")
+
+	FutureJoin3(f1, f2, f3).Cancel()
+
+	waitStopped(t, "f1", stopped1)
+	waitStopped(t, "f2", stopped2)
+	if v, err := f3.Get(context.Background()); v != "done" || err != nil {
+		t.Errorf("completed input Get() = (%v, %v), want (done, nil)", v, err)
+	}
+}
+
+func TestFutureAny_CancelsLosers(t *testing.T) {
+	slow, stopped := blockingFuture(context.Background())
+	fast := FutureDo(func() (int, error) { return 2, nil })
+
+	val, err := FutureAny(slow, fast).Get(context.Background())
+	if err != nil || val != 2 {
+		t.Fatalf("Get() = (%v, %v), want (2, nil)", val, err)
+	}
+	waitStopped(t, "losing branch", stopped)
+}
+
+func TestFutureAny_KeepsSharedLosers(t *testing.T) {
+	slow, stopped := blockingFuture(context.Background())
+	other := FutureThen(slow, func(v int) (int, error) { return v, nil })
+	fast := FutureOf(2)
+
+	if val, err := FutureAny(slow, fast).Get(context.Background()); err != nil || val != 2 {
+		t.Fatalf("Get() = (%v, %v), want (2, nil)", val, err)
+	}
+	select {
+	case <-stopped:
+		t.Fatal("FutureAny cancelled a losing branch still needed by another future")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	other.Cancel()
+	waitStopped(t, "losing branch", stopped)
+}
+
+func TestFutureAny_CancelPropagates(t *testing.T) {
+	f1, stopped1 := blockingFuture(context.Background())
+	f2, stopped2 := blockingFuture(context.Background())
+
+	FutureAny(f1, f2).Cancel()
+
+	waitStopped(t, "f1", stopped1)
+	waitStopped(t, "f2", stopped2)
+}
+
+// ========================================
+// Inspection and Callback Tests
+// ========================================
+
+func TestFuture_IsDoneTryGet(t *testing.T) {
+	future, _ := blockingFuture(context.Background())
+	if future.IsDone() {
+		t.Error("IsDone() = true before completion")
+	}
+	if value, err, ok := future.TryGet(); ok || value != 0 || err != nil {
+		t.Errorf("TryGet() = (%v, %v, %v), want (0, nil, false)", value, err, ok)
+	}
+
+	future.Cancel()
+	if !future.IsDone() {
+		t.Error("IsDone() = false after Cancel()")
+	}
+	if _, err, ok := future.TryGet(); !ok || err != context.Canceled {
+		t.Errorf("TryGet() = (_, %v, %v), want (_, %v, true)", err, ok, context.Canceled)
+	}
+
+	if value, err, ok := FutureOf(42).TryGet(); !ok || value != 42 || err != nil {
+		t.Errorf("TryGet() = (%v, %v, %v), want (42, nil, true)", value, err, ok)
+	}
+}
+
+func TestFuture_DoneInSelect(t *testing.T) {
+	release := make(chan struct{})
+	future := FutureDo(func() (int, error) {
+		<-release
+		return 42, nil
+	})
+
+	select {
+	case <-future.Done():
+		t.Fatal("Done() closed before completion")
+	default:
+	}
+
+	close(release)
+	select {
+	case <-future.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done() not closed after completion")
+	}
+	if value, _, ok := future.TryGet(); !ok || value != 42 {
+		t.Errorf("TryGet() = (%v, _, %v), want (42, _, true)", value, ok)
+	}
+}
+
+func TestFuture_OnComplete(t *testing.T) {
+	release := make(chan struct{})
+	future := FutureDo(func() (int, error) {
+		<-release
+		return 42, nil
+	})
+
+	var order []int
+	called := make(chan struct{})
+	future.OnComplete(func(v int, err error) { order = append(order, 1) })
+	future.OnComplete(func(v int, err error) {
+		order = append(order, 2)
+		close(called)
+	})
+	close(release)
+	waitStopped(t, "callbacks", called) // Get may return before the callbacks run
+
+	// Registered after completion: called right away in this goroutine
+	future.OnComplete(func(v int, err error) {
+		if v != 42 || err != nil {
+			t.Errorf("OnComplete() got (%v, %v), want (42, nil)", v, err)
+		}
+		order = append(order, 3)
+	})
+	if !reflect.DeepEqual(order, []int{1, 2, 3}) {
+		t.Errorf("callbacks called in order %v, want [1 2 3]", order)
+	}
+}
+
+func TestFuture_OnSuccessOnFailure(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name        string
+		future      Future[int]
+		wantSuccess []int
+		wantFailure []error
+	}{
+		{"success", FutureOf(42), []int{42}, nil},
+		{"failure", FutureError[int](errFailed), nil, []error{errFailed}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var successes []int
+			var failures []error
+			tt.future.OnSuccess(func(v int) { successes = append(successes, v) })
+			tt.future.OnFailure(func(err error) { failures = append(failures, err) })
+
+			if !reflect.DeepEqual(successes, tt.wantSuccess) {
+				t.Errorf("OnSuccess() got %v, want %v", successes, tt.wantSuccess)
+			}
+			if !reflect.DeepEqual(failures, tt.wantFailure) {
+				t.Errorf("OnFailure() got %v, want %v", failures, tt.wantFailure)
+			}
+		})
+	}
+}
+
+func TestFuture_OnFailureAfterCancel(t *testing.T) {
+	future, _ := blockingFuture(context.Background())
+	failed := make(chan error, 1)
+	future.OnFailure(func(err error) { failed <- err })
+
+	future.Cancel()
+	select {
+	case err := <-failed:
+		if err != context.Canceled {
+			t.Errorf("OnFailure() got %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnFailure() not called after Cancel()")
+	}
+}
+
+func TestFuture_OnCompleteSlowCallbackDoesNotDelayCancel(t *testing.T) {
+	parent, stopped := blockingFuture(context.Background())
+	child := FutureThen(parent, func(v int) (int, error) { return v, nil })
+
+	release := make(chan struct{})
+	defer close(release)
+	child.OnComplete(func(int, error) { <-release })
+
+	go child.Cancel()
+	waitStopped(t, "parent", stopped) // Before the callback returns
+}
+
+func TestFuture_OnCompletePanickingCallback(t *testing.T) {
+	f := &future[int]{done: make(chan struct{})}
+	var calls []int
+	f.OnComplete(func(int, error) { calls = append(calls, 1) })
+	f.OnComplete(func(int, error) { panic("boom") })
+	f.OnComplete(func(int, error) { calls = append(calls, 3) })
+
+	f.complete(42, nil) // Does not panic
+	if !reflect.DeepEqual(calls, []int{1, 3}) {
+		t.Errorf("callbacks called %v, want [1 3]", calls)
+	}
+	if result, err := f.Get(context.Background()); err != nil || result != 42 {
+		t.Errorf("Get() = (%v, %v), want (42, nil)", result, err)
+	}
+}
+
+func TestFuture_OnCompleteConcurrent(t *testing.T) {
+	release := make(chan struct{})
+	future := FutureDo(func() (int, error) {
+		<-release
+		return 42, nil
+	})
+
+	const n = 100
+	var calls int32
+	var called sync.WaitGroup
+	called.Add(n)
+	for i := 0; i < n; i++ {
+		go future.OnComplete(func(int, error) {
+			atomic.AddInt32(&calls, 1)
+			called.Done()
+		})
+		if i == n/2 {
+			close(release) // Completes while callbacks are being registered
+		}
+	}
+	called.Wait()
+
+	time.Sleep(5 * time.Millisecond)
+	if got := atomic.LoadInt32(&calls); got != n {
+		t.Errorf("callbacks called %d times, want %d", got, n)
+	}
+}